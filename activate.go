@@ -0,0 +1,193 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/rpdg/winput/window"
+)
+
+// ForegroundWindow returns the window the user is working with, which receives HID and
+// other global input. It returns ErrWindowNotFound if there is none, e.g. while the desktop
+// is switching or the secure desktop (UAC, Ctrl+Alt+Del) is shown.
+func ForegroundWindow() (*Window, error) {
+	hwnd := window.GetForegroundWindow()
+	if hwnd == 0 {
+		return nil, ErrWindowNotFound
+	}
+	return &Window{HWND: hwnd}, nil
+}
+
+// IsForeground reports whether the window, or the top-level window containing it, is the
+// foreground window.
+func (w *Window) IsForeground() bool {
+	fg := window.GetForegroundWindow()
+	return fg != 0 && fg == rootWindow(w.HWND)
+}
+
+// rootWindow returns the top-level window containing hwnd.
+func rootWindow(hwnd uintptr) uintptr {
+	if root := window.GetAncestor(hwnd, window.GA_ROOT); root != 0 {
+		return root
+	}
+	return hwnd
+}
+
+// Activate brings the top-level window containing w to the foreground and gives it keyboard
+// focus, which the HID backend and global input need. A minimized window is restored first.
+//
+// Windows only lets the foreground application, or the one that received the last input
+// event, change the foreground (the foreground lock). Activate attaches to the foreground
+// thread's input state and, if that is not enough, holds Alt around the request so the
+// calling process counts as the source of the last input; the Alt release may then open the
+// target's menu bar in some applications. It returns ErrActivateFailed if the window is
+// still not in the foreground afterwards.
+func (w *Window) Activate() error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.activate()
+}
+
+func (w *Window) activate() error {
+	root := rootWindow(w.HWND)
+	if window.IsIconic(root) {
+		window.ShowWindow(root, window.SW_RESTORE)
+	}
+	if window.GetForegroundWindow() == root {
+		return nil
+	}
+
+	// Thread input attachment applies to the calling OS thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	self := window.CurrentThreadID()
+	if fg := window.GetForegroundWindow(); fg != 0 {
+		if tid, _ := window.GetThreadProcessID(fg); tid != 0 && tid != self && window.AttachThreadInput(self, tid, true) {
+			defer window.AttachThreadInput(self, tid, false)
+		}
+	}
+
+	window.BringWindowToTop(root)
+	if setForeground(root) {
+		return nil
+	}
+	keybdEvent(KeyAlt, false)
+	window.SetForegroundWindow(root)
+	keybdEvent(KeyAlt, true)
+	if !waitForeground(root) {
+		return ErrActivateFailed
+	}
+	return nil
+}
+
+// Focus gives the window keyboard focus within its application, e.g. a child Edit control
+// of an application that routes keys by focus rather than by the window they are posted to.
+// It attaches to the window's thread input state to call SetFocus and detaches again
+// afterwards. It does not bring the application to the foreground; use Activate for that.
+// It returns ErrActivateFailed if the window does not have focus afterwards, e.g. because
+// it is disabled.
+func (w *Window) Focus() error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.focus()
+}
+
+func (w *Window) focus() error {
+	tid, _ := window.GetThreadProcessID(w.HWND)
+	if tid == 0 {
+		return ErrWindowGone
+	}
+	if err := w.setFocus(tid); err != nil {
+		return err
+	}
+	if window.ThreadFocus(tid) != w.HWND {
+		return ErrActivateFailed
+	}
+	return nil
+}
+
+// FocusedChild returns the control that has keyboard focus on the window's thread
+// (GetGUIThreadInfo), e.g. to check where typed keys would go. This is usually w or one of
+// its descendants, but can be another window of the same thread, such as an open dialog.
+// It returns ErrNoFocus if no window of the thread has focus.
+func (w *Window) FocusedChild() (*Window, error) {
+	tid, _ := window.GetThreadProcessID(w.HWND)
+	if tid == 0 {
+		return nil, ErrWindowGone
+	}
+	focus := window.ThreadFocus(tid)
+	if focus == 0 {
+		return nil, ErrNoFocus
+	}
+	return &Window{HWND: focus}, nil
+}
+
+// CaretPos returns the position of the text caret on the window's thread in w's client
+// coordinates: its left edge, halfway down, so Click(CaretPos()) lands on the caret's line.
+// Use it to click where text would be inserted, or to check that typing moved the caret.
+// It returns ErrNoCaret if the thread shows no caret, e.g. when no edit control has focus
+// or the application draws its own; callers can then fall back to coordinate clicks.
+func (w *Window) CaretPos() (x, y int32, err error) {
+	tid, _ := window.GetThreadProcessID(w.HWND)
+	if tid == 0 {
+		return 0, 0, ErrWindowGone
+	}
+	caret, rc := window.ThreadCaret(tid)
+	if caret == 0 {
+		return 0, 0, ErrNoCaret
+	}
+	sx, sy, err := window.ClientToScreen(caret, rc.Left, (rc.Top+rc.Bottom)/2)
+	if err != nil {
+		return 0, 0, err
+	}
+	return window.ScreenToClient(w.HWND, sx, sy)
+}
+
+// setFocus calls SetFocus attached to the thread tid that owns w.
+func (w *Window) setFocus(tid uint32) error {
+	// Thread input attachment applies to the calling OS thread.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if self := window.CurrentThreadID(); tid != self {
+		if !window.AttachThreadInput(self, tid, true) {
+			return fmt.Errorf("%w: cannot attach to thread %d", ErrActivateFailed, tid)
+		}
+		defer window.AttachThreadInput(self, tid, false)
+	}
+	window.SetFocus(w.HWND)
+	return nil
+}
+
+// Flash flashes the window's caption and taskbar button count times to get the operator's
+// attention, e.g. when a captcha or elevation prompt needs a human. Flash(0) flashes until
+// the window comes to the foreground. It does nothing if the window is already in the
+// foreground.
+func (w *Window) Flash(count uint32) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	root := rootWindow(w.HWND)
+	if window.GetForegroundWindow() == root {
+		return nil
+	}
+	flags := uint32(window.FLASHW_ALL)
+	if count == 0 {
+		flags |= window.FLASHW_TIMERNOFG
+	}
+	window.FlashWindow(root, flags, count)
+	return nil
+}
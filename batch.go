@@ -0,0 +1,139 @@
+//go:build windows
+
+package winput
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Step is one unit of work in a batch. Do receives the batch's Session and should send
+// input through it, so that keys it leaves held can be released if it fails.
+type Step struct {
+	// Name identifies the step in results and errors.
+	Name string
+	// Detail optionally records what the step attempts (coordinates, keys, text),
+	// copied verbatim into its StepResult.
+	Detail string
+	Do     func(s *Session) error
+}
+
+// BatchOptions configures RunBatch.
+type BatchOptions struct {
+	// ContinueOnError runs every step even if earlier ones fail, instead of stopping at
+	// the first failure.
+	ContinueOnError bool
+	// Session configures the session the batch runs under.
+	Session SessionOptions
+}
+
+// StepResult is the outcome of one Step.
+type StepResult struct {
+	Name     string
+	Detail   string
+	Err      error
+	Duration time.Duration
+	// Skipped is set for steps not run because an earlier step failed (without
+	// ContinueOnError) or the context was cancelled.
+	Skipped bool
+	// Sensitive is set for input issued under WithSensitive; Detail is redacted
+	// where winput produced it, but a Step's own Detail is copied as is.
+	Sensitive bool
+}
+
+// BatchResult holds one StepResult per Step, in order.
+type BatchResult struct {
+	Steps []StepResult
+}
+
+// Failed returns the results of the steps that ran and failed.
+func (r *BatchResult) Failed() []StepResult {
+	var out []StepResult
+	for _, s := range r.Steps {
+		if s.Err != nil && !s.Skipped {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Err returns nil if every step that ran succeeded, or a *BatchError otherwise.
+func (r *BatchResult) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	return &BatchError{Failed: failed, Total: len(r.Steps)}
+}
+
+// BatchError aggregates the failures of a batch. errors.Is and errors.As see
+// through it to each individual step error.
+type BatchError struct {
+	Failed []StepResult
+	Total  int
+}
+
+func (e *BatchError) Error() string {
+	parts := make([]string, len(e.Failed))
+	for i, s := range e.Failed {
+		parts[i] = fmt.Sprintf("%s: %v", s.Name, s.Err)
+	}
+	return fmt.Sprintf("%d of %d steps failed: %s", len(e.Failed), e.Total, strings.Join(parts, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, s := range e.Failed {
+		errs[i] = s.Err
+	}
+	return errs
+}
+
+// RunBatch runs steps in order under a single Session. After a failed step, any keys and
+// mouse buttons it left pressed through the session are released before the next step
+// runs, so one failure cannot leave a modifier or a button stuck for the rest of the batch.
+//
+// The returned error is the session acquisition error, BatchResult.Err(), or ctx.Err()
+// if the batch was cancelled before any step failed.
+// The result is never nil and always has one entry per step.
+func RunBatch(ctx context.Context, steps []Step, opts BatchOptions) (*BatchResult, error) {
+	res := &BatchResult{Steps: make([]StepResult, len(steps))}
+	sensitive := isSensitive()
+	for i, st := range steps {
+		res.Steps[i] = StepResult{Name: st.Name, Detail: st.Detail, Skipped: true, Sensitive: sensitive}
+	}
+
+	s, err := AcquireSessionWithOptions(ctx, opts.Session)
+	if err != nil {
+		return res, err
+	}
+	defer s.Release()
+
+	for i, st := range steps {
+		if err := ctx.Err(); err != nil {
+			if res.Err() == nil {
+				return res, err
+			}
+			break
+		}
+
+		start := time.Now()
+		err := st.Do(s)
+		res.Steps[i].Skipped = false
+		res.Steps[i].Duration = time.Since(start)
+		res.Steps[i].Err = err
+		if err == nil {
+			continue
+		}
+
+		if cerr := s.do(s.releaseHeld); cerr != nil {
+			res.Steps[i].Err = fmt.Errorf("%w (cleanup: %v)", err, cerr)
+		}
+		if !opts.ContinueOnError {
+			break
+		}
+	}
+	return res, res.Err()
+}
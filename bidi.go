@@ -0,0 +1,75 @@
+//go:build windows
+
+package winput
+
+import (
+	"time"
+	"unicode"
+
+	"github.com/rpdg/winput/hid"
+	"github.com/rpdg/winput/keyboard"
+	"github.com/rpdg/winput/window"
+)
+
+// SetBidiClipboard marks the window as mis-handling posted right-to-left or combining
+// WM_CHARs, so TypeBidi pastes via the clipboard instead. The setting is shared by every
+// Window with the same handle.
+func (w *Window) SetBidiClipboard(enabled bool) {
+	updateSettings(w.HWND, func(s *windowSettings) { s.bidiClipboard = enabled })
+}
+
+// TypeBidi types right-to-left, mixed-direction or combining-character text.
+// The text is normalized to NFC (so base letters and diacritics arrive as the target expects)
+// and sent in logical order with directional marks (U+200E, U+200F, ...) preserved.
+// It pastes via the clipboard (overwriting it) under the HID backend, which cannot type
+// characters absent from the keyboard layout, and for windows marked with SetBidiClipboard.
+func (w *Window) TypeBidi(text string) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.typeBidi(text)
+}
+
+func (w *Window) typeBidi(text string) error {
+	nfc, err := window.NormalizeNFC(text)
+	if err != nil {
+		return err
+	}
+	opts := TypeOptions{}
+	if getBackend() == BackendHID || (settingsFor(w.HWND).bidiClipboard && needsBidiCare(nfc)) {
+		opts.Strategy = TypeStrategyClipboard
+	}
+	return w.typeText(nfc, opts)
+}
+
+// needsBidiCare reports whether text contains right-to-left letters, combining marks
+// or formatting characters such as directional marks.
+func needsBidiCare(text string) bool {
+	for _, r := range text {
+		if unicode.In(r, unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Mn, unicode.Me, unicode.Cf) {
+			return true
+		}
+	}
+	return false
+}
+
+// pasteText puts text on the clipboard and pastes it: WM_PASTE under the Message backend,
+// a physical Ctrl+V under the HID backend.
+func pasteText(cb Backend, hwnd uintptr, text string) error {
+	if err := window.SetClipboardText(text); err != nil {
+		return err
+	}
+	if cb != BackendHID {
+		return keyboard.Paste(hwnd)
+	}
+	release, err := holdModifiers(ModCtrl)
+	if err != nil {
+		return err
+	}
+	defer release()
+	err = hid.Press(uint16(KeyV))
+	time.Sleep(10 * time.Millisecond)
+	return err
+}
@@ -0,0 +1,139 @@
+//go:build windows
+
+package winput
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+const wsExLayoutRTL = 0x00400000
+
+// BurstOptions configures BeginBurstWithOptions.
+type BurstOptions struct {
+	// TTL is how long a snapshot is trusted before it is re-resolved even without a
+	// move notification. 0 means the default (2s).
+	TTL time.Duration
+}
+
+var defaultBurstOptions = BurstOptions{
+	TTL: 2 * time.Second,
+}
+
+// burst is a snapshot of a window's client origin in screen coordinates.
+type burst struct {
+	mu     sync.Mutex
+	origin window.POINT
+	rect   window.RECT // window rect when origin was taken, checked before every use
+	taken  time.Time
+	ttl    time.Duration
+	stale  atomic.Bool // set by the WinEvent hook when the window or an ancestor moves
+	hook   *window.WinEventHook
+}
+
+// BeginBurst starts a burst for the window: its client origin is resolved once and
+// subsequent HID operations on the window (Move, Click, DoubleClick, ...) and
+// ClientToScreen compute screen coordinates from the snapshot instead of calling
+// ClientToScreen per operation.
+//
+// Before each use the snapshot is checked against the window's current rectangle
+// (GetWindowRect), so an operation right after the window or an ancestor moved does not use
+// a stale origin. It is also re-resolved when a location change is reported (e.g. a frame
+// change that keeps the rectangle), or after BurstOptions.TTL. Call EndBurst when done.
+func (w *Window) BeginBurst() error {
+	return w.BeginBurstWithOptions(defaultBurstOptions)
+}
+
+// BeginBurstWithOptions is BeginBurst with custom options.
+func (w *Window) BeginBurstWithOptions(opts BurstOptions) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = defaultBurstOptions.TTL
+	}
+	w.EndBurst()
+
+	// Mirrored (right-to-left) windows flip x, so the conversion is not a plain offset.
+	if window.GetWindowLong(w.HWND, window.GWL_EXSTYLE)&wsExLayoutRTL != 0 {
+		return nil
+	}
+
+	b := &burst{ttl: opts.TTL}
+	if err := b.refresh(w.HWND); err != nil {
+		return err
+	}
+
+	chain := map[uintptr]bool{}
+	for h := w.HWND; h != 0 && !chain[h]; h = window.GetAncestor(h, window.GA_PARENT) {
+		chain[h] = true
+	}
+	// Without the hook the burst still works, relying on the TTL alone.
+	b.hook, _ = window.HookWinEvents(w.HWND, window.EVENT_OBJECT_LOCATIONCHANGE, window.EVENT_OBJECT_LOCATIONCHANGE,
+		func(_ uint32, hwnd uintptr, idObject, _ int32) {
+			if idObject == window.OBJID_WINDOW && chain[hwnd] {
+				b.stale.Store(true)
+			}
+		})
+
+	updateSettings(w.HWND, func(s *windowSettings) { s.burst = b })
+	return nil
+}
+
+// EndBurst ends the window's burst, if any, so coordinates are resolved per operation again.
+func (w *Window) EndBurst() {
+	var b *burst
+	updateSettings(w.HWND, func(s *windowSettings) {
+		b, s.burst = s.burst, nil
+	})
+	if b != nil && b.hook != nil {
+		b.hook.Close()
+	}
+}
+
+func (b *burst) refresh(hwnd uintptr) error {
+	rect, err := window.GetWindowRect(hwnd)
+	if err != nil {
+		return err
+	}
+	x, y, err := window.ClientToScreen(hwnd, 0, 0)
+	if err != nil {
+		return err
+	}
+	b.origin = window.POINT{X: x, Y: y}
+	b.rect = rect
+	b.taken = time.Now()
+	return nil
+}
+
+// clientOrigin returns the snapshot, re-resolving it first if it was invalidated or expired,
+// or if the window is no longer where it was when the snapshot was taken. The WinEvent hook
+// runs asynchronously, so the rectangle check is what catches a move just before the call.
+func (b *burst) clientOrigin(hwnd uintptr) (window.POINT, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	rect, err := window.GetWindowRect(hwnd)
+	if err != nil {
+		return window.POINT{}, err
+	}
+	if b.stale.Swap(false) || time.Since(b.taken) > b.ttl || rect != b.rect {
+		if err := b.refresh(hwnd); err != nil {
+			return window.POINT{}, err
+		}
+	}
+	return b.origin, nil
+}
+
+// clientToScreen converts client coordinates of hwnd to screen coordinates, using the
+// window's burst snapshot when one is active.
+func clientToScreen(hwnd uintptr, x, y int32) (int32, int32, error) {
+	if b := settingsFor(hwnd).burst; b != nil {
+		if o, err := b.clientOrigin(hwnd); err == nil {
+			return x + o.X, y + o.Y, nil
+		}
+	}
+	return window.ClientToScreen(hwnd, x, y)
+}
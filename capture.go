@@ -0,0 +1,61 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/rpdg/winput/screen"
+	"github.com/rpdg/winput/window"
+)
+
+// Capture returns a screenshot of the window alone, taken with PrintWindow so that it is
+// complete even where other windows cover it. The image bounds are the window rect in client
+// coordinates: (0, 0) is the top-left pixel of the client area and the frame lies at negative
+// coordinates, so img.At(x, y) is the pixel that Click(x, y) targets.
+// It returns ErrWindowMinimized for a minimized window, which has nothing to draw.
+func (w *Window) Capture() (*image.RGBA, error) {
+	if !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	if window.IsIconic(w.HWND) {
+		return nil, fmt.Errorf("%w: cannot capture a minimized window", ErrWindowMinimized)
+	}
+	rc, err := window.GetWindowRect(w.HWND)
+	if err != nil {
+		return nil, err
+	}
+	ox, oy, err := window.ClientToScreen(w.HWND, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+	img, err := screen.CaptureWindow(w.HWND)
+	if err != nil {
+		if !w.IsValid() {
+			return nil, ErrWindowGone
+		}
+		return nil, err
+	}
+	img.Rect = img.Rect.Add(image.Pt(int(rc.Left-ox), int(rc.Top-oy)))
+	return img, nil
+}
+
+// CaptureClient is Capture restricted to the client-area rectangle at (x, y) with size
+// width x height, e.g. to watch a status label without grabbing the desktop. The rectangle
+// is clipped to the client area and the image bounds are the clipped rectangle in client
+// coordinates, so img.At(x, y) is client pixel (x, y). It fails if the clipped rectangle
+// is empty.
+func (w *Window) CaptureClient(x, y, width, height int32) (*image.RGBA, error) {
+	if !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	if window.IsIconic(w.HWND) {
+		return nil, fmt.Errorf("%w: cannot capture a minimized window", ErrWindowMinimized)
+	}
+	img, err := screen.CaptureWindowClient(w.HWND, x, y, width, height)
+	if err != nil && !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	return img, err
+}
@@ -0,0 +1,63 @@
+//go:build windows
+
+package winput
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// Close asks the window to close by posting WM_CLOSE, as its close button does. The
+// application may ask to save changes first or ignore the request; use ForceClose to make
+// sure it goes away. Once the window is destroyed, Close and the other methods of w return
+// ErrWindowGone.
+func (w *Window) Close() error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	return mapAccessDenied(window.PostMessage(w.HWND, window.WM_CLOSE, 0, 0))
+}
+
+// ForceClose posts WM_CLOSE and waits up to timeout for the window to be destroyed. If it
+// survives, e.g. behind a "save changes?" prompt, the owning process is terminated, losing
+// unsaved work. A window of the calling process is never terminated; ForceClose returns
+// ErrTimeout for it instead. It returns ErrWindowGone if the window no longer exists on entry.
+func (w *Window) ForceClose(timeout time.Duration) error {
+	pid := window.GetWindowPID(w.HWND)
+	if pid == 0 {
+		return ErrWindowGone
+	}
+	// A full or UIPI-blocked queue only rules out the graceful path.
+	if err := w.Close(); errors.Is(err, ErrWindowGone) {
+		return nil
+	}
+	if waitGone(w.HWND, timeout) {
+		return nil
+	}
+	if pid == uint32(os.Getpid()) {
+		return fmt.Errorf("%w: window did not close within %v", ErrTimeout, timeout)
+	}
+	if err := window.TerminateProcess(pid); err != nil {
+		return mapAccessDenied(err)
+	}
+	if !waitGone(w.HWND, 5*time.Second) {
+		return fmt.Errorf("%w: window still exists after terminating process %d", ErrTimeout, pid)
+	}
+	return nil
+}
+
+// waitGone reports whether hwnd is destroyed within timeout.
+func waitGone(hwnd uintptr, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for window.IsValid(hwnd) {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return true
+}
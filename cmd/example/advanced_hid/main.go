@@ -32,9 +32,11 @@ func main() {
 	}
 	target := w[0]
 
-	// Bring to front (User manual action required usually)
-	fmt.Println("Please focus the Notepad window within 3 seconds...")
-	time.Sleep(3 * time.Second)
+	// Bring to front: HID input goes to whatever has focus
+	if err := target.Activate(); err != nil {
+		fmt.Printf("Could not activate Notepad (%v), please focus it within 3 seconds...\n", err)
+		time.Sleep(3 * time.Second)
+	}
 
 	// 3. Perform Input
 	fmt.Println("👉 Moving Mouse (Human-like trajectory)...")
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/rpdg/winput"
+)
+
+// Prints an environment report for bug reports.
+// Usage: go run ./cmd/example/doctor
+func main() {
+	winput.EnablePerMonitorDPI()
+
+	report, err := winput.Doctor()
+	if err != nil {
+		log.Fatalf("doctor failed: %v", err)
+	}
+	fmt.Print(report.String())
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"os/exec"
+	"time"
+
+	"github.com/rpdg/winput/gamepad"
+)
+
+// Drives a virtual Xbox 360 controller so it can be watched in the Windows
+// "Game Controllers" dialog (joy.cpl -> Properties).
+// Requires the ViGEm bus driver and ViGEmClient.dll next to the executable.
+// Usage: go run ./cmd/example/gamepad
+func main() {
+	fmt.Println("=== winput: Gamepad Example ===")
+
+	pad, err := gamepad.NewX360Pad()
+	if err != nil {
+		if errors.Is(err, gamepad.ErrBusNotInstalled) {
+			log.Fatal("❌ ViGEm bus driver not found. Please install it.")
+		}
+		log.Fatalf("❌ Gamepad setup failed: %v", err)
+	}
+	defer pad.Close()
+
+	// Open the test dialog; select the new controller and click Properties.
+	exec.Command("control", "joy.cpl").Start()
+	fmt.Println("Open Properties for the 'Controller (XBOX 360 For Windows)' entry within 5 seconds...")
+	time.Sleep(5 * time.Second)
+
+	fmt.Println("👉 Cycling buttons...")
+	for _, b := range []gamepad.Button{gamepad.ButtonA, gamepad.ButtonB, gamepad.ButtonX, gamepad.ButtonY,
+		gamepad.ButtonLeftShoulder, gamepad.ButtonRightShoulder, gamepad.ButtonBack, gamepad.ButtonStart} {
+		pad.SetButton(b, true)
+		time.Sleep(300 * time.Millisecond)
+		pad.SetButton(b, false)
+	}
+
+	fmt.Println("👉 Sweeping triggers and sticks...")
+	for i := 0; i <= 200; i++ {
+		a := float64(i) / 200 * 2 * math.Pi
+		x, y := int16(math.Cos(a)*32000), int16(math.Sin(a)*32000)
+		pad.SetStick(gamepad.StickLeft, x, y)
+		pad.SetStick(gamepad.StickRight, -x, y)
+		pad.SetTrigger(gamepad.TriggerLeft, uint8(i*255/200))
+		pad.SetTrigger(gamepad.TriggerRight, uint8(255-i*255/200))
+		time.Sleep(15 * time.Millisecond)
+	}
+
+	pad.Reset()
+	pad.Flush()
+	if err := pad.Err(); err != nil {
+		log.Fatalf("❌ Report submission failed: %v", err)
+	}
+	fmt.Println("=== Done ===")
+}
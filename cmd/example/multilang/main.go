@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/rpdg/winput"
+)
+
+// Types right-to-left, mixed-direction and combining-character text into Notepad.
+// Usage: open Notepad, then go run ./cmd/example/multilang
+func main() {
+	fmt.Println("=== winput: Multi-language Text Example ===")
+	winput.EnablePerMonitorDPI()
+
+	w, err := winput.FindByClass("Notepad")
+	if err != nil {
+		log.Println("❌ Notepad not found. Please open Notepad to run this example.")
+		return
+	}
+
+	edit := w
+	for _, class := range []string{"RichEditD2DPT", "Edit"} {
+		if child, err := w.FindChildByClass(class); err == nil {
+			edit = child
+			break
+		}
+	}
+
+	samples := []string{
+		"Hebrew: שלום עולם\n",
+		"Arabic with diacritics: مَرْحَبًا\n",
+		"Mixed: order 66 for שלום at مرحبا, done\n",
+		"Decomposed Latin (normalized to NFC): Café\n",
+	}
+	for _, s := range samples {
+		if err := edit.TypeBidi(s); err != nil {
+			log.Fatalf("TypeBidi failed: %v", err)
+		}
+	}
+
+	// Targets that scramble posted RTL characters can be switched to clipboard pasting.
+	edit.SetBidiClipboard(true)
+	if err := edit.TypeBidi("Pasted: ‏שלום‎ world\n"); err != nil {
+		log.Fatalf("TypeBidi (clipboard) failed: %v", err)
+	}
+	fmt.Println("✅ Done")
+}
@@ -0,0 +1,68 @@
+//go:build windows
+
+package winput
+
+import "github.com/rpdg/winput/window"
+
+// System command IDs for SysCommand.
+const (
+	SC_MINIMIZE = window.SC_MINIMIZE
+	SC_MAXIMIZE = window.SC_MAXIMIZE
+	SC_CLOSE    = window.SC_CLOSE
+	SC_RESTORE  = window.SC_RESTORE
+)
+
+// MenuCommand is a menu item discovered by ListCommands.
+type MenuCommand = window.MenuItem
+
+// Command posts WM_COMMAND with the given menu/accelerator ID, as if the user picked the menu item.
+// It needs no coordinates or focus and works while the window is minimized.
+func (w *Window) Command(id uint16) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.command(id)
+}
+
+func (w *Window) command(id uint16) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	if err := w.checkPumping(); err != nil {
+		return err
+	}
+	// HIWORD(wParam) = 0 (menu), lParam = 0 (no control).
+	return window.PostMessage(w.HWND, window.WM_COMMAND, uintptr(id), 0)
+}
+
+// SysCommand posts WM_SYSCOMMAND (e.g. SC_MINIMIZE, SC_RESTORE, SC_CLOSE).
+func (w *Window) SysCommand(sc uintptr) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.sysCommand(sc)
+}
+
+func (w *Window) sysCommand(sc uintptr) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	if err := w.checkPumping(); err != nil {
+		return err
+	}
+	return window.PostMessage(w.HWND, window.WM_SYSCOMMAND, sc, 0)
+}
+
+// ListCommands walks the window's menu bar and returns every command ID with its label,
+// so the right ID can be found once and hard-coded for use with Command.
+// Applications without a classic menu bar (e.g. ribbon UIs) return an empty list.
+func (w *Window) ListCommands() ([]MenuCommand, error) {
+	if !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	return window.MenuItems(w.HWND)
+}
@@ -0,0 +1,106 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// CoordinateRecord captures where a client coordinate landed when an action was performed,
+// together with the window geometry that determined it. Comparing it with the present state
+// (ReplayCoordinates) explains most "clicked the wrong place" bugs after the fact.
+type CoordinateRecord struct {
+	Time   time.Time
+	Action string // e.g. "Click", "Move"
+	HWND   uintptr
+	// X, Y are the client coordinates passed to the action; ScreenX, ScreenY where they mapped.
+	X, Y             int32
+	ScreenX, ScreenY int32
+	WindowRect       window.RECT // outer frame, screen coordinates
+	ClientWidth      int32
+	ClientHeight     int32
+	DPI              uint32
+	Monitor          uintptr // HMONITOR nearest to the window
+	Foreground       bool    // the window's top-level window was in the foreground
+}
+
+var coordRecorder atomic.Pointer[func(CoordinateRecord)]
+
+// SetCoordinateRecorder installs fn to receive a CoordinateRecord for every Window mouse
+// action at a client point before it is sent: Move, Hover, Click, ClickRight, ClickMiddle,
+// DoubleClick, DoubleClickRight, MultiClick, ClickX, ClickWithModifiers, MouseDown, MouseUp,
+// Drag (its start point), Scroll, ScrollH, ScrollWithOptions (Zoom included) and
+// ScrollAtPoint. Actions without a client point, such as MoveRel, are not recorded.
+// fn is called synchronously under the input lock and should only store the record.
+// Pass nil to stop recording.
+func SetCoordinateRecorder(fn func(CoordinateRecord)) {
+	if fn == nil {
+		coordRecorder.Store(nil)
+		return
+	}
+	coordRecorder.Store(&fn)
+}
+
+// RecordCoordinates captures a CoordinateRecord for client point (x, y) of the window now.
+// Fields that cannot be read (e.g. DPI on old systems) are left zero.
+func (w *Window) RecordCoordinates(action string, x, y int32) CoordinateRecord {
+	rec := CoordinateRecord{Time: time.Now(), Action: action, HWND: w.HWND, X: x, Y: y}
+	rec.ScreenX, rec.ScreenY, _ = clientToScreen(w.HWND, x, y)
+	rec.WindowRect, _ = window.GetWindowRect(w.HWND)
+	rec.ClientWidth, rec.ClientHeight, _ = window.GetClientRect(w.HWND)
+	rec.DPI, _, _ = window.GetDPI(w.HWND)
+	rec.Monitor = window.MonitorFromWindow(w.HWND)
+	root := window.GetAncestor(w.HWND, window.GA_ROOT)
+	rec.Foreground = root != 0 && window.GetForegroundWindow() == root
+	return rec
+}
+
+// recordAction reports the action to the coordinate recorder, if one is installed.
+func (w *Window) recordAction(action string, x, y int32) {
+	if fn := coordRecorder.Load(); fn != nil {
+		(*fn)(w.RecordCoordinates(action, x, y))
+	}
+}
+
+// ReplayCoordinates recomputes where the recorded client coordinate would land today and
+// explains what changed since it was recorded (window moved or resized, DPI, monitor,
+// foreground). If the window no longer exists, the recorded screen position is returned.
+func ReplayCoordinates(rec CoordinateRecord) (screenX, screenY int32, explanation string) {
+	w := &Window{HWND: rec.HWND}
+	if !w.IsValid() {
+		return rec.ScreenX, rec.ScreenY, fmt.Sprintf("window %#x no longer exists; returning the recorded position", rec.HWND)
+	}
+	now := w.RecordCoordinates(rec.Action, rec.X, rec.Y)
+
+	var notes []string
+	if dx, dy := now.ScreenX-rec.ScreenX, now.ScreenY-rec.ScreenY; dx != 0 || dy != 0 {
+		notes = append(notes, fmt.Sprintf("(%d,%d) now maps to (%d,%d), %+d,%+d from the recorded (%d,%d)",
+			rec.X, rec.Y, now.ScreenX, now.ScreenY, dx, dy, rec.ScreenX, rec.ScreenY))
+	}
+	if now.WindowRect != rec.WindowRect {
+		notes = append(notes, fmt.Sprintf("window rect changed from %v to %v", rec.WindowRect, now.WindowRect))
+	}
+	if now.ClientWidth != rec.ClientWidth || now.ClientHeight != rec.ClientHeight {
+		notes = append(notes, fmt.Sprintf("client size changed from %dx%d to %dx%d; layouts that scale with the window have moved",
+			rec.ClientWidth, rec.ClientHeight, now.ClientWidth, now.ClientHeight))
+	}
+	if now.DPI != rec.DPI && rec.DPI != 0 && now.DPI != 0 {
+		notes = append(notes, fmt.Sprintf("DPI changed from %d to %d; client coordinates of DPI-aware content scale by %.2f",
+			rec.DPI, now.DPI, float64(now.DPI)/float64(rec.DPI)))
+	}
+	if now.Monitor != rec.Monitor {
+		notes = append(notes, "window is on a different monitor")
+	}
+	if now.Foreground != rec.Foreground {
+		notes = append(notes, fmt.Sprintf("foreground changed (was %v, now %v); another window may cover the point", rec.Foreground, now.Foreground))
+	}
+	if len(notes) == 0 {
+		return now.ScreenX, now.ScreenY, "unchanged: the point lands where it did when recorded"
+	}
+	return now.ScreenX, now.ScreenY, strings.Join(notes, "; ")
+}
@@ -0,0 +1,179 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// dialogClass is the window class of standard dialogs, including the common file dialogs.
+const dialogClass = "#32770"
+
+const idOK = 1
+
+// FileDialogStage identifies the step of OpenFileDialog that failed.
+type FileDialogStage int
+
+const (
+	// FileDialogTrigger is sending the shortcut or command that opens the dialog.
+	FileDialogTrigger FileDialogStage = iota
+	// FileDialogWait is waiting for the dialog owned by the target to appear.
+	FileDialogWait
+	// FileDialogFindEdit is locating the file name edit inside the dialog.
+	FileDialogFindEdit
+	// FileDialogSetPath is setting the file name edit's text.
+	FileDialogSetPath
+	// FileDialogConfirm is pressing Open (IDOK) and waiting for the dialog to close.
+	// It fails if the dialog stays open, e.g. behind a "file not found" message box.
+	FileDialogConfirm
+)
+
+// String returns a readable name for the stage.
+func (s FileDialogStage) String() string {
+	switch s {
+	case FileDialogTrigger:
+		return "trigger"
+	case FileDialogWait:
+		return "wait for dialog"
+	case FileDialogFindEdit:
+		return "find file name edit"
+	case FileDialogSetPath:
+		return "set path"
+	case FileDialogConfirm:
+		return "confirm"
+	default:
+		return fmt.Sprintf("FileDialogStage(%d)", int(s))
+	}
+}
+
+// FileDialogError reports the stage at which OpenFileDialog failed. It matches
+// ErrFileDialog and the underlying error (e.g. ErrTimeout) with errors.Is.
+type FileDialogError struct {
+	Stage  FileDialogStage
+	Dialog uintptr // the dialog, once found
+	Err    error
+}
+
+func (e *FileDialogError) Error() string {
+	return fmt.Sprintf("%v: %s: %v", ErrFileDialog, e.Stage, e.Err)
+}
+
+func (e *FileDialogError) Unwrap() []error {
+	return []error{ErrFileDialog, e.Err}
+}
+
+// FileDialogOptions configures OpenFileDialogWithOptions.
+type FileDialogOptions struct {
+	// Hotkey opens the dialog. Defaults to Ctrl+O. Ignored if Command is set.
+	Hotkey []Key
+	// Command, if non-zero, opens the dialog by posting this menu command ID instead
+	// (see ListCommands), which needs neither focus nor a working accelerator.
+	Command uint16
+	// Timeout bounds each wait: for the dialog to appear, and for it to close. Defaults to 10s.
+	Timeout time.Duration
+}
+
+// OpenFileDialog opens a file through the owner's standard Open dialog: it presses Ctrl+O,
+// waits for the "#32770" dialog owned by owner, sets the file name edit (nested as
+// ComboBoxEx32 > ComboBox > Edit) to path in one message and confirms with IDOK.
+// Failures are reported as a *FileDialogError naming the stage.
+func OpenFileDialog(owner *Window, path string, timeout time.Duration) error {
+	return OpenFileDialogWithOptions(owner, path, FileDialogOptions{Timeout: timeout})
+}
+
+// OpenFileDialogWithOptions is OpenFileDialog with a configurable trigger.
+func OpenFileDialogWithOptions(owner *Window, path string, opts FileDialogOptions) error {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	fail := func(stage FileDialogStage, dlg uintptr, err error) error {
+		return &FileDialogError{Stage: stage, Dialog: dlg, Err: err}
+	}
+
+	var err error
+	if opts.Command != 0 {
+		err = owner.Command(opts.Command)
+	} else {
+		keys := opts.Hotkey
+		if len(keys) == 0 {
+			keys = []Key{KeyCtrl, KeyO}
+		}
+		err = owner.PressHotkey(keys...)
+	}
+	if err != nil {
+		return fail(FileDialogTrigger, 0, err)
+	}
+
+	dlg, err := waitOwnedDialog(owner.HWND, opts.Timeout)
+	if err != nil {
+		return fail(FileDialogWait, 0, err)
+	}
+
+	edit := fileNameEdit(dlg.HWND)
+	if edit == 0 {
+		return fail(FileDialogFindEdit, dlg.HWND, ErrWindowNotFound)
+	}
+	if err := (&Window{HWND: edit}).ReplaceText(path); err != nil {
+		return fail(FileDialogSetPath, dlg.HWND, err)
+	}
+
+	if err := dlg.Command(idOK); err != nil {
+		return fail(FileDialogConfirm, dlg.HWND, err)
+	}
+	deadline := time.Now().Add(opts.Timeout)
+	for dlg.IsValid() && window.IsVisible(dlg.HWND) {
+		if time.Now().After(deadline) {
+			return fail(FileDialogConfirm, dlg.HWND, fmt.Errorf("%w: dialog still open", ErrTimeout))
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return nil
+}
+
+// waitOwnedDialog polls for a visible "#32770" window owned by owner.
+func waitOwnedDialog(owner uintptr, timeout time.Duration) (*Window, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		if !window.IsValid(owner) {
+			return nil, ErrWindowGone
+		}
+		hwnds, err := window.EnumTopLevel()
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hwnds {
+			if window.GetOwner(h) == owner && window.IsVisible(h) && window.GetClassName(h) == dialogClass {
+				return &Window{HWND: h}, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, ErrTimeout
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// fileNameEdit finds the file name edit of a common file dialog: the Edit inside the
+// ComboBox inside the ComboBoxEx32, falling back to the dialog's first Edit. It returns 0
+// if there is none.
+func fileNameEdit(dlg uintptr) uintptr {
+	var firstEdit uintptr
+	for _, h := range window.EnumDescendants(dlg) {
+		switch window.GetClassName(h) {
+		case "ComboBoxEx32":
+			if combo, err := window.FindChildByClass(h, "ComboBox"); err == nil {
+				if edit, err := window.FindChildByClass(combo, "Edit"); err == nil {
+					return edit
+				}
+			}
+		case "Edit":
+			if firstEdit == 0 {
+				firstEdit = h
+			}
+		}
+	}
+	return firstEdit
+}
@@ -35,6 +35,13 @@
 // 6. Thread Safety:
 // All public input methods are thread-safe and serialized using an internal mutex. This prevents state pollution
 // (e.g., mixing Shift states from concurrent operations) and race conditions when switching backends.
+// Use AcquireSession to run a whole sequence of calls without interleaving with other goroutines.
+//
+// 7. Cross-Platform Builds:
+// The implementation is Windows-only (//go:build windows), but every package also builds on other
+// platforms, where each API returns ErrUnsupportedPlatform. Code importing winput can therefore be
+// compiled and unit-tested on Linux CI. The stubs are generated; run go generate after changing
+// an exported Windows-only API.
 //
 // Example:
 //
@@ -68,3 +75,5 @@
 //		// winput.SetHIDLibraryPath("libs/interception.dll")
 //		// winput.SetBackend(winput.BackendHID)
 package winput
+
+//go:generate go run ./internal/stubgen
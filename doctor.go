@@ -0,0 +1,184 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/rpdg/winput/hid"
+	"github.com/rpdg/winput/screen"
+	"github.com/rpdg/winput/window"
+)
+
+// Report is the result of Doctor. Probe failures are recorded in the
+// corresponding error fields instead of aborting the report.
+type Report struct {
+	OSMajor, OSMinor, OSBuild uint32
+	OSErr                     error
+
+	Arch         string
+	Capabilities Capabilities
+	DPIAwareness Level
+	Elevated     bool
+	ElevatedErr  error
+
+	SessionID     uint32
+	SessionErr    error
+	RemoteSession bool
+
+	Backend Backend
+	// HIDErr is nil if the Interception library loads and the driver accepts a context.
+	HIDErr error
+
+	Monitors    []screen.Monitor
+	MonitorsErr error
+
+	// Self-tests. nil means the test passed.
+	CursorTest    error // zero-distance SetCursorPos
+	SendInputTest error // zero-distance relative mouse SendInput
+	MessageTest   error // WM_NULL round-trip to a message-only window
+
+	// Warnings lists conditions likely to break input simulation.
+	Warnings []string
+}
+
+// Doctor gathers environment information relevant to input simulation and runs
+// harmless self-tests (no visible input is produced). The returned error is only
+// non-nil if the report could not be produced at all; individual probe failures
+// are recorded in the Report. Paste Report.String() when filing issues.
+func Doctor() (Report, error) {
+	r := Report{
+		Arch:         runtime.GOARCH,
+		Capabilities: OSCapabilities(),
+		DPIAwareness: DPIAwarenessLevel(),
+		Backend:      getBackend(),
+	}
+
+	r.OSMajor, r.OSMinor, r.OSBuild, r.OSErr = window.OSVersion()
+	r.Elevated, r.ElevatedErr = window.IsElevated()
+	r.SessionID, r.SessionErr = window.SessionID()
+	r.RemoteSession = window.IsRemoteSession()
+	r.HIDErr = hid.Probe()
+	r.Monitors, r.MonitorsErr = screen.Monitors()
+
+	r.CursorTest = testCursor()
+	r.SendInputTest = testSendInput()
+	r.MessageTest = testMessageWindow()
+
+	if r.DPIAwareness < LevelPerMonitor {
+		r.Warnings = append(r.Warnings, "process is not Per-Monitor DPI aware; call EnablePerMonitorDPI() at startup")
+	}
+	if !r.Capabilities.PerMonitor && !r.Capabilities.PerMonitorV2 {
+		r.Warnings = append(r.Warnings, "Windows before 8.1 cannot be Per-Monitor DPI aware; capture and coordinates are exact on the primary monitor only")
+	}
+	if r.SessionErr == nil && r.SessionID == 0 {
+		r.Warnings = append(r.Warnings, "running in session 0 (service); there is no interactive desktop to send input to")
+	}
+	if r.SendInputTest != nil {
+		r.Warnings = append(r.Warnings, "SendInput is blocked; global keyboard/mouse input will fail (UIPI, secure desktop or locked workstation)")
+	}
+	if r.Backend == BackendHID && r.HIDErr != nil {
+		r.Warnings = append(r.Warnings, "HID backend selected but Interception is unavailable")
+	}
+	if r.MonitorsErr == nil && len(r.Monitors) == 0 {
+		r.Warnings = append(r.Warnings, "no monitors enumerated")
+	}
+
+	return r, nil
+}
+
+// String renders the report as human-readable text.
+func (r Report) String() string {
+	var b strings.Builder
+	status := func(err error) string {
+		if err != nil {
+			return "FAIL (" + err.Error() + ")"
+		}
+		return "ok"
+	}
+
+	fmt.Fprintf(&b, "winput doctor (behavior version %d)\n", BehaviorVersion)
+	if r.OSErr != nil {
+		fmt.Fprintf(&b, "OS:          unknown (%v)\n", r.OSErr)
+	} else {
+		fmt.Fprintf(&b, "OS:          Windows %d.%d build %d\n", r.OSMajor, r.OSMinor, r.OSBuild)
+	}
+	fmt.Fprintf(&b, "Arch:        %s\n", r.Arch)
+	if len(r.Capabilities.Missing) > 0 {
+		fmt.Fprintf(&b, "Missing API: %s\n", strings.Join(r.Capabilities.Missing, ", "))
+	}
+	fmt.Fprintf(&b, "DPI:         %s\n", r.DPIAwareness)
+	if r.ElevatedErr != nil {
+		fmt.Fprintf(&b, "Elevated:    unknown (%v)\n", r.ElevatedErr)
+	} else {
+		fmt.Fprintf(&b, "Elevated:    %v\n", r.Elevated)
+	}
+	if r.SessionErr != nil {
+		fmt.Fprintf(&b, "Session:     unknown (%v)\n", r.SessionErr)
+	} else {
+		kind := "console"
+		switch {
+		case r.SessionID == 0:
+			kind = "service"
+		case r.RemoteSession:
+			kind = "remote"
+		}
+		fmt.Fprintf(&b, "Session:     %d (%s)\n", r.SessionID, kind)
+	}
+	backend := "Message"
+	if r.Backend == BackendHID {
+		backend = "HID"
+	}
+	fmt.Fprintf(&b, "Backend:     %s\n", backend)
+	fmt.Fprintf(&b, "HID:         %s\n", status(r.HIDErr))
+	if r.MonitorsErr != nil {
+		fmt.Fprintf(&b, "Monitors:    unknown (%v)\n", r.MonitorsErr)
+	} else {
+		fmt.Fprintf(&b, "Monitors:    %d\n", len(r.Monitors))
+		for i, m := range r.Monitors {
+			primary := ""
+			if m.Primary {
+				primary = " primary"
+			}
+			fmt.Fprintf(&b, "  [%d] (%d,%d)-(%d,%d)%s\n", i, m.Bounds.Left, m.Bounds.Top, m.Bounds.Right, m.Bounds.Bottom, primary)
+		}
+	}
+	fmt.Fprintf(&b, "SetCursorPos: %s\n", status(r.CursorTest))
+	fmt.Fprintf(&b, "SendInput:    %s\n", status(r.SendInputTest))
+	fmt.Fprintf(&b, "Messages:     %s\n", status(r.MessageTest))
+	for _, w := range r.Warnings {
+		fmt.Fprintf(&b, "WARNING: %s\n", w)
+	}
+	return b.String()
+}
+
+// testCursor moves the cursor to where it already is.
+func testCursor() error {
+	x, y, err := window.GetCursorPos()
+	if err != nil {
+		return err
+	}
+	if r, _, e := window.ProcSetCursorPos.Call(uintptr(x), uintptr(y)); r == 0 {
+		return fmt.Errorf("SetCursorPos failed: %v", e)
+	}
+	return nil
+}
+
+// testMessageWindow round-trips WM_NULL through a message-only window owned by this goroutine's thread.
+func testMessageWindow() error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	hwnd, err := window.CreateMessageWindow()
+	if err != nil {
+		return err
+	}
+	defer window.DestroyWindow(hwnd)
+
+	if err := window.Ping(hwnd, 1000); err != nil {
+		return fmt.Errorf("WM_NULL round-trip failed: %w", err)
+	}
+	return nil
+}
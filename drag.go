@@ -0,0 +1,300 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rpdg/winput/hid"
+	"github.com/rpdg/winput/mouse"
+	"github.com/rpdg/winput/window"
+)
+
+// DragOptions configures drag gestures.
+type DragOptions struct {
+	// Steps is the number of intermediate cursor positions used by the Message backend path.
+	// 0 means the default (30). The HID backend always uses its human-like trajectory.
+	Steps int
+	// Dwell is how long DragBetween hovers over the destination before the button is released,
+	// giving drop targets time to highlight. 0 means the default (300ms).
+	Dwell time.Duration
+	// Button is the button Window.Drag holds, MouseLeft by default. DragBetween always uses
+	// the left button.
+	Button MouseButton
+}
+
+var defaultDragOptions = DragOptions{
+	Steps: 30,
+	Dwell: 300 * time.Millisecond,
+}
+
+const (
+	mouseEventLeftDown = 0x0002
+	mouseEventLeftUp   = 0x0004
+	mouseEventWheel    = 0x0800
+	mouseEventHWheel   = 0x1000
+)
+
+// Drag presses the left button at client point (fromX, fromY), moves to (toX, toY) with the
+// button held and releases it there, e.g. to drag a slider, move a scrollbar thumb or
+// rubber-band select. The Message backend posts the button down, WM_MOUSEMOVE messages
+// along the path and the button up; the HID backend presses the physical button and follows
+// the human-like Move trajectory. Use DragBetween for OLE drag-and-drop between windows.
+func (w *Window) Drag(fromX, fromY, toX, toY int32) error {
+	return w.DragWithOptions(fromX, fromY, toX, toY, DragOptions{})
+}
+
+// DragWithOptions is Drag with another button or number of Message backend steps.
+func (w *Window) DragWithOptions(fromX, fromY, toX, toY int32, opts DragOptions) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.drag(fromX, fromY, toX, toY, opts)
+}
+
+func (w *Window) drag(fromX, fromY, toX, toY int32, opts DragOptions) error {
+	if err := opts.Button.check(); err != nil {
+		return err
+	}
+	if opts.Steps <= 0 {
+		opts.Steps = defaultDragOptions.Steps
+	}
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	w.recordAction("Drag", fromX, fromY)
+
+	if getBackend() == BackendHID {
+		sx, sy, err := clientToScreen(w.HWND, fromX, fromY)
+		if err != nil {
+			return err
+		}
+		ex, ey, err := clientToScreen(w.HWND, toX, toY)
+		if err != nil {
+			return err
+		}
+		return hid.Drag(sx, sy, ex, ey, opts.Button.hid())
+	}
+	return mouse.Drag(w.HWND, opts.Button.message(), fromX, fromY, toX, toY, opts.Steps)
+}
+
+// DragBetween presses the left button at client point (sx, sy) of src, moves across the screen
+// to client point (dx, dy) of dst, hovers for opts.Dwell and releases.
+//
+// OLE drag-and-drop needs real cursor movement, so the Message backend drives the physical
+// cursor (SetCursorPos/mouse_event) for this call instead of posting messages.
+// If either window moves or the destination point becomes covered by another window mid-drag,
+// the drag is cancelled with Esc before the button is released, and ErrWindowMoved or
+// ErrWindowObscured is returned.
+func DragBetween(src *Window, sx, sy int32, dst *Window, dx, dy int32, opts DragOptions) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return dragBetween(src, sx, sy, dst, dx, dy, opts)
+}
+
+func dragBetween(src *Window, sx, sy int32, dst *Window, dx, dy int32, opts DragOptions) error {
+	if opts.Steps <= 0 {
+		opts.Steps = defaultDragOptions.Steps
+	}
+	if opts.Dwell <= 0 {
+		opts.Dwell = defaultDragOptions.Dwell
+	}
+
+	if err := src.checkReady(); err != nil {
+		return err
+	}
+	if err := dst.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	cb := getBackend()
+
+	fromX, fromY, err := window.ClientToScreen(src.HWND, sx, sy)
+	if err != nil {
+		return err
+	}
+	toX, toY, err := window.ClientToScreen(dst.HWND, dx, dy)
+	if err != nil {
+		return err
+	}
+
+	srcRect, err := window.GetWindowRect(src.HWND)
+	if err != nil {
+		return err
+	}
+	dstRect, err := window.GetWindowRect(dst.HWND)
+	if err != nil {
+		return err
+	}
+
+	if !ownsScreenPoint(src.HWND, fromX, fromY) {
+		return fmt.Errorf("%w: source (%d, %d)", ErrWindowObscured, fromX, fromY)
+	}
+
+	// 1. Press at source
+	if err := cursorTo(cb, fromX, fromY); err != nil {
+		return err
+	}
+	if err := leftButton(cb, true); err != nil {
+		return err
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	// abort cancels the OLE drag loop before releasing so nothing is dropped on the wrong target.
+	abort := func(cause error) error {
+		keyDownImpl(cb, 0, KeyEsc)
+		time.Sleep(30 * time.Millisecond)
+		keyUpImpl(cb, 0, KeyEsc)
+		leftButton(cb, false)
+		return cause
+	}
+
+	// verify checks that neither window moved and the destination point is still reachable.
+	verify := func() error {
+		if rc, err := window.GetWindowRect(src.HWND); err != nil || rc != srcRect {
+			return fmt.Errorf("%w: source window", ErrWindowMoved)
+		}
+		if rc, err := window.GetWindowRect(dst.HWND); err != nil || rc != dstRect {
+			return fmt.Errorf("%w: destination window", ErrWindowMoved)
+		}
+		if !ownsScreenPoint(dst.HWND, toX, toY) {
+			return fmt.Errorf("%w: destination (%d, %d)", ErrWindowObscured, toX, toY)
+		}
+		return nil
+	}
+
+	// 2. Traverse to destination
+	if cb == BackendHID {
+		if err := hid.Move(toX, toY); err != nil {
+			return abort(err)
+		}
+	} else {
+		for i := 1; i <= opts.Steps; i++ {
+			x := fromX + (toX-fromX)*int32(i)/int32(opts.Steps)
+			y := fromY + (toY-fromY)*int32(i)/int32(opts.Steps)
+			if err := window.SetCursorPos(x, y); err != nil {
+				return abort(err)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+
+	if err := verify(); err != nil {
+		return abort(err)
+	}
+
+	// 3. Dwell: drop targets only update on movement, so wiggle by one pixel while hovering.
+	deadline := time.Now().Add(opts.Dwell)
+	for offset := int32(1); time.Now().Before(deadline); offset = -offset {
+		time.Sleep(50 * time.Millisecond)
+		if err := cursorTo(cb, toX+offset, toY); err != nil {
+			return abort(err)
+		}
+	}
+	if err := cursorTo(cb, toX, toY); err != nil {
+		return abort(err)
+	}
+
+	if err := verify(); err != nil {
+		return abort(err)
+	}
+
+	// 4. Release
+	return leftButton(cb, false)
+}
+
+// DragMouse presses the left button at screen point (fromX, fromY), moves to (toX, toY) with
+// the button held and releases it there, e.g. to drop a file onto a drop zone found by
+// screen capture. The Message backend drives the physical cursor with SetCursorPos and
+// mouse_event; the HID backend follows the human-like Move trajectory.
+// Both points are subject to the work area guard (see SetWorkAreaGuard).
+func DragMouse(fromX, fromY, toX, toY int32) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return dragMouse(fromX, fromY, toX, toY)
+}
+
+func dragMouse(fromX, fromY, toX, toY int32) error {
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	fromX, fromY, guard := guardWorkArea(fromX, fromY)
+	toX, toY, toGuard := guardWorkArea(toX, toY)
+	if guard == nil {
+		guard = toGuard
+	}
+
+	if getBackend() == BackendHID {
+		if err := hid.Drag(fromX, fromY, toX, toY, hid.ButtonLeft); err != nil {
+			return err
+		}
+		return guard
+	}
+
+	if err := window.SetCursorPos(fromX, fromY); err != nil {
+		return err
+	}
+	time.Sleep(30 * time.Millisecond)
+	leftButton(BackendMessage, true)
+	steps := defaultDragOptions.Steps
+	for i := 1; i <= steps; i++ {
+		time.Sleep(10 * time.Millisecond)
+		x := fromX + (toX-fromX)*int32(i)/int32(steps)
+		y := fromY + (toY-fromY)*int32(i)/int32(steps)
+		if err := window.SetCursorPos(x, y); err != nil {
+			// Release anyway so the button is not left held.
+			leftButton(BackendMessage, false)
+			return err
+		}
+	}
+	time.Sleep(30 * time.Millisecond)
+	leftButton(BackendMessage, false)
+	return guard
+}
+
+// ownsScreenPoint reports whether the top-level window of hwnd is the one under the point.
+func ownsScreenPoint(hwnd uintptr, x, y int32) bool {
+	under := window.WindowFromPoint(x, y)
+	if under == 0 {
+		return false
+	}
+	return window.GetAncestor(under, window.GA_ROOT) == window.GetAncestor(hwnd, window.GA_ROOT)
+}
+
+// cursorTo moves the physical cursor to the screen point using the given backend.
+func cursorTo(cb Backend, x, y int32) error {
+	if cb == BackendHID {
+		return hid.Move(x, y)
+	}
+	return window.SetCursorPos(x, y)
+}
+
+// leftButton presses or releases the physical left button using the given backend.
+func leftButton(cb Backend, down bool) error {
+	if cb == BackendHID {
+		if down {
+			return hid.LeftDown()
+		}
+		return hid.LeftUp()
+	}
+	flag := uintptr(mouseEventLeftUp)
+	if down {
+		flag = mouseEventLeftDown
+	}
+	window.ProcMouseEvent.Call(flag, 0, 0, 0, 0)
+	return nil
+}
@@ -2,7 +2,9 @@ package winput
 
 import (
 	"errors"
+	"fmt"
 
+	"github.com/rpdg/winput/hid"
 	"github.com/rpdg/winput/window"
 )
 
@@ -16,6 +18,14 @@ var (
 	// ErrWindowNotVisible implies the window is hidden or minimized.
 	ErrWindowNotVisible = errors.New("window is not visible")
 
+	// ErrWindowMinimized implies the window is minimized; Restore brings it back.
+	// It wraps ErrWindowNotVisible.
+	ErrWindowMinimized = fmt.Errorf("%w: minimized", ErrWindowNotVisible)
+
+	// ErrWindowHidden implies the window does not have the WS_VISIBLE style; Show brings it back.
+	// It wraps ErrWindowNotVisible.
+	ErrWindowHidden = fmt.Errorf("%w: hidden", ErrWindowNotVisible)
+
 	// ErrUnsupportedKey implies the character cannot be mapped to a key.
 	ErrUnsupportedKey = errors.New("unsupported key or character")
 
@@ -36,4 +46,102 @@ var (
 
 	// ErrReadTextFailed implies the library could not read text from the target window/control.
 	ErrReadTextFailed = window.ErrReadTextFailed
+
+	// ErrProcessNotFound implies no running process matched the requested name or path.
+	ErrProcessNotFound = window.ErrProcessNotFound
+
+	// ErrSessionActive is returned by input calls made outside a fail-fast Session while it is held.
+	ErrSessionActive = errors.New("input session active")
+
+	// ErrSessionExpired implies the Session exceeded its MaxHold and was released automatically.
+	ErrSessionExpired = errors.New("input session expired")
+
+	// ErrSessionReleased implies the Session was already released by its holder.
+	ErrSessionReleased = errors.New("input session released")
+
+	// ErrWindowMoved implies the window changed position or size while a gesture was in progress.
+	ErrWindowMoved = errors.New("window moved during operation")
+
+	// ErrWindowObscured implies another window covers the target point.
+	ErrWindowObscured = errors.New("window is obscured at target point")
+
+	// ErrNoInteractiveSession implies input cannot be injected because there is no interactive desktop
+	// (service session, locked workstation, secure desktop or headless CI).
+	ErrNoInteractiveSession = errors.New("no interactive input session")
+
+	// ErrTargetNotPumping implies strict mode found the window's thread is not processing messages,
+	// so posted input would be queued but never consumed.
+	ErrTargetNotPumping = errors.New("target window is not processing messages")
+
+	// ErrInputBusy implies another process held the cross-process input lock past the wait timeout.
+	ErrInputBusy = errors.New("input device busy in another process")
+
+	// ErrZOrderTopMost implies a non-topmost window cannot be placed among topmost windows.
+	ErrZOrderTopMost = errors.New("cannot place non-topmost window above a topmost window")
+
+	// ErrFocusStolen implies the foreground window changed while typing into it; see FocusStolenError.
+	ErrFocusStolen = errors.New("focus stolen")
+
+	// ErrLayoutNotInstalled implies the requested keyboard layout is not installed.
+	// The returned error is a *LayoutNotInstalledError listing the installed layouts.
+	ErrLayoutNotInstalled = errors.New("keyboard layout not installed")
+
+	// ErrUnsupportedPlatform implies the package was built for a platform other than Windows.
+	// Every input, window and capture API returns it there.
+	ErrUnsupportedPlatform = window.ErrUnsupportedPlatform
+
+	// ErrDPIAlreadySet implies the process DPI awareness was already set to another level
+	// and can no longer be changed.
+	ErrDPIAlreadySet = window.ErrDPIAlreadySet
+
+	// ErrUnsupportedOS implies a feature needs an API that this version of Windows does not export.
+	// The error is an *UnsupportedOSError naming the API; see OSCapabilities.
+	ErrUnsupportedOS = window.ErrUnsupportedOS
+
+	// ErrHIDLibraryLoaded implies SetHIDLibraryPath was called after a library from another
+	// path had been loaded; use ReloadHIDLibrary.
+	ErrHIDLibraryLoaded = hid.ErrLibraryLoaded
+
+	// ErrHIDLibraryInUse implies ReloadHIDLibrary found input operations using the driver.
+	ErrHIDLibraryInUse = hid.ErrLibraryInUse
+
+	// ErrChildIndexOutOfRange implies FindChildByClassNth asked for more children of a class
+	// than exist. The error is a *ChildIndexError carrying the count.
+	ErrChildIndexOutOfRange = window.ErrChildIndexOutOfRange
+
+	// ErrMoveInaccurate implies the HID backend could not place the cursor exactly on the target.
+	// The error is a *MoveInaccurateError carrying the final position.
+	ErrMoveInaccurate = hid.ErrMoveInaccurate
+
+	// ErrOutsideWorkArea implies a global mouse target lay on the taskbar or another app bar and
+	// was moved into the work area (see SetWorkAreaGuard). The error is an *OutsideWorkAreaError.
+	ErrOutsideWorkArea = errors.New("target outside monitor work area")
+
+	// ErrActivateFailed implies Windows refused to bring a window to the foreground
+	// (the foreground lock), e.g. because another application is in use.
+	ErrActivateFailed = errors.New("window activation refused")
+
+	// ErrWindowHung implies Windows considers the window hung (not responding for 5 seconds),
+	// so posted input would sit in its queue (see SetStrictReadyCheck).
+	ErrWindowHung = errors.New("window is not responding")
+
+	// ErrNoFocus implies no window of the target's thread has keyboard focus.
+	ErrNoFocus = errors.New("no window has keyboard focus")
+
+	// ErrNoCaret implies the target's thread shows no text caret, e.g. because no edit control has focus.
+	ErrNoCaret = errors.New("no caret")
+
+	// ErrNotResolved implies Refind was called on a window that did not come from Resolve.
+	ErrNotResolved = errors.New("window was not obtained from Resolve")
+
+	// ErrSelfTarget implies a synchronous message was refused because the target window
+	// belongs to the calling thread (see SetAllowSelfTarget).
+	ErrSelfTarget = errors.New("target window belongs to the calling thread")
+
+	// ErrFileDialog implies OpenFileDialog failed. The error is a *FileDialogError naming
+	// the stage.
+	ErrFileDialog = errors.New("file dialog automation failed")
+
+	// ErrTimeout implies the operation did not complete within the requested time.
+	ErrTimeout = errors.New("operation timed out")
 )
@@ -0,0 +1,262 @@
+//go:build windows
+
+package winput
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/rpdg/winput/window"
+)
+
+// findTopLevel returns the top-level windows whose title satisfies match, in z-order.
+func findTopLevel(match func(title string) bool, opts []FindOption) ([]*Window, error) {
+	var o findOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	hwnds, err := window.EnumTopLevel()
+	if err != nil {
+		return nil, err
+	}
+	var windows []*Window
+	for _, h := range hwnds {
+		if o.skip(h) {
+			continue
+		}
+		title, err := window.GetTitle(h)
+		if err != nil || !match(title) {
+			continue
+		}
+		windows = append(windows, &Window{HWND: h})
+	}
+	return windows, nil
+}
+
+// normalize applies the MatchCaseInsensitive and MatchTrimSpace options to s.
+func (o findOptions) normalize(s string) string {
+	if o.trimSpace {
+		s = strings.TrimSpace(s)
+	}
+	if o.foldCase {
+		s = window.Fold(s)
+	}
+	return s
+}
+
+// skip reports whether the top-level window h is filtered out by the options.
+func (o findOptions) skip(h uintptr) bool {
+	return !o.includeHidden && (!window.IsVisible(h) || window.IsToolWindow(h))
+}
+
+// FindByTitleContains returns the topmost top-level window whose title contains substr
+// (case-insensitive, Unicode-aware), e.g. "Notepad" for "report.txt - Notepad".
+// Invisible and tool windows are skipped unless IncludeHidden is given, and surrounding
+// white space is ignored with MatchTrimSpace.
+func FindByTitleContains(substr string, opts ...FindOption) (*Window, error) {
+	windows, err := FindAllByTitleContains(substr, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return windows[0], nil
+}
+
+// FindAllByTitleContains is FindByTitleContains returning every match, in z-order
+// (topmost first). It returns ErrWindowNotFound if there is none.
+func FindAllByTitleContains(substr string, opts ...FindOption) ([]*Window, error) {
+	o := findOptions{foldCase: true}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	want := o.normalize(substr)
+	windows, err := findTopLevel(func(title string) bool {
+		return strings.Contains(o.normalize(title), want)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(windows) == 0 {
+		return nil, ErrWindowNotFound
+	}
+	return windows, nil
+}
+
+// FindByTitleRegex returns every top-level window whose title matches the regular
+// expression pattern, in z-order (topmost first), for titles carrying dynamic data such as
+// `^MyApp v\d+\.\d+`. Windows with an empty title are skipped. Like the other Find
+// functions it skips invisible and tool windows by default; pass IncludeHidden to search
+// every top-level window. It returns the compile error for an invalid pattern, and
+// ErrWindowNotFound if nothing matches.
+func FindByTitleRegex(pattern string, opts ...FindOption) ([]*Window, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	windows, err := findTopLevel(func(title string) bool {
+		return title != "" && re.MatchString(title)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(windows) == 0 {
+		return nil, ErrWindowNotFound
+	}
+	return windows, nil
+}
+
+// WindowAt returns the deepest visible window at the screen point (WindowFromPoint), e.g. the
+// control under a template match, in virtual desktop coordinates (negative left of or above
+// the primary monitor). Use ScreenToClient on the result to address it.
+// It returns ErrWindowNotFound if the point is over the desktop or outside every monitor;
+// the desktop (Progman/WorkerW) is never returned.
+func WindowAt(x, y int32) (*Window, error) {
+	hwnd := window.WindowFromPoint(x, y)
+	if hwnd == 0 || isDesktop(rootWindow(hwnd)) {
+		return nil, ErrWindowNotFound
+	}
+	return &Window{HWND: hwnd}, nil
+}
+
+// TopLevelWindowAt is WindowAt returning the top-level window that contains the point's window.
+func TopLevelWindowAt(x, y int32) (*Window, error) {
+	w, err := WindowAt(x, y)
+	if err != nil {
+		return nil, err
+	}
+	return &Window{HWND: rootWindow(w.HWND)}, nil
+}
+
+// isDesktop reports whether the top-level window is the shell's desktop (the wallpaper and
+// icons), which WindowFromPoint returns for points not covered by an application window.
+func isDesktop(root uintptr) bool {
+	class := window.GetClassName(root)
+	return class == "Progman" || class == "WorkerW"
+}
+
+// FindChildByTitle returns the first descendant control whose text equals text
+// (case-insensitive), e.g. the "OK" button of a dialog, in EnumChildWindows order.
+// Mnemonic markers are ignored, so "Open" matches a button labelled "&Open".
+// It returns ErrWindowNotFound if there is none.
+func (w *Window) FindChildByTitle(text string) (*Window, error) {
+	folded := window.Fold(text)
+	return w.findChild(func(t string) bool { return t == folded })
+}
+
+// FindChildByTitleContains is FindChildByTitle matching controls whose text contains substr.
+func (w *Window) FindChildByTitleContains(substr string) (*Window, error) {
+	folded := window.Fold(substr)
+	return w.findChild(func(t string) bool { return strings.Contains(t, folded) })
+}
+
+// FindDescendantByClass returns the shallowest descendant with the specified class name
+// (case-insensitive, like FindWindowW), searching breadth-first so that controls nested
+// in container panes are found, nearest first. maxDepth limits the search to that many
+// levels below w (1 means direct children only); maxDepth <= 0 means no limit.
+// It returns ErrWindowNotFound if there is none.
+func (w *Window) FindDescendantByClass(class string, maxDepth int) (*Window, error) {
+	if !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	children := make(map[uintptr][]uintptr)
+	for _, h := range window.EnumDescendants(w.HWND) {
+		parent := window.GetAncestor(h, window.GA_PARENT)
+		children[parent] = append(children[parent], h)
+	}
+	level := children[w.HWND]
+	for depth := 1; len(level) > 0 && (maxDepth <= 0 || depth <= maxDepth); depth++ {
+		var next []uintptr
+		for _, h := range level {
+			if strings.EqualFold(window.GetClassName(h), class) {
+				return &Window{HWND: h}, nil
+			}
+			next = append(next, children[h]...)
+		}
+		level = next
+	}
+	return nil, ErrWindowNotFound
+}
+
+// findChild returns the first descendant whose folded, mnemonic-free text satisfies match.
+func (w *Window) findChild(match func(text string) bool) (*Window, error) {
+	if !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	for _, h := range window.EnumDescendants(w.HWND) {
+		text, err := window.GetText(h)
+		if err != nil || text == "" {
+			continue
+		}
+		if match(window.Fold(stripMnemonic(text))) {
+			return &Window{HWND: h}, nil
+		}
+	}
+	return nil, ErrWindowNotFound
+}
+
+// stripMnemonic removes the '&' that underlines the next character of a control label,
+// keeping a literal "&&" as "&".
+func stripMnemonic(s string) string {
+	if !strings.Contains(s, "&") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '&' {
+			if i+1 < len(s) && s[i+1] == '&' {
+				b.WriteByte('&')
+				i++
+			}
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+// WindowInfo describes a top-level window, as returned by ListWindows.
+type WindowInfo struct {
+	HWND        uintptr
+	Title       string
+	ClassName   string
+	PID         uint32
+	ProcessName string // executable name, e.g. "notepad.exe"
+	Visible     bool
+	Rect        window.RECT // outer frame, screen coordinates
+}
+
+// ListWindows describes every top-level window, in z-order (topmost first), to find out
+// what a target's class name and title are without Spy++. Like the Find functions it skips
+// invisible and tool windows (the hundreds of hidden system windows) unless IncludeHidden is given.
+func ListWindows(opts ...FindOption) ([]WindowInfo, error) {
+	var o findOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	hwnds, err := window.EnumTopLevel()
+	if err != nil {
+		return nil, err
+	}
+	names, err := window.ProcessNames()
+	if err != nil {
+		return nil, err
+	}
+	var infos []WindowInfo
+	for _, h := range hwnds {
+		if o.skip(h) {
+			continue
+		}
+		info := WindowInfo{
+			HWND:      h,
+			ClassName: window.GetClassName(h),
+			PID:       window.GetWindowPID(h),
+			Visible:   window.IsVisible(h),
+		}
+		info.Title, _ = window.GetTitle(h)
+		info.ProcessName = names[info.PID]
+		info.Rect, _ = window.GetWindowRect(h)
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
@@ -0,0 +1,29 @@
+package winput
+
+// FindOption adjusts how the title-based Find functions select windows.
+type FindOption func(*findOptions)
+
+type findOptions struct {
+	includeHidden bool
+	foldCase      bool
+	trimSpace     bool
+}
+
+// IncludeHidden makes a search also consider invisible windows and tool windows
+// (WS_EX_TOOLWINDOW without a taskbar button), which are skipped by default.
+func IncludeHidden() FindOption {
+	return func(o *findOptions) { o.includeHidden = true }
+}
+
+// MatchCaseInsensitive makes FindByTitle compare titles case-insensitively (Unicode-aware),
+// e.g. for localized applications that vary the capitalization of their title.
+// FindByTitleContains is always case-insensitive.
+func MatchCaseInsensitive() FindOption {
+	return func(o *findOptions) { o.foldCase = true }
+}
+
+// MatchTrimSpace makes FindByTitle and FindByTitleContains ignore leading and trailing
+// white space in both the window title and the searched-for text.
+func MatchTrimSpace() FindOption {
+	return func(o *findOptions) { o.trimSpace = true }
+}
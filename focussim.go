@@ -0,0 +1,126 @@
+//go:build windows
+
+package winput
+
+import (
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// SimulateFocusLoss posts the messages a top-level window receives when the user switches
+// to another application, in the order Windows sends them: WM_NCACTIVATE(FALSE),
+// WM_ACTIVATE(WA_INACTIVE), WM_ACTIVATEAPP(FALSE), then WM_KILLFOCUS to the focused control.
+// Nothing else on the desktop changes, so it is suited to testing how an application
+// reacts to deactivation (pausing playback, locking forms).
+//
+// It is a simulation: an application that checks GetForegroundWindow or GetFocus sees that
+// it is still active. Use StealFocus for a real focus change.
+func (w *Window) SimulateFocusLoss() error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.postFocusChange(false)
+}
+
+// SimulateFocusGain posts the messages of the window being activated again:
+// WM_ACTIVATEAPP(TRUE), WM_NCACTIVATE(TRUE), WM_ACTIVATE(WA_ACTIVE), then WM_SETFOCUS to
+// the focused control. See SimulateFocusLoss.
+func (w *Window) SimulateFocusGain() error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.postFocusChange(true)
+}
+
+func (w *Window) postFocusChange(active bool) error {
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	root := rootWindow(w.HWND)
+	// The control that owns focus within the window gets WM_KILLFOCUS / WM_SETFOCUS.
+	focus := root
+	if tid, _ := window.GetThreadProcessID(root); tid != 0 {
+		if f := window.ThreadFocus(tid); f != 0 && window.GetAncestor(f, window.GA_ROOT) == root {
+			focus = f
+		}
+	}
+
+	type post struct {
+		hwnd   uintptr
+		msg    uint32
+		wparam uintptr
+	}
+	msgs := []post{
+		{root, window.WM_NCACTIVATE, 0},
+		{root, window.WM_ACTIVATE, window.WA_INACTIVE},
+		{root, window.WM_ACTIVATEAPP, 0},
+		{focus, window.WM_KILLFOCUS, 0},
+	}
+	if active {
+		msgs = []post{
+			{root, window.WM_ACTIVATEAPP, 1},
+			{root, window.WM_NCACTIVATE, 1},
+			{root, window.WM_ACTIVATE, window.WA_ACTIVE},
+			{focus, window.WM_SETFOCUS, 0},
+		}
+	}
+	for _, m := range msgs {
+		if err := window.PostMessage(m.hwnd, m.msg, m.wparam, 0); err != nil {
+			return mapAccessDenied(err)
+		}
+	}
+	return nil
+}
+
+// StealFocus really deactivates the window: it brings a window owned by winput (an off-screen
+// test window) to the foreground, so the target loses activation and focus exactly as it
+// would to another application. restore reactivates the target and closes the thief.
+// It returns ErrActivateFailed if Windows refuses the foreground change (the foreground
+// lock), which happens unless the calling process owns the current foreground window or
+// received the last input event.
+func (w *Window) StealFocus() (restore func() error, err error) {
+	if !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	root := rootWindow(w.HWND)
+	thief, err := NewTestWindow(TestWindowOptions{Visible: true, Title: "winput focus thief", X: -10000, Y: -10000, Width: 1, Height: 1})
+	if err != nil {
+		return nil, err
+	}
+	if !setForeground(thief.HWND) {
+		thief.Close()
+		return nil, ErrActivateFailed
+	}
+	return func() error {
+		defer thief.Close()
+		if !setForeground(root) {
+			return ErrActivateFailed
+		}
+		return nil
+	}, nil
+}
+
+// setForeground asks for hwnd to become the foreground window and reports whether it did.
+func setForeground(hwnd uintptr) bool {
+	if !window.SetForegroundWindow(hwnd) {
+		return false
+	}
+	return waitForeground(hwnd)
+}
+
+// waitForeground reports whether hwnd becomes the foreground window within 500ms.
+func waitForeground(hwnd uintptr) bool {
+	deadline := time.Now().Add(500 * time.Millisecond)
+	for window.GetForegroundWindow() != hwnd {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return true
+}
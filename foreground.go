@@ -0,0 +1,118 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// FocusStolenError reports that the foreground window changed during TypeIntoForeground.
+// It matches ErrFocusStolen with errors.Is.
+type FocusStolenError struct {
+	// Sent is the number of characters delivered to the expected window before focus moved.
+	Sent int
+	// Foreground is the window that had taken the foreground (0 if none).
+	Foreground uintptr
+}
+
+func (e *FocusStolenError) Error() string {
+	return fmt.Sprintf("%v after %d characters (foreground is now %#x %q)",
+		ErrFocusStolen, e.Sent, e.Foreground, window.GetClassName(e.Foreground))
+}
+
+func (e *FocusStolenError) Unwrap() error {
+	return ErrFocusStolen
+}
+
+// ForegroundTypeOptions configures TypeIntoForegroundWithOptions.
+type ForegroundTypeOptions struct {
+	// CheckEvery re-verifies the foreground window every N characters (default 1: before each one).
+	CheckEvery int
+	// Reactivate tries to bring the expected window back (SetForegroundWindow) and resume when
+	// focus moves, e.g. to a transient popup, instead of failing immediately.
+	Reactivate bool
+}
+
+// TypeIntoForeground types text globally, but only while expected (or one of its children)
+// is the foreground window. The foreground window is verified immediately before the first
+// character and before every subsequent one; if it changes, typing stops and a
+// *FocusStolenError (errors.Is ErrFocusStolen) reports how many characters were sent.
+// This keeps text such as credentials from landing in whatever window popped up.
+func TypeIntoForeground(expected *Window, text string) error {
+	return TypeIntoForegroundWithOptions(expected, text, ForegroundTypeOptions{})
+}
+
+// TypeIntoForegroundWithOptions is TypeIntoForeground with custom options.
+func TypeIntoForegroundWithOptions(expected *Window, text string, opts ForegroundTypeOptions) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return typeIntoForeground(expected, text, opts)
+}
+
+func typeIntoForeground(expected *Window, text string, opts ForegroundTypeOptions) error {
+	if opts.CheckEvery <= 0 {
+		opts.CheckEvery = 1
+	}
+	if !expected.IsValid() {
+		return ErrWindowGone
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	cb := getBackend()
+	if cb == BackendMessage {
+		if err := probeSendInput(); err != nil {
+			return err
+		}
+	}
+
+	root := window.GetAncestor(expected.HWND, window.GA_ROOT)
+	if root == 0 {
+		root = expected.HWND
+	}
+	verify := func(sent int) error {
+		fg := window.GetForegroundWindow()
+		if fg != 0 && window.GetAncestor(fg, window.GA_ROOT) == root {
+			return nil
+		}
+		if opts.Reactivate && window.SetForegroundWindow(root) {
+			time.Sleep(100 * time.Millisecond)
+			if fg2 := window.GetForegroundWindow(); fg2 != 0 && window.GetAncestor(fg2, window.GA_ROOT) == root {
+				return nil
+			}
+			fg = window.GetForegroundWindow()
+		}
+		return &FocusStolenError{Sent: sent, Foreground: fg}
+	}
+
+	delay := expected.Timing().KeyDelay
+	runes := settingsFor(expected.HWND).runes
+	sent := 0
+	for i, r := range text {
+		if sent%opts.CheckEvery == 0 {
+			if err := verify(sent); err != nil {
+				return err
+			}
+		}
+		var err error
+		if def, ok := runes[r]; ok && cb == BackendMessage {
+			err = pressKeyDef(def)
+		} else if cb == BackendHID {
+			err = hidTypeRune(r, i, runes)
+		} else {
+			err = sendUnicode(r)
+		}
+		if err != nil {
+			return err
+		}
+		sent++
+		time.Sleep(delay)
+	}
+	return nil
+}
@@ -0,0 +1,246 @@
+//go:build windows
+
+package gamepad
+
+import (
+	"encoding/binary"
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// PadOptions configures NewX360PadWithOptions.
+type PadOptions struct {
+	// ReportInterval is how often pending state changes are submitted to the bus.
+	// 0 means the default (8ms); a negative value disables the submitter, in which
+	// case changes are only sent by Flush.
+	ReportInterval time.Duration
+}
+
+var defaultPadOptions = PadOptions{
+	ReportInterval: 8 * time.Millisecond,
+}
+
+// Pad is a virtual Xbox 360 controller plugged into the ViGEm bus.
+//
+// All methods are safe for concurrent use: each Set* call updates the state atomically
+// and every submitted report is a consistent snapshot, so calls from several goroutines
+// never produce a half-applied stick or trigger.
+type Pad struct {
+	mu     sync.Mutex
+	client uintptr
+	target uintptr
+	state  Report
+	dirty  bool
+	closed bool
+	err    error // first error from the background submitter
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewX360Pad plugs in a virtual Xbox 360 controller.
+// It returns ErrLibraryNotFound if ViGEmClient.dll cannot be loaded and
+// ErrBusNotInstalled if the ViGEm bus driver is missing.
+func NewX360Pad() (*Pad, error) {
+	return NewX360PadWithOptions(defaultPadOptions)
+}
+
+// NewX360PadWithOptions is NewX360Pad with custom options.
+func NewX360PadWithOptions(opts PadOptions) (*Pad, error) {
+	if opts.ReportInterval == 0 {
+		opts.ReportInterval = defaultPadOptions.ReportInterval
+	}
+	if err := Load(); err != nil {
+		return nil, err
+	}
+
+	client, _, _ := syscall.SyscallN(procAlloc)
+	if client == 0 {
+		return nil, fmt.Errorf("vigem_alloc failed")
+	}
+	r, _, _ := syscall.SyscallN(procConnect, client)
+	if err := vigemError("vigem_connect", r); err != nil {
+		syscall.SyscallN(procFree, client)
+		return nil, err
+	}
+
+	target, _, _ := syscall.SyscallN(procX360Alloc)
+	if target == 0 {
+		syscall.SyscallN(procDisconnect, client)
+		syscall.SyscallN(procFree, client)
+		return nil, fmt.Errorf("vigem_target_x360_alloc failed")
+	}
+	r, _, _ = syscall.SyscallN(procTargetAdd, client, target)
+	if err := vigemError("vigem_target_add", r); err != nil {
+		syscall.SyscallN(procTargetFree, target)
+		syscall.SyscallN(procDisconnect, client)
+		syscall.SyscallN(procFree, client)
+		return nil, err
+	}
+
+	p := &Pad{client: client, target: target}
+	if opts.ReportInterval > 0 {
+		p.stop = make(chan struct{})
+		p.done = make(chan struct{})
+		go p.submitter(opts.ReportInterval)
+	}
+	return p, nil
+}
+
+// SetButton presses (down=true) or releases the given buttons.
+func (p *Pad) SetButton(b Button, down bool) error {
+	return p.update(func(r *Report) error {
+		r.setButton(b, down)
+		return nil
+	})
+}
+
+// SetTrigger sets an analog trigger (0 = released, 255 = fully pressed).
+func (p *Pad) SetTrigger(t Trigger, value uint8) error {
+	return p.update(func(r *Report) error {
+		if !r.setTrigger(t, value) {
+			return fmt.Errorf("gamepad: invalid trigger %d", t)
+		}
+		return nil
+	})
+}
+
+// SetStick sets a thumbstick position. Axes range -32768 to 32767; (0, 0) is centered.
+func (p *Pad) SetStick(s Stick, x, y int16) error {
+	return p.update(func(r *Report) error {
+		if !r.setStick(s, x, y) {
+			return fmt.Errorf("gamepad: invalid stick %d", s)
+		}
+		return nil
+	})
+}
+
+// SetState replaces the whole controller state.
+func (p *Pad) SetState(r Report) error {
+	return p.update(func(cur *Report) error {
+		*cur = r
+		return nil
+	})
+}
+
+// Reset releases all buttons and triggers and centers both sticks.
+func (p *Pad) Reset() error {
+	return p.SetState(Report{})
+}
+
+// State returns the current controller state, including changes not yet submitted.
+func (p *Pad) State() Report {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state
+}
+
+// Flush submits the current state to the bus immediately.
+func (p *Pad) Flush() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrPadClosed
+	}
+	return p.submitLocked()
+}
+
+// Err returns the first error encountered by the background submitter, if any.
+func (p *Pad) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
+
+// Close stops the submitter and unplugs the controller.
+// It is safe to call Close more than once.
+func (p *Pad) Close() error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mu.Unlock()
+
+	if p.stop != nil {
+		close(p.stop)
+		<-p.done
+	}
+
+	r, _, _ := syscall.SyscallN(procTargetRemove, p.client, p.target)
+	err := vigemError("vigem_target_remove", r)
+	syscall.SyscallN(procTargetFree, p.target)
+	syscall.SyscallN(procDisconnect, p.client)
+	syscall.SyscallN(procFree, p.client)
+	return err
+}
+
+func (p *Pad) update(fn func(*Report) error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return ErrPadClosed
+	}
+	if err := fn(&p.state); err != nil {
+		return err
+	}
+	p.dirty = true
+	return nil
+}
+
+func (p *Pad) submitter(interval time.Duration) {
+	defer close(p.done)
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-t.C:
+			p.mu.Lock()
+			if p.dirty {
+				if err := p.submitLocked(); err != nil && p.err == nil {
+					p.err = err
+				}
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// submitLocked sends the current state. p.mu must be held.
+func (p *Pad) submitLocked() error {
+	var b [12]byte
+	binary.LittleEndian.PutUint16(b[0:], uint16(p.state.Buttons))
+	b[2] = p.state.LeftTrigger
+	b[3] = p.state.RightTrigger
+	binary.LittleEndian.PutUint16(b[4:], uint16(p.state.ThumbLX))
+	binary.LittleEndian.PutUint16(b[6:], uint16(p.state.ThumbLY))
+	binary.LittleEndian.PutUint16(b[8:], uint16(p.state.ThumbRX))
+	binary.LittleEndian.PutUint16(b[10:], uint16(p.state.ThumbRY))
+
+	// XUSB_REPORT is passed by value: by reference on amd64, in two registers on
+	// arm64 and as three stack words on 386.
+	var r uintptr
+	switch runtime.GOARCH {
+	case "amd64":
+		r, _, _ = syscall.SyscallN(procX360Update, p.client, p.target, uintptr(unsafe.Pointer(&b)))
+	case "arm64":
+		r, _, _ = syscall.SyscallN(procX360Update, p.client, p.target,
+			uintptr(binary.LittleEndian.Uint64(b[0:])), uintptr(binary.LittleEndian.Uint32(b[8:])))
+	default:
+		r, _, _ = syscall.SyscallN(procX360Update, p.client, p.target,
+			uintptr(binary.LittleEndian.Uint32(b[0:])), uintptr(binary.LittleEndian.Uint32(b[4:])),
+			uintptr(binary.LittleEndian.Uint32(b[8:])))
+	}
+	if err := vigemError("vigem_target_x360_update", r); err != nil {
+		return err
+	}
+	p.dirty = false
+	return nil
+}
@@ -0,0 +1,82 @@
+package gamepad
+
+// Button is an Xbox 360 controller button (XUSB_BUTTON). Values may be OR-ed together.
+type Button uint16
+
+const (
+	ButtonDPadUp        Button = 0x0001
+	ButtonDPadDown      Button = 0x0002
+	ButtonDPadLeft      Button = 0x0004
+	ButtonDPadRight     Button = 0x0008
+	ButtonStart         Button = 0x0010
+	ButtonBack          Button = 0x0020
+	ButtonLeftThumb     Button = 0x0040
+	ButtonRightThumb    Button = 0x0080
+	ButtonLeftShoulder  Button = 0x0100
+	ButtonRightShoulder Button = 0x0200
+	ButtonGuide         Button = 0x0400
+	ButtonA             Button = 0x1000
+	ButtonB             Button = 0x2000
+	ButtonX             Button = 0x4000
+	ButtonY             Button = 0x8000
+)
+
+// Trigger selects one of the analog triggers.
+type Trigger int
+
+const (
+	TriggerLeft Trigger = iota
+	TriggerRight
+)
+
+// Stick selects one of the thumbsticks.
+type Stick int
+
+const (
+	StickLeft Stick = iota
+	StickRight
+)
+
+// Report is the controller state sent to the bus (XUSB_REPORT).
+// Triggers range 0-255; stick axes range -32768 to 32767 with +Y pointing up.
+type Report struct {
+	Buttons      Button
+	LeftTrigger  uint8
+	RightTrigger uint8
+	ThumbLX      int16
+	ThumbLY      int16
+	ThumbRX      int16
+	ThumbRY      int16
+}
+
+func (r *Report) setButton(b Button, down bool) {
+	if down {
+		r.Buttons |= b
+	} else {
+		r.Buttons &^= b
+	}
+}
+
+func (r *Report) setTrigger(t Trigger, v uint8) bool {
+	switch t {
+	case TriggerLeft:
+		r.LeftTrigger = v
+	case TriggerRight:
+		r.RightTrigger = v
+	default:
+		return false
+	}
+	return true
+}
+
+func (r *Report) setStick(s Stick, x, y int16) bool {
+	switch s {
+	case StickLeft:
+		r.ThumbLX, r.ThumbLY = x, y
+	case StickRight:
+		r.ThumbRX, r.ThumbRY = x, y
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package gamepad
+
+import "testing"
+
+func TestReportUpdates(t *testing.T) {
+	var r Report
+	r.setButton(ButtonA|ButtonB, true)
+	r.setButton(ButtonB, false)
+	if r.Buttons != ButtonA {
+		t.Errorf("Buttons = %#x, want %#x", r.Buttons, ButtonA)
+	}
+
+	if !r.setTrigger(TriggerRight, 200) || r.RightTrigger != 200 || r.LeftTrigger != 0 {
+		t.Errorf("setTrigger(TriggerRight) = %+v", r)
+	}
+	if r.setTrigger(Trigger(5), 1) {
+		t.Error("setTrigger accepted an invalid trigger")
+	}
+
+	if !r.setStick(StickLeft, -32768, 32767) || r.ThumbLX != -32768 || r.ThumbLY != 32767 {
+		t.Errorf("setStick(StickLeft) = %+v", r)
+	}
+	if r.setStick(Stick(-1), 0, 0) {
+		t.Error("setStick accepted an invalid stick")
+	}
+}
@@ -0,0 +1,116 @@
+//go:build windows
+
+package gamepad
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"syscall"
+)
+
+var (
+	// ErrLibraryNotFound is returned when ViGEmClient.dll cannot be loaded.
+	ErrLibraryNotFound = errors.New("ViGEmClient library not found")
+	// ErrBusNotInstalled implies the ViGEm bus driver is missing or not accessible.
+	ErrBusNotInstalled = errors.New("ViGEm bus driver not installed or accessible")
+	// ErrNoFreeSlot is returned when the bus has no free slot for another virtual controller.
+	ErrNoFreeSlot = errors.New("ViGEm bus has no free slot")
+	// ErrPadClosed is returned when a Pad is used after Close.
+	ErrPadClosed = errors.New("gamepad closed")
+)
+
+// VIGEM_ERROR codes (ViGEmClient.h).
+const (
+	vigemErrorNone               = 0x20000000
+	vigemErrorBusNotFound        = 0xE0000001
+	vigemErrorNoFreeSlot         = 0xE0000002
+	vigemErrorBusVersionMismatch = 0xE0000008
+	vigemErrorBusAccessFailed    = 0xE0000009
+)
+
+var (
+	dllMu     sync.Mutex
+	dllHandle syscall.Handle
+
+	procAlloc        uintptr
+	procFree         uintptr
+	procConnect      uintptr
+	procDisconnect   uintptr
+	procX360Alloc    uintptr
+	procTargetFree   uintptr
+	procTargetAdd    uintptr
+	procTargetRemove uintptr
+	procX360Update   uintptr
+)
+
+// Default library name
+var libraryPath = "ViGEmClient.dll"
+
+// SetLibraryPath sets the path for LoadLibrary.
+func SetLibraryPath(path string) {
+	dllMu.Lock()
+	defer dllMu.Unlock()
+	libraryPath = path
+}
+
+// Load loads ViGEmClient.dll and resolves function addresses.
+// NewX360Pad calls it automatically.
+func Load() error {
+	dllMu.Lock()
+	defer dllMu.Unlock()
+
+	if dllHandle != 0 {
+		return nil
+	}
+
+	h, err := syscall.LoadLibrary(libraryPath)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrLibraryNotFound, err)
+	}
+
+	procs := []struct {
+		addr *uintptr
+		name string
+	}{
+		{&procAlloc, "vigem_alloc"},
+		{&procFree, "vigem_free"},
+		{&procConnect, "vigem_connect"},
+		{&procDisconnect, "vigem_disconnect"},
+		{&procX360Alloc, "vigem_target_x360_alloc"},
+		{&procTargetFree, "vigem_target_free"},
+		{&procTargetAdd, "vigem_target_add"},
+		{&procTargetRemove, "vigem_target_remove"},
+		{&procX360Update, "vigem_target_x360_update"},
+	}
+	for _, p := range procs {
+		if *p.addr = getProc(h, p.name); *p.addr == 0 {
+			for _, p := range procs {
+				*p.addr = 0
+			}
+			syscall.FreeLibrary(h)
+			return fmt.Errorf("%w: library loaded but symbol %s missing", ErrLibraryNotFound, p.name)
+		}
+	}
+
+	dllHandle = h
+	return nil
+}
+
+func getProc(h syscall.Handle, name string) uintptr {
+	addr, _ := syscall.GetProcAddress(h, name)
+	return addr
+}
+
+// vigemError maps a VIGEM_ERROR result of op to a Go error.
+func vigemError(op string, r uintptr) error {
+	switch uint32(r) {
+	case vigemErrorNone:
+		return nil
+	case vigemErrorBusNotFound, vigemErrorBusAccessFailed, vigemErrorBusVersionMismatch:
+		return fmt.Errorf("%w: %s returned 0x%08X", ErrBusNotInstalled, op, uint32(r))
+	case vigemErrorNoFreeSlot:
+		return ErrNoFreeSlot
+	}
+	return fmt.Errorf("%s failed: 0x%08X", op, uint32(r))
+}
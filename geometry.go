@@ -0,0 +1,202 @@
+//go:build windows
+
+package winput
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// -----------------------------------------------------------------------------
+// Window Geometry
+// -----------------------------------------------------------------------------
+
+// mapAccessDenied translates window.ErrAccessDenied into ErrPermissionDenied.
+func mapAccessDenied(err error) error {
+	if errors.Is(err, window.ErrAccessDenied) {
+		return fmt.Errorf("%w: %v", ErrPermissionDenied, err)
+	}
+	return err
+}
+
+// SetPosition moves the window's outer frame to (x, y): screen coordinates for a top-level
+// window, parent client coordinates for a child. It keeps the size and z-order and does not
+// activate the window.
+func (w *Window) SetPosition(x, y int32) error {
+	return w.setWindowPos(x, y, 0, 0, window.SWP_NOSIZE)
+}
+
+// Resize sets the outer size of the window, keeping its position. Use ResizeClient to size
+// the client area, which Click and Move coordinates are relative to.
+func (w *Window) Resize(width, height int32) error {
+	return w.setWindowPos(0, 0, width, height, window.SWP_NOMOVE)
+}
+
+// SetBounds moves and resizes the window's outer frame to r (see SetPosition).
+func (w *Window) SetBounds(r Rect) error {
+	return w.setWindowPos(r.Left, r.Top, r.Right-r.Left, r.Bottom-r.Top, 0)
+}
+
+// ResizeClient resizes the window so that its client area is width x height, keeping its
+// position. The frame size comes from AdjustWindowRectExForDpi for the window's styles and
+// DPI; if the resulting client area still differs (e.g. a wrapping menu bar), the size is
+// corrected once by the difference.
+func (w *Window) ResizeClient(width, height int32) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	ow, oh, err := window.WindowSizeForClient(w.HWND, width, height)
+	if err != nil {
+		return err
+	}
+	if err := w.Resize(ow, oh); err != nil {
+		return err
+	}
+	cw, ch, err := window.GetClientRect(w.HWND)
+	if err != nil {
+		return err
+	}
+	if cw != width || ch != height {
+		return w.Resize(ow+width-cw, oh+height-ch)
+	}
+	return nil
+}
+
+func (w *Window) setWindowPos(x, y, width, height int32, flags uint32) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	flags |= window.SWP_NOZORDER | window.SWP_NOACTIVATE | window.SWP_NOOWNERZORDER
+	return mapAccessDenied(window.SetWindowPos(w.HWND, 0, x, y, width, height, flags))
+}
+
+// SetPosImmediate moves and resizes the window (outer frame, screen coordinates) with DWM
+// transition animations disabled, then waits up to timeout for the change to settle:
+// the rect must stop changing, the window must have processed its queued messages
+// (including WM_WINDOWPOSCHANGED), and DWM must have composed a frame.
+// The animation attribute is re-enabled before returning.
+func (w *Window) SetPosImmediate(x, y, width, height int32, timeout time.Duration) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	if err := w.checkSelfTarget(); err != nil {
+		return err
+	}
+
+	// Best effort: DWM may be unavailable (composition disabled), the move still happens.
+	if err := window.SetTransitionsDisabled(w.HWND, true); err == nil {
+		defer window.SetTransitionsDisabled(w.HWND, false)
+	}
+
+	if err := window.SetWindowPos(w.HWND, 0, x, y, width, height,
+		window.SWP_NOZORDER|window.SWP_NOACTIVATE|window.SWP_NOOWNERZORDER); err != nil {
+		return mapAccessDenied(err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	if err := w.WaitStableRect(timeout); err != nil {
+		return err
+	}
+	remaining := time.Until(deadline)
+	if remaining < time.Millisecond {
+		remaining = time.Millisecond
+	}
+	if err := window.Ping(w.HWND, uint32(remaining/time.Millisecond)); err != nil {
+		return fmt.Errorf("%w: window did not process reposition: %v", ErrTimeout, err)
+	}
+	window.DwmFlush()
+	return nil
+}
+
+// WaitStableRect polls the window rect until it stops changing (unchanged for 3 consecutive
+// polls, ~50ms) or the timeout expires, in which case ErrTimeout is returned.
+func (w *Window) WaitStableRect(timeout time.Duration) error {
+	const (
+		pollInterval = 16 * time.Millisecond
+		stablePolls  = 3
+	)
+
+	deadline := time.Now().Add(timeout)
+	last, err := window.GetWindowRect(w.HWND)
+	if err != nil {
+		return ErrWindowGone
+	}
+
+	stable := 0
+	for stable < stablePolls {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: window rect still changing after %v", ErrTimeout, timeout)
+		}
+		time.Sleep(pollInterval)
+
+		rc, err := window.GetWindowRect(w.HWND)
+		if err != nil {
+			return ErrWindowGone
+		}
+		if rc == last {
+			stable++
+		} else {
+			stable = 0
+			last = rc
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// Z-Order
+// -----------------------------------------------------------------------------
+
+// zOrderFlags repositions in z-order only, without moving, resizing or activating.
+const zOrderFlags = window.SWP_NOMOVE | window.SWP_NOSIZE | window.SWP_NOACTIVATE
+
+// IsTopMost reports whether the window is always-on-top (WS_EX_TOPMOST).
+func (w *Window) IsTopMost() bool {
+	return window.IsTopMost(w.HWND)
+}
+
+// SetTopMost makes the window always-on-top, or returns it to the normal z-order band,
+// without activating it. It returns ErrPermissionDenied if UIPI blocks the change, e.g.
+// for an elevated window.
+func (w *Window) SetTopMost(topmost bool) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	after := window.HWND_NOTOPMOST
+	if topmost {
+		after = window.HWND_TOPMOST
+	}
+	if err := window.SetWindowPos(w.HWND, after, 0, 0, 0, 0, zOrderFlags); err != nil {
+		return mapAccessDenied(err)
+	}
+	if w.IsTopMost() != topmost {
+		return fmt.Errorf("%w: topmost state of the window did not change", ErrPermissionDenied)
+	}
+	return nil
+}
+
+// SendToBack moves the window to the bottom of the z-order without activating it,
+// e.g. to push a window that obscures the target out of the way without changing the
+// target's state. A topmost window loses its topmost status.
+func (w *Window) SendToBack() error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	return mapAccessDenied(window.SetWindowPos(w.HWND, window.HWND_BOTTOM, 0, 0, 0, 0, zOrderFlags))
+}
+
+// InsertAfter places the window directly below other in the z-order without activating either.
+// A non-topmost window cannot be placed among topmost windows; that case returns
+// ErrZOrderTopMost instead of silently changing the window's topmost state.
+func (w *Window) InsertAfter(other *Window) error {
+	if !w.IsValid() || !other.IsValid() {
+		return ErrWindowGone
+	}
+	if other.IsTopMost() && !w.IsTopMost() {
+		return ErrZOrderTopMost
+	}
+	return mapAccessDenied(window.SetWindowPos(w.HWND, other.HWND, 0, 0, 0, 0, zOrderFlags))
+}
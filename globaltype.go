@@ -0,0 +1,173 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/rpdg/winput/keyboard"
+	"github.com/rpdg/winput/window"
+)
+
+// GlobalTypeMethod is one rung of the Message-backend fallback ladder used by the global Type.
+type GlobalTypeMethod int
+
+const (
+	// GlobalTypeSendInput injects Unicode key events with SendInput.
+	GlobalTypeSendInput GlobalTypeMethod = iota
+	// GlobalTypePostChar posts WM_CHAR to the focused control of the foreground window.
+	GlobalTypePostChar
+	// GlobalTypeClipboard places the text on the clipboard (overwriting it) and posts WM_PASTE
+	// to the focused control of the foreground window.
+	GlobalTypeClipboard
+)
+
+func (m GlobalTypeMethod) String() string {
+	switch m {
+	case GlobalTypeSendInput:
+		return "SendInput"
+	case GlobalTypePostChar:
+		return "WM_CHAR"
+	case GlobalTypeClipboard:
+		return "clipboard"
+	}
+	return fmt.Sprintf("GlobalTypeMethod(%d)", int(m))
+}
+
+// sendInputRetryAfter is how long a failed SendInput probe is cached before re-probing.
+const sendInputRetryAfter = 5 * time.Second
+
+var (
+	globalTypeMu      sync.Mutex
+	globalTypeMethods = []GlobalTypeMethod{GlobalTypeSendInput, GlobalTypePostChar, GlobalTypeClipboard}
+
+	sendInputOK        bool
+	sendInputErr       error
+	sendInputNextProbe time.Time
+)
+
+// SetGlobalTypeMethods sets the methods the global Type tries, in order, under the Message backend.
+// The default is SendInput, then WM_CHAR, then clipboard. Calling it with no methods restores the default.
+func SetGlobalTypeMethods(methods ...GlobalTypeMethod) {
+	globalTypeMu.Lock()
+	defer globalTypeMu.Unlock()
+	if len(methods) == 0 {
+		methods = []GlobalTypeMethod{GlobalTypeSendInput, GlobalTypePostChar, GlobalTypeClipboard}
+	}
+	globalTypeMethods = append([]GlobalTypeMethod(nil), methods...)
+}
+
+// probeSendInput reports whether SendInput is usable. Success is cached for the life of the
+// process; failure is cached for sendInputRetryAfter so a later login or elevation is picked up.
+func probeSendInput() error {
+	globalTypeMu.Lock()
+	defer globalTypeMu.Unlock()
+
+	if sendInputOK {
+		return nil
+	}
+	if sendInputErr != nil && time.Now().Before(sendInputNextProbe) {
+		return sendInputErr
+	}
+
+	if err := testSendInput(); err != nil {
+		sendInputErr = fmt.Errorf("%w: %v", ErrNoInteractiveSession, err)
+		sendInputNextProbe = time.Now().Add(sendInputRetryAfter)
+		return sendInputErr
+	}
+	sendInputOK, sendInputErr = true, nil
+	return nil
+}
+
+type mouseInput struct {
+	Dx        int32
+	Dy        int32
+	MouseData uint32
+	DwFlags   uint32
+	Time      uint32
+	DwExtra   uintptr
+}
+
+type mouseInputEvent struct {
+	Type uint32
+	Mi   mouseInput
+}
+
+const (
+	INPUT_MOUSE      = 0
+	MOUSEEVENTF_MOVE = 0x0001
+)
+
+// testSendInput injects a zero-distance relative mouse move, which is invisible to the user.
+func testSendInput() error {
+	in := mouseInputEvent{Type: INPUT_MOUSE}
+	in.Mi.DwFlags = MOUSEEVENTF_MOVE
+	n, _, e := window.ProcSendInput.Call(1, uintptr(unsafe.Pointer(&in)), unsafe.Sizeof(in))
+	if n == 0 {
+		return fmt.Errorf("SendInput failed: %v", e)
+	}
+	return nil
+}
+
+// typeGlobalMessage walks the configured ladder. A method is only chosen if its
+// preconditions hold, so text is never delivered twice. If every method fails,
+// the error lists each attempt and wraps the first failure.
+func typeGlobalMessage(text string) error {
+	globalTypeMu.Lock()
+	methods := globalTypeMethods
+	globalTypeMu.Unlock()
+
+	var first error
+	var attempts []string
+	fail := func(m GlobalTypeMethod, err error) {
+		if first == nil {
+			first = err
+		}
+		attempts = append(attempts, fmt.Sprintf("%s: %v", m, err))
+	}
+
+	for _, m := range methods {
+		switch m {
+		case GlobalTypeSendInput:
+			if err := probeSendInput(); err != nil {
+				fail(m, err)
+				continue
+			}
+			delay := GetTiming().KeyDelay
+			for _, r := range text {
+				if err := sendUnicode(r); err != nil {
+					return err
+				}
+				time.Sleep(delay)
+			}
+			return nil
+
+		case GlobalTypePostChar, GlobalTypeClipboard:
+			target := window.FocusedWindow()
+			if target == 0 {
+				fail(m, fmt.Errorf("%w: no foreground window", ErrNoInteractiveSession))
+				continue
+			}
+			if m == GlobalTypePostChar {
+				return keyboard.TypeWithDelay(target, text, GetTiming().KeyDelay)
+			}
+			if err := window.SetClipboardText(text); err != nil {
+				fail(m, err)
+				continue
+			}
+			return keyboard.Paste(target)
+
+		default:
+			fail(m, fmt.Errorf("unknown method"))
+		}
+	}
+
+	if first == nil {
+		return fmt.Errorf("global Type: no methods configured")
+	}
+	return fmt.Errorf("global Type failed (%s): %w", strings.Join(attempts, "; "), first)
+}
@@ -1,30 +1,111 @@
+//go:build windows
+
 package hid
 
 import (
 	"errors"
 	"fmt"
-	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/rpdg/winput/hid/interception"
+	"github.com/rpdg/winput/screen"
 	"github.com/rpdg/winput/window"
 )
 
+const (
+	smCXDoubleClk = 36
+	smCYDoubleClk = 37
+)
+
 var ErrDriverNotInstalled = errors.New("interception driver not installed or accessible")
 
-// SetLibraryPath sets the custom path for the interception.dll library.
-func SetLibraryPath(path string) {
+// ErrLibraryLoaded is returned by SetLibraryPath once a library from another path is loaded.
+var ErrLibraryLoaded = interception.ErrAlreadyLoaded
+
+// ErrLibraryInUse is returned by Reload when input operations hold the driver.
+var ErrLibraryInUse = errors.New("interception library in use")
+
+// ErrMoveInaccurate is returned when Move cannot bring the cursor exactly onto the target.
+var ErrMoveInaccurate = errors.New("mouse move did not reach the target")
+
+// MoveInaccurateError reports where the cursor ended up after Move gave up.
+// It matches ErrMoveInaccurate with errors.Is.
+type MoveInaccurateError struct {
+	TargetX, TargetY int32
+	X, Y             int32 // final cursor position
+	// EdgeConstrained is set when the target or the trajectory had to be clamped to the
+	// desktop, or the cursor stalled against a screen edge on the way.
+	EdgeConstrained bool
+}
+
+func (e *MoveInaccurateError) Error() string {
+	msg := fmt.Sprintf("%v: target (%d,%d), cursor at (%d,%d)", ErrMoveInaccurate, e.TargetX, e.TargetY, e.X, e.Y)
+	if e.EdgeConstrained {
+		msg += " (edge-constrained)"
+	}
+	return msg
+}
+
+func (e *MoveInaccurateError) Unwrap() error {
+	return ErrMoveInaccurate
+}
+
+// SetLibraryPath sets the custom path for the interception.dll library. It fails with
+// ErrLibraryLoaded once a library from another path is loaded; use Reload
+// to switch libraries then.
+func SetLibraryPath(path string) error {
+	initMutex.Lock()
+	defer initMutex.Unlock()
+	return interception.SetLibraryPath(path)
+}
+
+// LoadedPath returns the path the loaded interception library came from, or "" if the
+// backend has not loaded one.
+func LoadedPath() string {
+	initMutex.RLock()
+	defer initMutex.RUnlock()
+	return interception.LoadedPath()
+}
+
+// Reload switches the backend to the interception library at path: it closes the
+// context, unloads the current library and initializes from path, all under the driver
+// lock, so no input operation sees a half-switched backend. If path cannot be
+// initialized, the previous library is restored when possible and the error is returned.
+//
+// Reload does not wait for input in progress: it fails with ErrLibraryInUse, reporting
+// the number of operations in flight, if any hold the driver.
+func Reload(path string) error {
+	if !initMutex.TryLock() {
+		if n := inFlight.Load(); n > 0 {
+			return fmt.Errorf("%w: %d input operations in flight", ErrLibraryInUse, n)
+		}
+		return fmt.Errorf("%w: backend is being initialized or closed", ErrLibraryInUse)
+	}
+	defer initMutex.Unlock()
+
+	prev := interception.LibraryPath()
+	wasInit := initialized
+	closeLocked()
 	interception.SetLibraryPath(path)
+	err := initLocked()
+	if err == nil {
+		return nil
+	}
+	interception.SetLibraryPath(prev)
+	if wasInit {
+		if rerr := initLocked(); rerr != nil {
+			return fmt.Errorf("%w (restoring %s failed: %v)", err, prev, rerr)
+		}
+	}
+	return err
 }
 
 const (
 	MaxInterceptionDevices = 20
 )
 
-// Use a local random source instead of global rand
-var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
-
 var (
 	ctx         interception.Context
 	mouseDev    interception.Device
@@ -34,6 +115,8 @@ var (
 	// RLock is held during ANY input operation to prevent Close() from destroying
 	// the context mid-operation.
 	initMutex sync.RWMutex
+	// inFlight counts the input operations holding initMutex, for Reload's diagnostics.
+	inFlight atomic.Int32
 )
 
 // Init initializes the Interception context and finds devices.
@@ -45,7 +128,11 @@ func Init() error {
 	if initialized {
 		return nil
 	}
+	return initLocked()
+}
 
+// initLocked loads the library and creates the context. initMutex must be held exclusively.
+func initLocked() error {
 	if err := interception.Load(); err != nil {
 		return err
 	}
@@ -84,8 +171,14 @@ func Close() error {
 	initMutex.Lock()
 	defer initMutex.Unlock()
 
+	closeLocked()
+	return nil
+}
+
+// closeLocked destroys the context and unloads the library. initMutex must be held exclusively.
+func closeLocked() {
 	if !initialized {
-		return nil
+		return
 	}
 
 	if ctx != 0 {
@@ -97,7 +190,6 @@ func Close() error {
 	initialized = false
 
 	interception.Unload()
-	return nil
 }
 
 // EnsureInit checks if the HID backend is initialized, and initializes it if not.
@@ -111,6 +203,29 @@ func EnsureInit() error {
 	return Init()
 }
 
+// Probe checks whether the Interception library loads and the driver accepts a context,
+// without leaving the backend initialized. It returns nil if the backend is already initialized.
+func Probe() error {
+	initMutex.Lock()
+	defer initMutex.Unlock()
+
+	if initialized {
+		return nil
+	}
+
+	if err := interception.Load(); err != nil {
+		return err
+	}
+	defer interception.Unload()
+
+	c := interception.CreateContext()
+	if c == 0 {
+		return ErrDriverNotInstalled
+	}
+	interception.DestroyContext(c)
+	return nil
+}
+
 func humanSleep(base int) {
 	maxJitter := base / 3
 	if maxJitter == 0 {
@@ -125,65 +240,116 @@ func humanSleep(base int) {
 	time.Sleep(time.Duration(duration) * time.Millisecond)
 }
 
+var lockWaitHook atomic.Pointer[func(time.Duration)]
+
+// SetLockWaitHook installs fn to be called with how long each input operation waited for
+// the driver lock, which is held exclusively only by Init and Close. wait is 0 when the
+// lock was free. Pass nil to remove it; without a hook the lock is taken directly.
+func SetLockWaitHook(fn func(wait time.Duration)) {
+	if fn == nil {
+		lockWaitHook.Store(nil)
+		return
+	}
+	lockWaitHook.Store(&fn)
+}
+
+// rlockInit read-locks initMutex, reporting the wait to the lock wait hook if one is set.
+func rlockInit() {
+	fn := lockWaitHook.Load()
+	if fn == nil {
+		initMutex.RLock()
+		return
+	}
+	if initMutex.TryRLock() {
+		(*fn)(0)
+		return
+	}
+	start := time.Now()
+	initMutex.RLock()
+	(*fn)(time.Since(start))
+}
+
+// runlockInit releases the read lock taken by acquireMouse or acquireKeyboard.
+func runlockInit() {
+	inFlight.Add(-1)
+	initMutex.RUnlock()
+}
+
 // Helper to acquire lock and return handles.
 // Caller MUST call unlock() when done.
 func acquireMouse() (interception.Context, interception.Device, func(), error) {
 	if err := EnsureInit(); err != nil {
 		return 0, 0, nil, err
 	}
-	initMutex.RLock()
+	rlockInit()
 	if !initialized {
 		initMutex.RUnlock()
 		return 0, 0, nil, fmt.Errorf("hid backend closed")
 	}
-	return ctx, mouseDev, initMutex.RUnlock, nil
+	inFlight.Add(1)
+	return ctx, mouseDev, runlockInit, nil
 }
 
 func acquireKeyboard() (interception.Context, interception.Device, func(), error) {
 	if err := EnsureInit(); err != nil {
 		return 0, 0, nil, err
 	}
-	initMutex.RLock()
+	rlockInit()
 	if !initialized {
 		initMutex.RUnlock()
 		return 0, 0, nil, fmt.Errorf("hid backend closed")
 	}
-	return ctx, keyboardDev, initMutex.RUnlock, nil
+	inFlight.Add(1)
+	return ctx, keyboardDev, runlockInit, nil
 }
 
 // -----------------------------------------------------------------------------
 // Mouse
 // -----------------------------------------------------------------------------
 
-func abs(n int32) int32 {
-	if n < 0 {
-		return -n
-	}
-	return n
-}
-
-func max(a, b int32) int32 {
-	if a > b {
-		return a
+// currentDesktop returns the monitor layout for clamping. If monitors cannot be
+// enumerated it falls back to the virtual desktop rectangle.
+func currentDesktop() desktop {
+	d := desktop{virtual: screen.VirtualBounds()}
+	if mons, err := screen.Monitors(); err == nil {
+		for _, m := range mons {
+			d.monitors = append(d.monitors, m.Bounds)
+		}
 	}
-	return b
+	return d
 }
 
 // Move simulates mouse movement to the target screen coordinates using human-like trajectory.
+//
+// Waypoints are clamped to the desktop (1px inside monitor edges), so relative strokes never
+// pile up against an edge; a target outside every monitor is moved to the nearest reachable
+// point. If the cursor stops moving at a checkpoint although strokes were sent, it has hit
+// an edge, and the rest of the trajectory is re-planned from its actual position.
+// The correction phase aims for the exact target, but a cursor left within 1px of it (e.g.
+// by enhanced pointer precision) is accepted, so clicks and drags still happen there. A
+// larger miss, or a target that could not be reached because of an edge, returns a
+// *MoveInaccurateError.
 func Move(targetX, targetY int32) error {
 	lCtx, lDev, unlock, err := acquireMouse()
 	if err != nil {
 		return err
 	}
 	defer unlock()
+	return moveRaw(lCtx, lDev, targetX, targetY)
+}
 
+// moveRaw is Move for a caller that holds the lock/context.
+func moveRaw(lCtx interception.Context, lDev interception.Device, targetX, targetY int32) error {
 	cx, cy, err := window.GetCursorPos()
 	if err != nil {
 		return err
 	}
 
-	dxTotal := abs(targetX - cx)
-	dyTotal := abs(targetY - cy)
+	d := currentDesktop()
+	target, edge := d.clamp(point{targetX, targetY}, 0)
+
+	dxTotal := abs(target.x - cx)
+	dyTotal := abs(target.y - cy)
 	maxDist := max(dxTotal, dyTotal)
 
 	// Adaptive steps calculation
@@ -204,49 +370,41 @@ func Move(targetX, targetY int32) error {
 
 	timeout := time.After(3 * time.Second) // Increased timeout for robustness
 
-	// 1. Trajectory Loop
-	for i := 1; i <= steps; i++ {
+	// 1. Trajectory Loop (dead reckoning)
+	// Deltas are computed from the planned path rather than from GetCursorPos, which lags
+	// behind injected input when pointer trails or cursor shadow are enabled. The real
+	// position is only consulted at checkpoints to absorb pointer acceleration drift.
+	path := planPath(cx, cy, target.x, target.y, steps, jitterFunc(steps))
+	if d.clampPath(path) {
+		edge = true
+	}
+	prev := point{cx, cy}
+	lastCheck := prev
+	var carryX, carryY int32 // correction owed from the last checkpoint
+	sent := false            // strokes were sent since the last checkpoint
+	for i := 0; i < len(path); i++ {
+		p := path[i]
 		select {
 		case <-timeout:
 			return fmt.Errorf("move timeout during trajectory")
 		default:
 		}
 
-		nextX := cx + (targetX-cx)*int32(i)/int32(steps)
-		nextY := cy + (targetY-cy)*int32(i)/int32(steps)
-
-		curX, curY, err := window.GetCursorPos()
-		if err != nil {
-			return err
-		}
+		dx := p.x - prev.x + carryX
+		dy := p.y - prev.y + carryY
+		carryX, carryY = 0, 0
+		prev = p
 
-		dx := nextX - curX
-		dy := nextY - curY
-
-		// Optimization: If very close (within jitter range), skip correction
-		// to avoid oscillation near the target.
-		if i > steps-5 && abs(dx) < 3 && abs(dy) < 3 {
-			continue
-		}
-
-		// Apply jitter only if not the final few steps
-		if i < steps-2 {
-			dx += int32(rng.Intn(3) - 1)
-			dy += int32(rng.Intn(3) - 1)
-		}
-
-		if dx == 0 && dy == 0 {
-			continue
-		}
-
-		stroke := interception.MouseStroke{
-			Flags: interception.MouseFlagMoveRelative,
-			X:     dx,
-			Y:     dy,
-		}
-
-		if err := interception.SendMouse(lCtx, lDev, &stroke); err != nil {
-			return err
+		if dx != 0 || dy != 0 {
+			stroke := interception.MouseStroke{
+				Flags: interception.MouseFlagMoveRelative,
+				X:     dx,
+				Y:     dy,
+			}
+			if err := interception.SendMouse(lCtx, lDev, &stroke); err != nil {
+				return err
+			}
+			sent = true
 		}
 
 		// Adaptive sleep
@@ -255,23 +413,48 @@ func Move(targetX, targetY int32) error {
 			sleepTime = 3 // Faster for long distances
 		}
 		time.Sleep(time.Duration(sleepTime) * time.Millisecond)
+
+		if (i+1)%checkpointEvery == 0 && i < len(path)-1 {
+			curX, curY, err := window.GetCursorPos()
+			if err != nil {
+				return err
+			}
+			cur := point{curX, curY}
+			if sent && cur == lastCheck {
+				// Stalled against an edge: the planned positions no longer describe the
+				// cursor. Re-plan the remaining steps from where it actually is.
+				edge = true
+				rest := planPath(curX, curY, target.x, target.y, len(path)-i-1, jitterFunc(len(path)-i-1))
+				d.clampPath(rest)
+				path = append(path[:i+1], rest...)
+				prev = cur
+			} else if ox, oy := p.x-curX, p.y-curY; abs(ox) > 2 || abs(oy) > 2 {
+				// Small offsets are likely in-flight lag rather than drift; leave them to the final phase.
+				carryX, carryY = ox, oy
+			}
+			lastCheck, sent = cur, false
+		}
 	}
 
 	// 2. Final Convergence (Critical for Click accuracy)
-	// Even after the loop, we might be off by a few pixels due to jitter or async lag.
-	// Force convergence.
+	// Even after the loop, we might be off by a few pixels due to acceleration or async lag.
+	// Force exact convergence.
+	var curX, curY int32
 	for retry := 0; retry < 5; retry++ {
 		time.Sleep(20 * time.Millisecond) // Wait for OS to settle
 
-		curX, curY, err := window.GetCursorPos()
+		curX, curY, err = window.GetCursorPos()
 		if err != nil {
 			return err
 		}
 
-		dx := targetX - curX
-		dy := targetY - curY
+		dx := target.x - curX
+		dy := target.y - curY
 
-		if abs(dx) <= 1 && abs(dy) <= 1 {
+		if dx == 0 && dy == 0 {
+			if edge && (target.x != targetX || target.y != targetY) {
+				break // Reached the clamped target, not the requested one
+			}
 			return nil // Reached target
 		}
 
@@ -286,9 +469,16 @@ func Move(targetX, targetY int32) error {
 		}
 	}
 
-	return nil
+	if !edge && abs(targetX-curX) <= moveTolerance && abs(targetY-curY) <= moveTolerance {
+		return nil
+	}
+	return &MoveInaccurateError{TargetX: targetX, TargetY: targetY, X: curX, Y: curY, EdgeConstrained: edge}
 }
 
+// moveTolerance is how far (px, per axis) Move may leave the cursor from an unconstrained
+// target without reporting an error.
+const moveTolerance = 1
+
 // clickRaw performs a left click at current position without movement logic.
 // Caller must hold the lock/context.
 // minHold/maxHold define the duration (ms) the button remains pressed.
@@ -338,6 +528,16 @@ func Click(x, y int32) error {
 
 // ClickRight simulates a right mouse button click at the current cursor position.
 func ClickRight(x, y int32) error {
+	return ClickButton(x, y, ButtonRight)
+}
+
+// ClickMiddle simulates a middle mouse button click at the current cursor position.
+func ClickMiddle(x, y int32) error {
+	return ClickButton(x, y, ButtonMiddle)
+}
+
+// ClickButton moves to (x, y) and presses and releases button b there.
+func ClickButton(x, y int32, b Button) error {
 	if err := Move(x, y); err != nil {
 		return err
 	}
@@ -350,22 +550,36 @@ func ClickRight(x, y int32) error {
 
 	time.Sleep(50 * time.Millisecond)
 
-	down := interception.MouseStroke{State: interception.MouseStateRightDown}
+	downState, upState := b.states()
+	down := interception.MouseStroke{State: downState}
 	if err := interception.SendMouse(lCtx, lDev, &down); err != nil {
 		return err
 	}
 
 	humanSleep(60)
 
-	up := interception.MouseStroke{State: interception.MouseStateRightUp}
-	if err := interception.SendMouse(lCtx, lDev, &up); err != nil {
-		return err
-	}
-	return nil
+	up := interception.MouseStroke{State: upState}
+	return interception.SendMouse(lCtx, lDev, &up)
 }
 
-// ClickMiddle simulates a middle mouse button click at the current cursor position.
-func ClickMiddle(x, y int32) error {
+// DoubleClick simulates a left mouse button double-click at the specified screen coordinates
+// with the default DoubleClickTiming.
+func DoubleClick(x, y int32) error {
+	return DoubleClickWithTiming(x, y, DoubleClickTiming{})
+}
+
+// DoubleClickWithTiming moves to the target once, then sends down/up/down/up with the given
+// timing, clamped so that the second press lands within the system double-click time
+// (GetDoubleClickTime). Before each press the cursor is checked against the double-click
+// rectangle (SM_CXDOUBLECLK x SM_CYDOUBLECLK) around the target and put back with
+// SetCursorPos if it drifted out, since the system would otherwise see two single clicks.
+func DoubleClickWithTiming(x, y int32, t DoubleClickTiming) error {
+	return MultiClickWithTiming(x, y, ButtonLeft, 2, t)
+}
+
+// MultiClickWithTiming is DoubleClickWithTiming for button b and count clicks, e.g. 3 for
+// a triple click; each press follows the previous one with the same timing.
+func MultiClickWithTiming(x, y int32, b Button, count int, t DoubleClickTiming) error {
 	if err := Move(x, y); err != nil {
 		return err
 	}
@@ -376,151 +590,168 @@ func ClickMiddle(x, y int32) error {
 	}
 	defer unlock()
 
-	time.Sleep(50 * time.Millisecond)
+	dct, _, _ := window.ProcGetDoubleClickTime.Call()
+	hold, gap := t.resolve(time.Duration(dct) * time.Millisecond)
+	cx, _, _ := window.ProcGetSystemMetrics.Call(smCXDoubleClk)
+	cy, _, _ := window.ProcGetSystemMetrics.Call(smCYDoubleClk)
+	slopX, slopY := max(int32(cx)/2, 1), max(int32(cy)/2, 1)
 
-	down := interception.MouseStroke{State: interception.MouseStateMiddleDown}
-	if err := interception.SendMouse(lCtx, lDev, &down); err != nil {
+	// Relative strokes are applied asynchronously; pin the cursor before the first press.
+	if err := window.SetCursorPos(x, y); err != nil {
 		return err
 	}
+	time.Sleep(12 * time.Millisecond)
 
-	humanSleep(60)
-
-	up := interception.MouseStroke{State: interception.MouseStateMiddleUp}
-	if err := interception.SendMouse(lCtx, lDev, &up); err != nil {
-		return err
+	downState, upState := b.states()
+	down := interception.MouseStroke{State: downState}
+	up := interception.MouseStroke{State: upState}
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			time.Sleep(gap)
+			if curX, curY, err := window.GetCursorPos(); err == nil && (abs(curX-x) >= slopX || abs(curY-y) >= slopY) {
+				if err := window.SetCursorPos(x, y); err != nil {
+					return err
+				}
+			}
+		}
+		if err := interception.SendMouse(lCtx, lDev, &down); err != nil {
+			return err
+		}
+		time.Sleep(hold)
+		if err := interception.SendMouse(lCtx, lDev, &up); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-// DoubleClick simulates a left mouse button double-click at the current cursor position.
-// It moves ONCE, then clicks twice rapidly and deterministically.
-func DoubleClick(x, y int32) error {
-	// 1. Move 到目标（保留你的 Move 保证轨迹与视觉一致）
-	if err := Move(x, y); err != nil {
-		return err
+// Button identifies a mouse button for Down, Up and Drag.
+type Button int
+
+const (
+	ButtonLeft Button = iota
+	ButtonRight
+	ButtonMiddle
+	ButtonX1
+	ButtonX2
+)
+
+// states returns the button's down and up stroke states.
+func (b Button) states() (down, up uint16) {
+	switch b {
+	case ButtonRight:
+		return interception.MouseStateRightDown, interception.MouseStateRightUp
+	case ButtonMiddle:
+		return interception.MouseStateMiddleDown, interception.MouseStateMiddleUp
+	case ButtonX1:
+		return interception.MouseStateButton4Down, interception.MouseStateButton4Up
+	case ButtonX2:
+		return interception.MouseStateButton5Down, interception.MouseStateButton5Up
+	default:
+		return interception.MouseStateLeftDown, interception.MouseStateLeftUp
 	}
+}
 
-	// 2. Acquire device
+// Drag moves to (fromX, fromY), presses button b, follows the human-like Move trajectory to
+// (toX, toY) and releases it there. The driver stays locked for the whole gesture, so Close
+// cannot tear the context down and leave the button held. If the move fails the button is
+// still released, wherever the cursor is.
+func Drag(fromX, fromY, toX, toY int32, b Button) error {
 	lCtx, lDev, unlock, err := acquireMouse()
 	if err != nil {
 		return err
 	}
 	defer unlock()
 
-	// 3. 强制设系统光标到精确目标（消除相对移动异步）
-	// window 包中假定存在 ProcSetCursorPos (见你其它处用法)
-	r, _, _ := window.ProcSetCursorPos.Call(uintptr(x), uintptr(y))
-	if r == 0 {
-		// 若 SetCursorPos 失败，仍可继续，但记录/返回错误更安全
-		// 这里选择返回错误，让调用者显式处理
-		return fmt.Errorf("SetCursorPos failed")
-	}
-	// 睡短候，以便系统合成与驱动稳定（一般 8~20ms 足够）
-	time.Sleep(12 * time.Millisecond)
-
-	// 4. 读取系统双击时间并选取稳健间隔（取三分之一，且不小于 30ms）
-	r2, _, _ := window.ProcGetDoubleClickTime.Call()
-	sysDc := time.Duration(r2) * time.Millisecond
-	if sysDc == 0 {
-		sysDc = 500 * time.Millisecond
-	}
-	interval := sysDc / 3
-	if interval < 30*time.Millisecond {
-		interval = 30 * time.Millisecond
+	if err := moveRaw(lCtx, lDev, fromX, fromY); err != nil {
+		return err
 	}
-	hold := 25 * time.Millisecond // Down 保持时间
-
-	down := interception.MouseStroke{State: interception.MouseStateLeftDown}
-	up := interception.MouseStroke{State: interception.MouseStateLeftUp}
+	time.Sleep(50 * time.Millisecond)
 
-	// helper：发送并短重试一次
-	sendOnce := func(st *interception.MouseStroke) error {
-		if err := interception.SendMouse(lCtx, lDev, st); err != nil {
-			// 短重试一次
-			time.Sleep(6 * time.Millisecond)
-			if err2 := interception.SendMouse(lCtx, lDev, st); err2 != nil {
-				return err2
-			}
-		}
-		return nil
+	downState, upState := b.states()
+	down := interception.MouseStroke{State: downState}
+	if err := interception.SendMouse(lCtx, lDev, &down); err != nil {
+		return err
 	}
+	humanSleep(60)
 
-	// 5. 原子双击序列（无随机、无 Move）
-	// First Down/Up
-	if err := sendOnce(&down); err != nil {
+	moveErr := moveRaw(lCtx, lDev, toX, toY)
+	humanSleep(60)
+	up := interception.MouseStroke{State: upState}
+	if err := interception.SendMouse(lCtx, lDev, &up); err != nil {
 		return err
 	}
-	time.Sleep(hold)
-	if err := sendOnce(&up); err != nil {
+	return moveErr
+}
+
+// Down moves to (x, y) and presses button b there, leaving it held until Up.
+func Down(x, y int32, b Button) error {
+	if err := Move(x, y); err != nil {
 		return err
 	}
+	time.Sleep(50 * time.Millisecond)
+	state, _ := b.states()
+	return sendMouseState(state)
+}
 
-	// Interval（严格且确定）
-	time.Sleep(interval)
-
-	// Second Down/Up
-	if err := sendOnce(&down); err != nil {
+// Up moves to (x, y) and releases button b there.
+func Up(x, y int32, b Button) error {
+	if err := Move(x, y); err != nil {
 		return err
 	}
-	time.Sleep(hold)
-	if err := sendOnce(&up); err != nil {
+	humanSleep(60)
+	_, state := b.states()
+	return sendMouseState(state)
+}
+
+// sendMouseState sends a single button stroke at the current cursor position.
+func sendMouseState(state uint16) error {
+	lCtx, lDev, unlock, err := acquireMouse()
+	if err != nil {
 		return err
 	}
+	defer unlock()
 
-	// 6. 验证光标位置（可选——用于诊断与重试）
-	curX, curY, err := window.GetCursorPos()
-	if err == nil {
-		if abs(curX-x) > 1 || abs(curY-y) > 1 {
-			// 若位置偏移过大，可做一次重试（仅一次）
-			// 记录日志以便后续分析（此处用 fmt.Println 作示例）
-			fmt.Printf("DoubleClick: pos drift detected cur=(%d,%d) want=(%d,%d); retrying once\n", curX, curY, x, y)
-
-			// 再做一次严格双击（不再递归）
-			// 先强制SetCursorPos
-			r, _, _ := window.ProcSetCursorPos.Call(uintptr(x), uintptr(y))
-			if r == 0 {
-				return fmt.Errorf("SetCursorPos failed on retry")
-			}
-			time.Sleep(12 * time.Millisecond)
+	stroke := interception.MouseStroke{State: state}
+	return interception.SendMouse(lCtx, lDev, &stroke)
+}
 
-			// 重试序列
-			if err := sendOnce(&down); err != nil {
-				return err
-			}
-			time.Sleep(hold)
-			if err := sendOnce(&up); err != nil {
-				return err
-			}
-			time.Sleep(interval)
-			if err := sendOnce(&down); err != nil {
-				return err
-			}
-			time.Sleep(hold)
-			if err := sendOnce(&up); err != nil {
-				return err
-			}
-		}
-	}
+// LeftDown presses the left mouse button at the current cursor position.
+func LeftDown() error {
+	return sendMouseState(interception.MouseStateLeftDown)
+}
 
-	return nil
+// LeftUp releases the left mouse button at the current cursor position.
+func LeftUp() error {
+	return sendMouseState(interception.MouseStateLeftUp)
 }
 
 // Scroll simulates a vertical mouse wheel scroll.
 func Scroll(delta int32) error {
+	return sendWheel(wheelStroke(interception.MouseStateWheel, delta))
+}
+
+// ScrollH simulates a horizontal mouse wheel scroll. Positive delta scrolls right.
+func ScrollH(delta int32) error {
+	return sendWheel(wheelStroke(interception.MouseStateHWheel, delta))
+}
+
+// wheelStroke builds a wheel stroke; state selects the vertical or horizontal wheel.
+func wheelStroke(state uint16, delta int32) interception.MouseStroke {
+	return interception.MouseStroke{
+		State:   state,
+		Rolling: int16(delta),
+	}
+}
+
+func sendWheel(stroke interception.MouseStroke) error {
 	lCtx, lDev, unlock, err := acquireMouse()
 	if err != nil {
 		return err
 	}
 	defer unlock()
 
-	stroke := interception.MouseStroke{
-		State:   interception.MouseStateWheel,
-		Rolling: int16(delta),
-	}
-	if err := interception.SendMouse(lCtx, lDev, &stroke); err != nil {
-		return err
-	}
-	return nil
+	return interception.SendMouse(lCtx, lDev, &stroke)
 }
 
 // -----------------------------------------------------------------------------
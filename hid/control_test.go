@@ -0,0 +1,73 @@
+//go:build windows
+
+package hid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rpdg/winput/hid/interception"
+)
+
+func TestReload(t *testing.T) {
+	if err := SetLibraryPath("interception.dll"); err != nil && !errors.Is(err, interception.ErrAlreadyLoaded) {
+		t.Fatalf("SetLibraryPath failed: %v", err)
+	}
+	before, wasLoaded := LoadedPath(), LoadedPath() != ""
+
+	t.Run("InUse", func(t *testing.T) {
+		rlockInit() // as an input operation would
+		inFlight.Add(1)
+		err := Reload(`C:\nonexistent\interception.dll`)
+		runlockInit()
+		if !errors.Is(err, ErrLibraryInUse) || !strings.Contains(err.Error(), "1 input operations") {
+			t.Errorf("Reload with input in flight = %v, want ErrLibraryInUse naming 1 operation", err)
+		}
+	})
+
+	t.Run("MissingLibrary", func(t *testing.T) {
+		err := Reload(`C:\nonexistent\interception.dll`)
+		if !errors.Is(err, interception.ErrLibraryNotFound) {
+			t.Fatalf("Reload(missing) = %v, want ErrLibraryNotFound", err)
+		}
+		// The previous library is restored, or nothing is loaded if there was none.
+		if got := LoadedPath(); wasLoaded && got != before || !wasLoaded && got != "" {
+			t.Errorf("LoadedPath after failed Reload = %q, want %q", got, before)
+		}
+		if got := interception.LibraryPath(); got == `C:\nonexistent\interception.dll` {
+			t.Errorf("failed Reload left the library path at %q", got)
+		}
+	})
+
+	if !wasLoaded {
+		return
+	}
+	t.Run("SetLibraryPathWhileLoaded", func(t *testing.T) {
+		if err := SetLibraryPath(`C:\other\interception.dll`); !errors.Is(err, interception.ErrAlreadyLoaded) {
+			t.Errorf("SetLibraryPath while loaded = %v, want ErrAlreadyLoaded", err)
+		}
+		if err := SetLibraryPath(before); err != nil {
+			t.Errorf("SetLibraryPath(loaded path) = %v, want nil", err)
+		}
+	})
+}
+
+func TestWheelStroke(t *testing.T) {
+	for _, c := range []struct {
+		state uint16
+		delta int32
+	}{
+		{interception.MouseStateWheel, 120},
+		{interception.MouseStateHWheel, 240},
+		{interception.MouseStateHWheel, -120},
+	} {
+		s := wheelStroke(c.state, c.delta)
+		if s.State != c.state || int32(s.Rolling) != c.delta {
+			t.Errorf("wheelStroke(0x%X, %d) = State 0x%X Rolling %d", c.state, c.delta, s.State, s.Rolling)
+		}
+	}
+	if s := wheelStroke(interception.MouseStateHWheel, 120); s.State != 0x800 {
+		t.Errorf("horizontal wheel State = 0x%X, want 0x800", s.State)
+	}
+}
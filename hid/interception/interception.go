@@ -1,9 +1,14 @@
+//go:build windows
+
 package interception
 
 import (
+	"debug/pe"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"runtime"
+	"strings"
 	"syscall"
 	"unsafe"
 )
@@ -21,17 +26,77 @@ var (
 var (
 	ErrLibraryNotFound = fmt.Errorf("interception library not found")
 	ErrSendFailed      = fmt.Errorf("interception_send failed")
+
+	// ErrWrongArchitecture is returned when the DLL was built for a different architecture
+	// than the process (e.g. a 32-bit interception.dll in a 64-bit program).
+	ErrWrongArchitecture = errors.New("interception library has the wrong architecture")
+	// ErrMissingExports is returned when the DLL loads but does not export the full
+	// interception API, i.e. it is not (a complete build of) interception.dll.
+	ErrMissingExports = errors.New("interception library is missing exports")
+	// ErrNotLoaded is returned by LibraryInfo before Load succeeds.
+	ErrNotLoaded = errors.New("interception library not loaded")
+	// ErrAlreadyLoaded is returned by SetLibraryPath when a library from another path is
+	// already loaded; the new path would otherwise only take effect after Unload.
+	ErrAlreadyLoaded = errors.New("interception library already loaded")
+)
+
+// requiredExports is the interception.h API. All of it is checked, not just the functions
+// used here, so that a stub or unrelated DLL is rejected at load time.
+var requiredExports = []string{
+	"interception_create_context",
+	"interception_destroy_context",
+	"interception_get_precedence",
+	"interception_set_precedence",
+	"interception_get_filter",
+	"interception_set_filter",
+	"interception_wait",
+	"interception_wait_with_timeout",
+	"interception_send",
+	"interception_receive",
+	"interception_get_hardware_id",
+	"interception_is_invalid",
+	"interception_is_keyboard",
+	"interception_is_mouse",
+}
+
+// ERROR_BAD_EXE_FORMAT: "%1 is not a valid Win32 application."
+const errorBadExeFormat syscall.Errno = 193
+
+var (
+	modkernel32            = syscall.NewLazyDLL("kernel32.dll")
+	procGetModuleFileNameW = modkernel32.NewProc("GetModuleFileNameW")
 )
 
 // Default library name
 var libraryPath = "interception.dll"
 
-// SetLibraryPath sets the path for LoadLibrary.
-func SetLibraryPath(path string) {
+// loadedPath is the libraryPath the loaded DLL came from.
+var loadedPath string
+
+// SetLibraryPath sets the path for LoadLibrary. It fails with ErrAlreadyLoaded if a library
+// from a different path is loaded; Unload it first.
+func SetLibraryPath(path string) error {
+	if dllHandle != 0 && path != loadedPath {
+		return fmt.Errorf("%w from %s", ErrAlreadyLoaded, loadedPath)
+	}
 	libraryPath = path
+	return nil
+}
+
+// LibraryPath returns the path the next Load uses.
+func LibraryPath() string {
+	return libraryPath
+}
+
+// LoadedPath returns the path the loaded library was loaded from, as given to
+// SetLibraryPath, or "" if none is loaded. LibraryInfo resolves it to a full path.
+func LoadedPath() string {
+	return loadedPath
 }
 
 // Load loads the interception.dll and resolves function addresses.
+// A DLL built for another architecture yields ErrWrongArchitecture, and one that lacks
+// part of the interception API yields ErrMissingExports naming the missing functions.
 func Load() error {
 	if dllHandle != 0 {
 		return nil
@@ -39,24 +104,95 @@ func Load() error {
 
 	h, err := syscall.LoadLibrary(libraryPath)
 	if err != nil {
+		if errors.Is(err, errorBadExeFormat) {
+			arch := "unknown"
+			if a, archErr := fileArch(libraryPath); archErr == nil {
+				arch = a
+			}
+			return fmt.Errorf("%w: %s is %s but this process is %s; use the %s build of interception.dll",
+				ErrWrongArchitecture, libraryPath, arch, runtime.GOARCH, archBuild(runtime.GOARCH))
+		}
 		return fmt.Errorf("%w: %v", ErrLibraryNotFound, err)
 	}
-	dllHandle = h
 
+	var missing []string
+	for _, name := range requiredExports {
+		if getProc(h, name) == 0 {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		syscall.FreeLibrary(h)
+		return fmt.Errorf("%w: %s does not export %s", ErrMissingExports, libraryPath, strings.Join(missing, ", "))
+	}
+
+	dllHandle = h
+	loadedPath = libraryPath
 	procCreateContext = getProc(h, "interception_create_context")
 	procDestroyContext = getProc(h, "interception_destroy_context")
 	procIsMouse = getProc(h, "interception_is_mouse")
 	procIsKeyboard = getProc(h, "interception_is_keyboard")
 	procSend = getProc(h, "interception_send")
 
-	// Check essential functions
-	if procCreateContext == 0 || procSend == 0 {
-		syscall.FreeLibrary(h)
-		dllHandle = 0
-		return fmt.Errorf("library loaded but symbols missing")
+	return nil
+}
+
+// Library describes the loaded interception DLL.
+type Library struct {
+	// Path is the full path Windows resolved the library to.
+	Path string
+	// Arch is the DLL's architecture as a GOARCH name ("386", "amd64", "arm64"),
+	// or the PE machine type in hex if it is none of those.
+	Arch string
+}
+
+// LibraryInfo returns the resolved path and architecture of the loaded DLL, for diagnostics.
+// It returns ErrNotLoaded before Load succeeds.
+func LibraryInfo() (Library, error) {
+	if dllHandle == 0 {
+		return Library{}, ErrNotLoaded
+	}
+	buf := make([]uint16, syscall.MAX_LONG_PATH)
+	n, _, e := procGetModuleFileNameW.Call(uintptr(dllHandle), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if n == 0 {
+		return Library{}, fmt.Errorf("GetModuleFileNameW failed: %v", e)
 	}
+	lib := Library{Path: syscall.UTF16ToString(buf[:n])}
+	arch, err := fileArch(lib.Path)
+	if err != nil {
+		return lib, err
+	}
+	lib.Arch = arch
+	return lib, nil
+}
 
-	return nil
+// fileArch reads the machine type from the PE header of the DLL at path.
+func fileArch(path string) (string, error) {
+	f, err := pe.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	switch f.Machine {
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "386", nil
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "amd64", nil
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64", nil
+	}
+	return fmt.Sprintf("%#x", f.Machine), nil
+}
+
+// archBuild names the interception.dll build folder for a GOARCH.
+func archBuild(goarch string) string {
+	switch goarch {
+	case "386":
+		return "x86"
+	case "amd64":
+		return "x64"
+	}
+	return goarch
 }
 
 // Unload frees the loaded DLL.
@@ -64,6 +200,7 @@ func Unload() {
 	if dllHandle != 0 {
 		syscall.FreeLibrary(dllHandle)
 		dllHandle = 0
+		loadedPath = ""
 		procCreateContext = 0
 		procDestroyContext = 0
 		procIsMouse = 0
@@ -108,13 +245,18 @@ var strokeSize = 24
 
 // Constants for Mouse
 const (
-	MouseStateLeftDown   = 0x001
-	MouseStateLeftUp     = 0x002
-	MouseStateRightDown  = 0x004
-	MouseStateRightUp    = 0x008
-	MouseStateMiddleDown = 0x010
-	MouseStateMiddleUp   = 0x020
-	MouseStateWheel      = 0x400
+	MouseStateLeftDown    = 0x001
+	MouseStateLeftUp      = 0x002
+	MouseStateRightDown   = 0x004
+	MouseStateRightUp     = 0x008
+	MouseStateMiddleDown  = 0x010
+	MouseStateMiddleUp    = 0x020
+	MouseStateButton4Down = 0x040 // X1 (back)
+	MouseStateButton4Up   = 0x080
+	MouseStateButton5Down = 0x100 // X2 (forward)
+	MouseStateButton5Up   = 0x200
+	MouseStateWheel       = 0x400
+	MouseStateHWheel      = 0x800
 
 	MouseFlagMoveRelative = 0x000
 	MouseFlagMoveAbsolute = 0x001
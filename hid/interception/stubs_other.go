@@ -0,0 +1,139 @@
+// Code generated by internal/stubgen; DO NOT EDIT.
+
+//go:build !windows
+
+package interception
+
+import (
+	"errors"
+	"fmt"
+	"github.com/rpdg/winput/window"
+)
+
+var ErrLibraryNotFound = fmt.Errorf("interception library not found")
+
+var ErrSendFailed = fmt.Errorf("interception_send failed")
+
+// ErrWrongArchitecture is returned when the DLL was built for a different architecture
+// than the process (e.g. a 32-bit interception.dll in a 64-bit program).
+var ErrWrongArchitecture = errors.New("interception library has the wrong architecture")
+
+// ErrMissingExports is returned when the DLL loads but does not export the full
+// interception API, i.e. it is not (a complete build of) interception.dll.
+var ErrMissingExports = errors.New("interception library is missing exports")
+
+// ErrNotLoaded is returned by LibraryInfo before Load succeeds.
+var ErrNotLoaded = errors.New("interception library not loaded")
+
+// ErrAlreadyLoaded is returned by SetLibraryPath when a library from another path is
+// already loaded; the new path would otherwise only take effect after Unload.
+var ErrAlreadyLoaded = errors.New("interception library already loaded")
+
+// Library describes the loaded interception DLL.
+type Library struct {
+	Path string
+
+	Arch string
+}
+
+type Context uintptr
+
+type Device int
+
+// Go-friendly structs
+type MouseStroke struct {
+	State       uint16
+	Flags       uint16
+	Rolling     int16
+	X           int32
+	Y           int32
+	Information uint32
+}
+
+type KeyStroke struct {
+	Code        uint16
+	State       uint16
+	Information uint32
+}
+
+// Constants for Mouse
+const (
+	MouseStateLeftDown    = 0x001
+	MouseStateLeftUp      = 0x002
+	MouseStateRightDown   = 0x004
+	MouseStateRightUp     = 0x008
+	MouseStateMiddleDown  = 0x010
+	MouseStateMiddleUp    = 0x020
+	MouseStateButton4Down = 0x040 // X1 (back)
+	MouseStateButton4Up   = 0x080
+	MouseStateButton5Down = 0x100 // X2 (forward)
+	MouseStateButton5Up   = 0x200
+	MouseStateWheel       = 0x400
+	MouseStateHWheel      = 0x800
+
+	MouseFlagMoveRelative = 0x000
+	MouseFlagMoveAbsolute = 0x001
+)
+
+// Constants for Keyboard
+const (
+	KeyStateDown = 0x00
+	KeyStateUp   = 0x01
+	KeyStateE0   = 0x02
+	KeyStateE1   = 0x04
+)
+
+// SetLibraryPath sets the path for LoadLibrary. It fails with ErrAlreadyLoaded if a library
+// from a different path is loaded; Unload it first.
+func SetLibraryPath(path string) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// LibraryPath returns the path the next Load uses.
+func LibraryPath() string {
+	return ""
+}
+
+// LoadedPath returns the path the loaded library was loaded from, as given to
+// SetLibraryPath, or "" if none is loaded. LibraryInfo resolves it to a full path.
+func LoadedPath() string {
+	return ""
+}
+
+// Load loads the interception.dll and resolves function addresses.
+// A DLL built for another architecture yields ErrWrongArchitecture, and one that lacks
+// part of the interception API yields ErrMissingExports naming the missing functions.
+func Load() error {
+	return window.ErrUnsupportedPlatform
+}
+
+// LibraryInfo returns the resolved path and architecture of the loaded DLL, for diagnostics.
+// It returns ErrNotLoaded before Load succeeds.
+func LibraryInfo() (Library, error) {
+	return *new(Library), window.ErrUnsupportedPlatform
+}
+
+// Unload frees the loaded DLL.
+func Unload() {}
+
+func CreateContext() Context {
+	return *new(Context)
+}
+
+func DestroyContext(ctx Context) {}
+
+func IsMouse(dev Device) bool {
+	return false
+}
+
+func IsKeyboard(dev Device) bool {
+	return false
+}
+
+func SendMouse(ctx Context, dev Device, s *MouseStroke) error {
+	return window.ErrUnsupportedPlatform
+}
+
+func SendKey(ctx Context, dev Device, s *KeyStroke) error {
+	return window.ErrUnsupportedPlatform
+}
@@ -0,0 +1,118 @@
+package hid
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/rpdg/winput/screen"
+)
+
+// Use a local random source instead of global rand
+var rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+
+// checkpointEvery is how many trajectory steps pass between reads of the real cursor position.
+const checkpointEvery = 10
+
+type point struct {
+	x, y int32
+}
+
+// planPath returns the absolute waypoints from (sx, sy) to (tx, ty) in the given number of steps.
+// jitter(i) offsets waypoint i; the final waypoint is always exactly the target.
+func planPath(sx, sy, tx, ty int32, steps int, jitter func(i int) (int32, int32)) []point {
+	if steps < 1 {
+		steps = 1
+	}
+	path := make([]point, steps)
+	for i := 1; i <= steps; i++ {
+		p := point{
+			x: sx + (tx-sx)*int32(i)/int32(steps),
+			y: sy + (ty-sy)*int32(i)/int32(steps),
+		}
+		if i < steps && jitter != nil {
+			jx, jy := jitter(i)
+			p.x += jx
+			p.y += jy
+		}
+		path[i-1] = p
+	}
+	return path
+}
+
+// jitterFunc returns a ±1px jitter for all but the last few steps, so the approach is clean.
+func jitterFunc(steps int) func(i int) (int32, int32) {
+	return func(i int) (int32, int32) {
+		if i >= steps-2 {
+			return 0, 0
+		}
+		return int32(rng.Intn(3) - 1), int32(rng.Intn(3) - 1)
+	}
+}
+
+// edgeMargin keeps intermediate waypoints this many pixels inside the desktop, so that
+// relative strokes never pile up against an edge and desynchronise dead reckoning.
+const edgeMargin = 1
+
+// desktop is the monitor layout waypoints are clamped to. Rect Right/Bottom are exclusive.
+type desktop struct {
+	virtual  screen.Rect
+	monitors []screen.Rect
+}
+
+// clamp returns p moved inside the desktop, at least margin pixels from the edges of the
+// monitor that contains it, and whether p had to be moved. A point in a gap between
+// monitors (e.g. the empty corner of an L-shaped layout) moves to the nearest monitor.
+func (d desktop) clamp(p point, margin int32) (point, bool) {
+	rects := d.monitors
+	if len(rects) == 0 {
+		rects = []screen.Rect{d.virtual}
+	}
+	best, bestDist := p, int64(-1)
+	for _, r := range rects {
+		q := point{
+			x: clampInt(p.x, r.Left+margin, r.Right-1-margin),
+			y: clampInt(p.y, r.Top+margin, r.Bottom-1-margin),
+		}
+		if q == p {
+			return p, false
+		}
+		dx, dy := int64(q.x-p.x), int64(q.y-p.y)
+		if dist := dx*dx + dy*dy; bestDist < 0 || dist < bestDist {
+			best, bestDist = q, dist
+		}
+	}
+	return best, true
+}
+
+// clampPath clamps every waypoint but the last with edgeMargin; the last is the target,
+// which the caller has already clamped to the reachable desktop. It reports whether any
+// waypoint moved.
+func (d desktop) clampPath(path []point) bool {
+	moved := false
+	for i := 0; i < len(path)-1; i++ {
+		var m bool
+		path[i], m = d.clamp(path[i], edgeMargin)
+		moved = moved || m
+	}
+	return moved
+}
+
+func clampInt(v, lo, hi int32) int32 {
+	if hi < lo {
+		return (lo + hi) / 2
+	}
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
+
+func abs(n int32) int32 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
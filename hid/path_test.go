@@ -0,0 +1,122 @@
+package hid
+
+import (
+	"testing"
+
+	"github.com/rpdg/winput/screen"
+)
+
+func TestPlanPath(t *testing.T) {
+	cases := []struct {
+		sx, sy, tx, ty int32
+		steps          int
+	}{
+		{0, 0, 500, 500, 20},
+		{100, 200, 90, 210, 5},
+		{-1920, 50, 1919, -30, 40},
+		{10, 10, 10, 10, 5},
+	}
+	for _, c := range cases {
+		path := planPath(c.sx, c.sy, c.tx, c.ty, c.steps, jitterFunc(c.steps))
+		if len(path) != c.steps {
+			t.Fatalf("planPath(%v) returned %d points, want %d", c, len(path), c.steps)
+		}
+		if last := path[len(path)-1]; last.x != c.tx || last.y != c.ty {
+			t.Errorf("planPath(%v) ends at (%d,%d), want (%d,%d)", c, last.x, last.y, c.tx, c.ty)
+		}
+		// Jitter must stay within 1px of the straight line.
+		for i, p := range path {
+			lx := c.sx + (c.tx-c.sx)*int32(i+1)/int32(c.steps)
+			ly := c.sy + (c.ty-c.sy)*int32(i+1)/int32(c.steps)
+			if abs(p.x-lx) > 1 || abs(p.y-ly) > 1 {
+				t.Errorf("planPath(%v)[%d] = (%d,%d), too far from (%d,%d)", c, i, p.x, p.y, lx, ly)
+			}
+		}
+	}
+}
+
+func BenchmarkPlanPath(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		planPath(0, 0, 1500, 800, 40, jitterFunc(40))
+	}
+}
+
+// twoMonitors is an L-shaped layout: B sits to the right of A and is raised by 600px,
+// leaving a dead corner below B.
+var twoMonitors = desktop{
+	virtual: screen.Rect{Left: 0, Top: -600, Right: 3000, Bottom: 1080},
+	monitors: []screen.Rect{
+		{Left: 0, Top: 0, Right: 1920, Bottom: 1080},
+		{Left: 1920, Top: -600, Right: 3000, Bottom: 600},
+	},
+}
+
+func TestClampNearEdges(t *testing.T) {
+	// Targets within 5px of every edge and corner of both monitors are reachable as-is.
+	for _, m := range twoMonitors.monitors {
+		xs := []int32{m.Left, m.Left + 5, (m.Left + m.Right) / 2, m.Right - 6, m.Right - 1}
+		ys := []int32{m.Top, m.Top + 5, (m.Top + m.Bottom) / 2, m.Bottom - 6, m.Bottom - 1}
+		for _, x := range xs {
+			for _, y := range ys {
+				p := point{x, y}
+				if q, moved := twoMonitors.clamp(p, 0); moved || q != p {
+					t.Errorf("clamp(%v, 0) = %v, %v; want unchanged", p, q, moved)
+				}
+			}
+		}
+	}
+}
+
+func TestClampOutside(t *testing.T) {
+	cases := []struct {
+		p, want point
+	}{
+		{point{-50, 500}, point{0, 500}},       // left of A
+		{point{2500, 900}, point{2500, 599}},   // dead corner below B
+		{point{1950, 1000}, point{1919, 1000}}, // dead corner, nearer A
+		{point{3100, -700}, point{2999, -600}}, // beyond B's top-right corner
+		{point{1000, -10}, point{1000, 0}},     // above A, left of B
+		{point{5000, 5000}, point{2999, 599}},  // far outside
+	}
+	for _, c := range cases {
+		got, moved := twoMonitors.clamp(c.p, 0)
+		if !moved || got != c.want {
+			t.Errorf("clamp(%v, 0) = %v, %v; want %v, true", c.p, got, moved, c.want)
+		}
+	}
+}
+
+func TestClampPath(t *testing.T) {
+	inside := func(p point) bool {
+		for _, m := range twoMonitors.monitors {
+			if p.x >= m.Left+edgeMargin && p.x < m.Right-edgeMargin &&
+				p.y >= m.Top+edgeMargin && p.y < m.Bottom-edgeMargin {
+				return true
+			}
+		}
+		return false
+	}
+	cases := []struct {
+		sx, sy, tx, ty int32
+		clamped        bool
+	}{
+		{100, 100, 1800, 900, false},
+		{100, 1079, 1919, 1079, true},  // along A's bottom edge
+		{1800, 1000, 2999, -600, true}, // into B's top-right corner, across the dead corner
+		{2500, 500, 1000, 1079, true},  // from B to A's bottom edge
+	}
+	for _, c := range cases {
+		path := planPath(c.sx, c.sy, c.tx, c.ty, 40, jitterFunc(40))
+		if got := twoMonitors.clampPath(path); got != c.clamped {
+			t.Errorf("clampPath(%v) = %v, want %v", c, got, c.clamped)
+		}
+		for i, p := range path[:len(path)-1] {
+			if !inside(p) {
+				t.Errorf("clampPath(%v)[%d] = %v, outside the margin", c, i, p)
+			}
+		}
+		if last := path[len(path)-1]; last.x != c.tx || last.y != c.ty {
+			t.Errorf("clampPath(%v) moved the target to %v", c, last)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+// Code generated by internal/stubgen; DO NOT EDIT.
+
+//go:build !windows
+
+package hid
+
+import (
+	"errors"
+	"github.com/rpdg/winput/hid/interception"
+	"github.com/rpdg/winput/window"
+	"time"
+)
+
+var ErrDriverNotInstalled = errors.New("interception driver not installed or accessible")
+
+// ErrLibraryLoaded is returned by SetLibraryPath once a library from another path is loaded.
+var ErrLibraryLoaded = interception.ErrAlreadyLoaded
+
+// ErrLibraryInUse is returned by Reload when input operations hold the driver.
+var ErrLibraryInUse = errors.New("interception library in use")
+
+// ErrMoveInaccurate is returned when Move cannot bring the cursor exactly onto the target.
+var ErrMoveInaccurate = errors.New("mouse move did not reach the target")
+
+// MoveInaccurateError reports where the cursor ended up after Move gave up.
+// It matches ErrMoveInaccurate with errors.Is.
+type MoveInaccurateError struct {
+	TargetX, TargetY int32
+	X, Y             int32
+
+	EdgeConstrained bool
+}
+
+const (
+	MaxInterceptionDevices = 20
+)
+
+// Button identifies a mouse button for Down, Up and Drag.
+type Button int
+
+const (
+	ButtonLeft Button = iota
+	ButtonRight
+	ButtonMiddle
+	ButtonX1
+	ButtonX2
+)
+
+func (e *MoveInaccurateError) Error() string {
+	return ""
+}
+
+func (e *MoveInaccurateError) Unwrap() error {
+	return window.ErrUnsupportedPlatform
+}
+
+// SetLibraryPath sets the custom path for the interception.dll library. It fails with
+// ErrLibraryLoaded once a library from another path is loaded; use Reload
+// to switch libraries then.
+func SetLibraryPath(path string) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// LoadedPath returns the path the loaded interception library came from, or "" if the
+// backend has not loaded one.
+func LoadedPath() string {
+	return ""
+}
+
+// Reload switches the backend to the interception library at path: it closes the
+// context, unloads the current library and initializes from path, all under the driver
+// lock, so no input operation sees a half-switched backend. If path cannot be
+// initialized, the previous library is restored when possible and the error is returned.
+//
+// Reload does not wait for input in progress: it fails with ErrLibraryInUse, reporting
+// the number of operations in flight, if any hold the driver.
+func Reload(path string) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Init initializes the Interception context and finds devices.
+// It loads the DLL, creates a context, and scans for mouse and keyboard devices.
+func Init() error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Close destroys the Interception context and unloads the DLL.
+// It ensures that no further input operations can be performed.
+func Close() error {
+	return window.ErrUnsupportedPlatform
+}
+
+// EnsureInit checks if the HID backend is initialized, and initializes it if not.
+func EnsureInit() error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Probe checks whether the Interception library loads and the driver accepts a context,
+// without leaving the backend initialized. It returns nil if the backend is already initialized.
+func Probe() error {
+	return window.ErrUnsupportedPlatform
+}
+
+// SetLockWaitHook installs fn to be called with how long each input operation waited for
+// the driver lock, which is held exclusively only by Init and Close. wait is 0 when the
+// lock was free. Pass nil to remove it; without a hook the lock is taken directly.
+func SetLockWaitHook(fn func(wait time.Duration)) {}
+
+// Move simulates mouse movement to the target screen coordinates using human-like trajectory.
+//
+// Waypoints are clamped to the desktop (1px inside monitor edges), so relative strokes never
+// pile up against an edge; a target outside every monitor is moved to the nearest reachable
+// point. If the cursor stops moving at a checkpoint although strokes were sent, it has hit
+// an edge, and the rest of the trajectory is re-planned from its actual position.
+// The correction phase aims for the exact target, but a cursor left within 1px of it (e.g.
+// by enhanced pointer precision) is accepted, so clicks and drags still happen there. A
+// larger miss, or a target that could not be reached because of an edge, returns a
+// *MoveInaccurateError.
+func Move(targetX, targetY int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Click simulates a left mouse button click at the current cursor position.
+// It triggers Move first to ensure correct context acquisition.
+func Click(x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ClickRight simulates a right mouse button click at the current cursor position.
+func ClickRight(x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ClickMiddle simulates a middle mouse button click at the current cursor position.
+func ClickMiddle(x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ClickButton moves to (x, y) and presses and releases button b there.
+func ClickButton(x, y int32, b Button) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// DoubleClick simulates a left mouse button double-click at the specified screen coordinates
+// with the default DoubleClickTiming.
+func DoubleClick(x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// DoubleClickWithTiming moves to the target once, then sends down/up/down/up with the given
+// timing, clamped so that the second press lands within the system double-click time
+// (GetDoubleClickTime). Before each press the cursor is checked against the double-click
+// rectangle (SM_CXDOUBLECLK x SM_CYDOUBLECLK) around the target and put back with
+// SetCursorPos if it drifted out, since the system would otherwise see two single clicks.
+func DoubleClickWithTiming(x, y int32, t DoubleClickTiming) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// MultiClickWithTiming is DoubleClickWithTiming for button b and count clicks, e.g. 3 for
+// a triple click; each press follows the previous one with the same timing.
+func MultiClickWithTiming(x, y int32, b Button, count int, t DoubleClickTiming) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Drag moves to (fromX, fromY), presses button b, follows the human-like Move trajectory to
+// (toX, toY) and releases it there. The driver stays locked for the whole gesture, so Close
+// cannot tear the context down and leave the button held. If the move fails the button is
+// still released, wherever the cursor is.
+func Drag(fromX, fromY, toX, toY int32, b Button) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Down moves to (x, y) and presses button b there, leaving it held until Up.
+func Down(x, y int32, b Button) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Up moves to (x, y) and releases button b there.
+func Up(x, y int32, b Button) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// LeftDown presses the left mouse button at the current cursor position.
+func LeftDown() error {
+	return window.ErrUnsupportedPlatform
+}
+
+// LeftUp releases the left mouse button at the current cursor position.
+func LeftUp() error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Scroll simulates a vertical mouse wheel scroll.
+func Scroll(delta int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ScrollH simulates a horizontal mouse wheel scroll. Positive delta scrolls right.
+func ScrollH(delta int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// KeyDown simulates a key down event for the specified scan code.
+func KeyDown(scanCode uint16) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// KeyUp simulates a key up event for the specified scan code.
+func KeyUp(scanCode uint16) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Press simulates a key press (down then up) for the specified scan code.
+func Press(scanCode uint16) error {
+	return window.ErrUnsupportedPlatform
+}
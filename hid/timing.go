@@ -0,0 +1,42 @@
+package hid
+
+import "time"
+
+// DoubleClickTiming tunes DoubleClickWithTiming. Zero fields select the defaults.
+type DoubleClickTiming struct {
+	// Hold is how long each press is held (default 25ms).
+	Hold time.Duration
+	// Gap is the pause between the first release and the second press
+	// (default a third of the system double-click time, at least 30ms).
+	Gap time.Duration
+}
+
+const (
+	defaultDoubleClickHold   = 25 * time.Millisecond
+	minDefaultDoubleClickGap = 30 * time.Millisecond
+	minDoubleClickGap        = 10 * time.Millisecond
+	defaultDoubleClickTime   = 500 * time.Millisecond
+)
+
+// resolve applies the defaults and clamps the timing to what the system accepts as a double
+// click: the second press must follow the first within dct (GetDoubleClickTime), so Hold+Gap
+// is kept within 3/4 of it, shortening Hold to at most half of that budget and then Gap.
+func (t DoubleClickTiming) resolve(dct time.Duration) (hold, gap time.Duration) {
+	if dct <= 0 {
+		dct = defaultDoubleClickTime
+	}
+	hold, gap = t.Hold, t.Gap
+	if hold <= 0 {
+		hold = defaultDoubleClickHold
+	}
+	if gap <= 0 {
+		gap = max(dct/3, minDefaultDoubleClickGap)
+	}
+
+	budget := dct * 3 / 4
+	hold = min(hold, budget/2)
+	if hold+gap > budget {
+		gap = max(budget-hold, minDoubleClickGap)
+	}
+	return hold, gap
+}
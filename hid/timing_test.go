@@ -0,0 +1,37 @@
+package hid
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDoubleClickTimingResolve(t *testing.T) {
+	const ms = time.Millisecond
+	cases := []struct {
+		dct       time.Duration
+		t         DoubleClickTiming
+		hold, gap time.Duration
+	}{
+		{500 * ms, DoubleClickTiming{}, 25 * ms, 500 * ms / 3},
+		{0, DoubleClickTiming{}, 25 * ms, 500 * ms / 3},                                // unknown: assume the 500ms default
+		{200 * ms, DoubleClickTiming{}, 25 * ms, 200 * ms / 3},                         // fastest setting in Control Panel
+		{60 * ms, DoubleClickTiming{}, 45 * ms / 2, 45 * ms / 2},                       // default gap exceeds the budget
+		{500 * ms, DoubleClickTiming{Hold: 40 * ms, Gap: 100 * ms}, 40 * ms, 100 * ms}, // within the budget
+		{200 * ms, DoubleClickTiming{Hold: 40 * ms, Gap: 300 * ms}, 40 * ms, 110 * ms}, // gap clamped
+		{200 * ms, DoubleClickTiming{Hold: 150 * ms}, 75 * ms, 200 * ms / 3},           // hold clamped
+		{200 * ms, DoubleClickTiming{Hold: 150 * ms, Gap: 100 * ms}, 75 * ms, 75 * ms}, // hold clamped, then gap
+	}
+	for _, c := range cases {
+		hold, gap := c.t.resolve(c.dct)
+		if hold != c.hold || gap != c.gap {
+			t.Errorf("%+v.resolve(%v) = %v, %v; want %v, %v", c.t, c.dct, hold, gap, c.hold, c.gap)
+		}
+		dct := c.dct
+		if dct == 0 {
+			dct = defaultDoubleClickTime
+		}
+		if hold+gap >= dct {
+			t.Errorf("%+v.resolve(%v): second press after %v, outside the double-click time", c.t, c.dct, hold+gap)
+		}
+	}
+}
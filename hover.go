@@ -0,0 +1,73 @@
+//go:build windows
+
+package winput
+
+import (
+	"context"
+	"time"
+
+	"github.com/rpdg/winput/hid"
+	"github.com/rpdg/winput/mouse"
+	"github.com/rpdg/winput/window"
+)
+
+// hoverInterval is how often Hover repeats WM_MOUSEMOVE while dwelling.
+const hoverInterval = 100 * time.Millisecond
+
+// Hover moves to the client point (x, y) and keeps the cursor there for d, so hover-activated
+// UI (tooltips, flyout menus) appears. The Message backend re-posts WM_MOUSEMOVE every 100ms
+// because applications start their hover timers from mouse moves; the HID backend moves the
+// physical cursor and waits. Returns ErrWindowGone if the window is destroyed during the dwell.
+func (w *Window) Hover(x, y int32, d time.Duration) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.hover(context.Background(), x, y, d)
+}
+
+func (w *Window) hover(ctx context.Context, x, y int32, d time.Duration) error {
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	w.recordAction("Hover", x, y)
+
+	hidBackend := getBackend() == BackendHID
+	if hidBackend {
+		sx, sy, err := clientToScreen(w.HWND, x, y)
+		if err != nil {
+			return err
+		}
+		if err := hid.Move(sx, sy); err != nil {
+			return err
+		}
+	} else if err := mouse.Move(w.HWND, x, y); err != nil {
+		return err
+	}
+
+	deadline := time.NewTimer(d)
+	defer deadline.Stop()
+	ticker := time.NewTicker(hoverInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-deadline.C:
+			return nil
+		case <-ticker.C:
+		}
+		if !window.IsValid(w.HWND) {
+			return ErrWindowGone
+		}
+		if !hidBackend {
+			if err := mouse.Move(w.HWND, x, y); err != nil {
+				return err
+			}
+		}
+	}
+}
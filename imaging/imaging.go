@@ -0,0 +1,105 @@
+// Package imaging converts winput captures (*image.RGBA, as returned by the screen package)
+// into the layouts expected by common vision libraries (OpenCV/gocv BGR Mats, grayscale
+// OCR input, base64 PNG for remote detectors).
+//
+// The functions are meant for per-frame loops: the *Into variants reuse caller buffers,
+// and each function documents whether it copies pixels.
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+)
+
+// ToBGRBytes returns the pixels of img as tightly packed 3-byte BGR (alpha stripped,
+// row stride normalized to width*3), plus the width and height. It always copies.
+// The layout matches an OpenCV CV_8UC3 Mat.
+func ToBGRBytes(img *image.RGBA) ([]byte, int, int) {
+	return ToBGRBytesInto(nil, img)
+}
+
+// ToBGRBytesInto is ToBGRBytes writing into dst, which is grown only if it is too small.
+// Pass the previous frame's buffer to avoid an allocation per frame.
+func ToBGRBytesInto(dst []byte, img *image.RGBA) ([]byte, int, int) {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	n := w * h * 3
+	if cap(dst) < n {
+		dst = make([]byte, n)
+	}
+	dst = dst[:n]
+
+	for y := 0; y < h; y++ {
+		src := img.Pix[img.PixOffset(b.Min.X, b.Min.Y+y):]
+		row := dst[y*w*3 : (y+1)*w*3]
+		for x, j := 0, 0; x < w*4; x, j = x+4, j+3 {
+			row[j] = src[x+2]
+			row[j+1] = src[x+1]
+			row[j+2] = src[x]
+		}
+	}
+	return dst, w, h
+}
+
+// ToGray converts img to 8-bit luma using the same weights as image/color.GrayModel. It always copies.
+func ToGray(img *image.RGBA) *image.Gray {
+	return ToGrayInto(nil, img)
+}
+
+// ToGrayInto is ToGray writing into dst, which is reused if its pixel buffer is large enough.
+func ToGrayInto(dst *image.Gray, img *image.RGBA) *image.Gray {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	r := image.Rect(0, 0, w, h)
+	if dst == nil || cap(dst.Pix) < w*h {
+		dst = image.NewGray(r)
+	} else {
+		dst.Pix = dst.Pix[:w*h]
+		dst.Stride = w
+		dst.Rect = r
+	}
+
+	for y := 0; y < h; y++ {
+		src := img.Pix[img.PixOffset(b.Min.X, b.Min.Y+y):]
+		row := dst.Pix[y*w : (y+1)*w]
+		for x := range row {
+			p := src[x*4 : x*4+3 : x*4+3]
+			// Same arithmetic as color.GrayModel (BT.601 on 16-bit channels), so results match exactly.
+			r, g, b := uint32(p[0])*0x101, uint32(p[1])*0x101, uint32(p[2])*0x101
+			row[x] = uint8((19595*r + 38470*g + 7471*b + 1<<15) >> 24)
+		}
+	}
+	return dst
+}
+
+// Crop returns the part of img inside r without copying: the result shares pixels with img,
+// so later writes to either are visible in both. r is clipped to img's bounds.
+func Crop(img *image.RGBA, r image.Rectangle) *image.RGBA {
+	return img.SubImage(r).(*image.RGBA)
+}
+
+// CropCopy returns an independent copy of the part of img inside r, with bounds starting at (0,0).
+// r is clipped to img's bounds.
+func CropCopy(img *image.RGBA, r image.Rectangle) *image.RGBA {
+	r = r.Intersect(img.Bounds())
+	out := image.NewRGBA(image.Rect(0, 0, r.Dx(), r.Dy()))
+	for y := 0; y < r.Dy(); y++ {
+		src := img.Pix[img.PixOffset(r.Min.X, r.Min.Y+y):]
+		copy(out.Pix[y*out.Stride:(y+1)*out.Stride], src[:r.Dx()*4])
+	}
+	return out
+}
+
+// EncodeBase64PNG encodes img as PNG and returns it base64-encoded (standard alphabet, no
+// data: prefix), the form most remote and LLM-based detectors accept.
+// It favors speed over size (png.BestSpeed).
+func EncodeBase64PNG(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := enc.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
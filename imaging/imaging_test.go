@@ -0,0 +1,148 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func testImage(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, color.RGBA{uint8(x * 7), uint8(y * 13), uint8(x + y), 255})
+		}
+	}
+	return img
+}
+
+func TestToBGRBytes(t *testing.T) {
+	// A sub-image has a stride wider than its width; the output must not.
+	img := Crop(testImage(16, 12), image.Rect(3, 2, 10, 9))
+	buf, w, h := ToBGRBytes(img)
+	if w != 7 || h != 7 || len(buf) != w*h*3 {
+		t.Fatalf("got %dx%d with %d bytes", w, h, len(buf))
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := img.RGBAAt(img.Rect.Min.X+x, img.Rect.Min.Y+y)
+			i := (y*w + x) * 3
+			if buf[i] != c.B || buf[i+1] != c.G || buf[i+2] != c.R {
+				t.Fatalf("pixel (%d,%d) = %v, want BGR of %v", x, y, buf[i:i+3], c)
+			}
+		}
+	}
+
+	// Reuse: a large enough buffer must not be reallocated.
+	reused, _, _ := ToBGRBytesInto(buf, img)
+	if &reused[0] != &buf[0] {
+		t.Error("ToBGRBytesInto reallocated a large enough buffer")
+	}
+}
+
+func TestToGray(t *testing.T) {
+	img := Crop(testImage(9, 9), image.Rect(1, 1, 8, 8))
+	g := ToGray(img)
+	for y := 0; y < 7; y++ {
+		for x := 0; x < 7; x++ {
+			want := color.GrayModel.Convert(img.At(img.Rect.Min.X+x, img.Rect.Min.Y+y)).(color.Gray)
+			if got := g.GrayAt(x, y); got != want {
+				t.Fatalf("pixel (%d,%d) = %v, want %v", x, y, got, want)
+			}
+		}
+	}
+	if again := ToGrayInto(g, img); again != g {
+		t.Error("ToGrayInto did not reuse dst")
+	}
+}
+
+func TestCrop(t *testing.T) {
+	img := testImage(10, 10)
+	r := image.Rect(2, 3, 6, 8)
+
+	view := Crop(img, r)
+	cp := CropCopy(img, r)
+	if cp.Bounds() != image.Rect(0, 0, 4, 5) {
+		t.Fatalf("CropCopy bounds = %v", cp.Bounds())
+	}
+	if cp.RGBAAt(0, 0) != img.RGBAAt(2, 3) {
+		t.Fatal("CropCopy pixel mismatch")
+	}
+
+	img.SetRGBA(2, 3, color.RGBA{1, 2, 3, 4})
+	if view.RGBAAt(2, 3) != (color.RGBA{1, 2, 3, 4}) {
+		t.Error("Crop should share pixels with the source")
+	}
+	if cp.RGBAAt(0, 0) == (color.RGBA{1, 2, 3, 4}) {
+		t.Error("CropCopy should not share pixels with the source")
+	}
+}
+
+func TestEncodeBase64PNG(t *testing.T) {
+	img := testImage(8, 8)
+	s, err := EncodeBase64PNG(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := png.Decode(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dec.Bounds() != img.Bounds() {
+		t.Fatalf("decoded bounds = %v", dec.Bounds())
+	}
+	if r, g, b, _ := dec.At(5, 3).RGBA(); uint8(r>>8) != 35 || uint8(g>>8) != 39 || uint8(b>>8) != 8 {
+		t.Errorf("decoded pixel = %d,%d,%d", r>>8, g>>8, b>>8)
+	}
+}
+
+var frame = testImage(1920, 1080)
+
+func BenchmarkToBGRBytesInto(b *testing.B) {
+	var buf []byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf, _, _ = ToBGRBytesInto(buf, frame)
+	}
+}
+
+func BenchmarkToGrayInto(b *testing.B) {
+	var g *image.Gray
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		g = ToGrayInto(g, frame)
+	}
+}
+
+func BenchmarkCrop(b *testing.B) {
+	r := image.Rect(100, 100, 740, 580)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		Crop(frame, r)
+	}
+}
+
+func BenchmarkCropCopy(b *testing.B) {
+	r := image.Rect(100, 100, 740, 580)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		CropCopy(frame, r)
+	}
+}
+
+func BenchmarkEncodeBase64PNG(b *testing.B) {
+	small := CropCopy(frame, image.Rect(0, 0, 640, 480))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := EncodeBase64PNG(small); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
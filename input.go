@@ -0,0 +1,31 @@
+package winput
+
+import "time"
+
+// WindowInput is the input surface shared by *Window and *SessionWindow. Code that drives a
+// window through it can be unit-tested on any platform against mock.Window, which records
+// the calls instead of sending them.
+type WindowInput interface {
+	Move(x, y int32) error
+	MoveRel(dx, dy int32) error
+	Click(x, y int32) error
+	ClickRight(x, y int32) error
+	ClickMiddle(x, y int32) error
+	DoubleClick(x, y int32) error
+	Hover(x, y int32, d time.Duration) error
+	MouseDown(button MouseButton, x, y int32) error
+	MouseUp(button MouseButton, x, y int32) error
+	Drag(fromX, fromY, toX, toY int32) error
+	Scroll(x, y int32, delta int32) error
+	ScrollH(x, y int32, delta int32) error
+	KeyDown(key Key) error
+	KeyUp(key Key) error
+	Press(key Key) error
+	PressHotkey(keys ...Key) error
+	Type(text string) error
+}
+
+var (
+	_ WindowInput = (*Window)(nil)
+	_ WindowInput = (*SessionWindow)(nil)
+)
@@ -0,0 +1,411 @@
+// Command stubgen generates the non-Windows stub files that let packages importing winput
+// build on every platform.
+//
+// For each package it reads the Windows-only files (//go:build windows) and writes
+// stubs_other.go (//go:build !windows) declaring the same exported API: constants,
+// variables and types are copied, and every exported function and method returns zero
+// values with ErrUnsupportedPlatform in place of any error result. Unexported struct
+// fields and variables that depend on Windows-only state (e.g. the Proc* handles) are
+// left out.
+//
+// Run it from the module root with `go generate` (see doc.go) after changing the
+// exported API of a Windows-only file; a test fails if the stubs are stale.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const stubFile = "stubs_other.go"
+
+const windowPkg = "github.com/rpdg/winput/window"
+
+// target is a package that needs stubs and the expression its stubs return as error.
+type target struct {
+	dir string
+	err string
+}
+
+var targets = []target{
+	{".", "ErrUnsupportedPlatform"},
+	{"window", "ErrUnsupportedPlatform"},
+	{"keyboard", "window.ErrUnsupportedPlatform"},
+	{"mouse", "window.ErrUnsupportedPlatform"},
+	{"hid", "window.ErrUnsupportedPlatform"},
+	{"hid/interception", "window.ErrUnsupportedPlatform"},
+	{"screen", "window.ErrUnsupportedPlatform"},
+	{"uia", "window.ErrUnsupportedPlatform"},
+	{"gamepad", "window.ErrUnsupportedPlatform"},
+}
+
+func main() {
+	for _, t := range targets {
+		src, err := generate(t.dir, t.err)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "stubgen: %s: %v\n", t.dir, err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(filepath.Join(t.dir, stubFile), src, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "stubgen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// generate returns the stub file for the package in dir.
+func generate(dir, errExpr string) ([]byte, error) {
+	fset := token.NewFileSet()
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	g := &gen{fset: fset, imports: map[string]string{}}
+	var files []*ast.File
+	for _, name := range names {
+		base := filepath.Base(name)
+		if strings.HasSuffix(base, "_test.go") || base == stubFile {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		if g.pkg == "" {
+			g.pkg = f.Name.Name
+		}
+		if isWindowsOnly(f) {
+			files = append(files, f)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Windows-only files")
+	}
+
+	// Package-level names declared in Windows-only files; stubs may not refer to the
+	// unexported ones unless they are emitted too.
+	g.windowsNames = map[string]bool{}
+	for _, f := range files {
+		for _, d := range f.Decls {
+			if gd, ok := d.(*ast.GenDecl); ok {
+				for _, s := range gd.Specs {
+					for _, id := range specNames(s) {
+						g.windowsNames[id.Name] = true
+					}
+				}
+			}
+		}
+	}
+
+	g.errExpr = errExpr
+	if strings.HasPrefix(errExpr, "window.") {
+		g.imports["window"] = windowPkg
+	}
+	for _, f := range files {
+		g.file(f)
+	}
+	return g.output()
+}
+
+func isWindowsOnly(f *ast.File) bool {
+	for _, cg := range f.Comments {
+		if cg.Pos() > f.Package {
+			break
+		}
+		for _, c := range cg.List {
+			if c.Text == "//go:build windows" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func specNames(s ast.Spec) []*ast.Ident {
+	switch s := s.(type) {
+	case *ast.ValueSpec:
+		return s.Names
+	case *ast.TypeSpec:
+		return []*ast.Ident{s.Name}
+	}
+	return nil
+}
+
+type gen struct {
+	fset         *token.FileSet
+	pkg          string
+	errExpr      string
+	windowsNames map[string]bool
+	imports      map[string]string // name -> path, for imports used by emitted code
+	decls        []string
+	funcs        []string
+}
+
+func (g *gen) file(f *ast.File) {
+	fileImports := map[string]string{}
+	for _, imp := range f.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := path[strings.LastIndex(path, "/")+1:]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		fileImports[name] = path
+	}
+
+	for _, d := range f.Decls {
+		switch d := d.(type) {
+		case *ast.GenDecl:
+			switch d.Tok {
+			case token.CONST:
+				g.constDecl(d, f, fileImports)
+			case token.VAR:
+				g.varDecl(d, fileImports)
+			case token.TYPE:
+				g.typeDecl(d, fileImports)
+			}
+		case *ast.FuncDecl:
+			g.funcDecl(d, fileImports)
+		}
+	}
+}
+
+// portable reports whether n refers only to exported or locally declared identifiers of
+// this package and to imports other than syscall and unsafe, recording the imports it uses.
+func (g *gen) portable(n ast.Node, fileImports map[string]string, local map[string]bool) bool {
+	ok := true
+	used := map[string]string{}
+	ast.Inspect(n, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.SelectorExpr:
+			if x, isIdent := n.X.(*ast.Ident); isIdent {
+				if path, isImport := fileImports[x.Name]; isImport {
+					if path == "syscall" || path == "unsafe" {
+						ok = false
+					}
+					used[x.Name] = path
+					return false
+				}
+			}
+		case *ast.Ident:
+			if g.windowsNames[n.Name] && !ast.IsExported(n.Name) && !local[n.Name] {
+				ok = false
+			}
+		}
+		return ok
+	})
+	if ok {
+		for k, v := range used {
+			g.imports[k] = v
+		}
+	}
+	return ok
+}
+
+func (g *gen) constDecl(d *ast.GenDecl, f *ast.File, fileImports map[string]string) {
+	exported := false
+	local := map[string]bool{}
+	for _, s := range d.Specs {
+		for _, id := range specNames(s) {
+			exported = exported || id.IsExported()
+			local[id.Name] = true
+		}
+	}
+	// A const block is kept whole so that iota values do not shift.
+	if exported && g.portable(d, fileImports, local) {
+		g.decls = append(g.decls, g.print(&printer.CommentedNode{Node: d, Comments: f.Comments}))
+	}
+}
+
+func (g *gen) varDecl(d *ast.GenDecl, fileImports map[string]string) {
+	for _, s := range d.Specs {
+		vs := s.(*ast.ValueSpec)
+		exported := false
+		for _, id := range vs.Names {
+			exported = exported || id.IsExported()
+		}
+		if !exported || !g.portable(vs, fileImports, nil) {
+			continue
+		}
+		doc := specDoc(d, vs.Doc)
+		vs.Doc, vs.Comment = nil, nil
+		g.decls = append(g.decls, doc+"var "+g.print(vs))
+	}
+}
+
+func (g *gen) typeDecl(d *ast.GenDecl, fileImports map[string]string) {
+	for _, s := range d.Specs {
+		ts := s.(*ast.TypeSpec)
+		if !ts.Name.IsExported() {
+			continue
+		}
+		doc := specDoc(d, ts.Doc)
+		ts.Doc, ts.Comment = nil, nil
+		if st, ok := ts.Type.(*ast.StructType); ok {
+			var fields []*ast.Field
+			for _, f := range st.Fields.List {
+				f.Doc, f.Comment = nil, nil
+				if !fieldExported(f) || !g.portable(f.Type, fileImports, nil) {
+					continue
+				}
+				fields = append(fields, f)
+			}
+			st.Fields.List = fields
+		} else if !g.portable(ts.Type, fileImports, nil) {
+			continue
+		}
+		g.decls = append(g.decls, doc+"type "+g.print(ts))
+	}
+}
+
+func fieldExported(f *ast.Field) bool {
+	if len(f.Names) == 0 {
+		t := f.Type
+		if star, ok := t.(*ast.StarExpr); ok {
+			t = star.X
+		}
+		switch t := t.(type) {
+		case *ast.Ident:
+			return t.IsExported()
+		case *ast.SelectorExpr:
+			return t.Sel.IsExported()
+		}
+		return false
+	}
+	var names []*ast.Ident
+	for _, n := range f.Names {
+		if n.IsExported() {
+			names = append(names, n)
+		}
+	}
+	f.Names = names
+	return len(names) > 0
+}
+
+func (g *gen) funcDecl(d *ast.FuncDecl, fileImports map[string]string) {
+	if !d.Name.IsExported() {
+		return
+	}
+	if d.Recv != nil {
+		t := d.Recv.List[0].Type
+		if star, ok := t.(*ast.StarExpr); ok {
+			t = star.X
+		}
+		if id, ok := t.(*ast.Ident); !ok || !id.IsExported() {
+			return
+		}
+	}
+	if !g.portable(d.Type, fileImports, nil) {
+		return
+	}
+
+	var results []string
+	if d.Type.Results != nil {
+		for _, f := range d.Type.Results.List {
+			n := len(f.Names)
+			if n == 0 {
+				n = 1
+			}
+			for ; n > 0; n-- {
+				results = append(results, g.zero(f.Type))
+			}
+		}
+	}
+
+	body := " {}"
+	if len(results) > 0 {
+		body = " {\n\treturn " + strings.Join(results, ", ") + "\n}"
+	}
+	doc := docText(d.Doc)
+	d.Doc, d.Body = nil, nil
+	g.funcs = append(g.funcs, doc+g.print(d)+body)
+}
+
+// zero returns the expression a stub returns for a result of type t.
+func (g *gen) zero(t ast.Expr) string {
+	switch t := t.(type) {
+	case *ast.StarExpr, *ast.MapType, *ast.ChanType, *ast.FuncType, *ast.InterfaceType:
+		return "nil"
+	case *ast.ArrayType:
+		if t.Len == nil {
+			return "nil"
+		}
+	case *ast.Ident:
+		switch t.Name {
+		case "error":
+			return g.errExpr
+		case "bool":
+			return "false"
+		case "string":
+			return `""`
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64",
+			"uintptr", "byte", "rune", "float32", "float64":
+			return "0"
+		}
+	}
+	return "*new(" + g.print(t) + ")"
+}
+
+// specDoc returns the doc comment of a spec, or of its declaration if it is the only spec.
+func specDoc(d *ast.GenDecl, doc *ast.CommentGroup) string {
+	if doc == nil && len(d.Specs) == 1 {
+		doc = d.Doc
+	}
+	return docText(doc)
+}
+
+func docText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, c := range cg.List {
+		b.WriteString(c.Text + "\n")
+	}
+	return b.String()
+}
+
+func (g *gen) print(n any) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, g.fset, n)
+	return buf.String()
+}
+
+func (g *gen) output() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("// Code generated by internal/stubgen; DO NOT EDIT.\n\n")
+	b.WriteString("//go:build !windows\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", g.pkg)
+
+	paths := make([]string, 0, len(g.imports))
+	for name, path := range g.imports {
+		if name == path[strings.LastIndex(path, "/")+1:] {
+			paths = append(paths, strconv.Quote(path))
+		} else {
+			paths = append(paths, name+" "+strconv.Quote(path))
+		}
+	}
+	sort.Strings(paths)
+	if len(paths) > 0 {
+		fmt.Fprintf(&b, "import (\n%s\n)\n\n", strings.Join(paths, "\n"))
+	}
+
+	for _, d := range g.decls {
+		b.WriteString(d + "\n\n")
+	}
+	for _, f := range g.funcs {
+		b.WriteString(f + "\n\n")
+	}
+	return format.Source(b.Bytes())
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestStubsUpToDate fails when a Windows-only file's exported API changed without
+// re-running `go generate` at the module root.
+func TestStubsUpToDate(t *testing.T) {
+	root := filepath.Join("..", "..")
+	for _, tg := range targets {
+		dir := filepath.Join(root, tg.dir)
+		want, err := generate(dir, tg.err)
+		if err != nil {
+			t.Fatalf("%s: %v", tg.dir, err)
+		}
+		got, err := os.ReadFile(filepath.Join(dir, stubFile))
+		if err != nil {
+			t.Fatalf("%s: %v", tg.dir, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s/%s is stale; run go generate in the module root", tg.dir, stubFile)
+		}
+	}
+}
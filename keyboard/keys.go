@@ -1,5 +1,7 @@
 package keyboard
 
+import "sync"
+
 // Key represents a hardware scan code.
 type Key uint16
 
@@ -154,14 +156,70 @@ var runeMap = map[rune]KeyDef{
 	'\t': {KeyTab, false},
 }
 
+// runeMu guards runeMap against RegisterRune while text is being typed.
+var runeMu sync.RWMutex
+
 // LookupKey returns the Scan Code and whether Shift is required.
+// It is safe to call concurrently with RegisterRune.
 func LookupKey(r rune) (Key, bool, bool) {
+	runeMu.RLock()
 	k, ok := runeMap[r]
+	runeMu.RUnlock()
 	return k.Code, k.Shifted, ok
 }
 
-// isExtended returns true if the key is an extended key (prefixed with E0).
-func isExtended(key Key) bool {
+// RegisterRune maps r to a key, adding a character of the local layout (e.g. '§') or
+// replacing a built-in mapping. It affects every later lookup, in all goroutines.
+func RegisterRune(r rune, def KeyDef) {
+	runeMu.Lock()
+	runeMap[r] = def
+	runeMu.Unlock()
+}
+
+// RegisterRunes is RegisterRune for several runes at once.
+func RegisterRunes(defs map[rune]KeyDef) {
+	runeMu.Lock()
+	for r, def := range defs {
+		runeMap[r] = def
+	}
+	runeMu.Unlock()
+}
+
+// RuneMap holds rune mappings that take precedence over the global ones, such as the
+// per-window overrides of the root package. A nil RuneMap holds none.
+type RuneMap map[rune]KeyDef
+
+// Lookup is LookupKey consulting m first.
+func (m RuneMap) Lookup(r rune) (Key, bool, bool) {
+	if def, ok := m[r]; ok {
+		return def.Code, def.Shifted, true
+	}
+	return LookupKey(r)
+}
+
+// IsCharacter reports whether the key types a printable character (a letter, digit,
+// punctuation or Space), as opposed to Enter, Tab, modifiers and navigation keys.
+// Scan codes are shared between some keys (KeySlash and KeyDivide), which both report true.
+func IsCharacter(key Key) bool {
+	if key == KeyEnter || key == KeyTab {
+		return false
+	}
+	runeMu.RLock()
+	defer runeMu.RUnlock()
+	for _, def := range runeMap {
+		if def.Code == key {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExtended reports whether the key is an extended key (prefixed with E0), i.e. whether
+// its keyboard messages carry the extended bit (24) in LPARAM.
+//
+// KeyRightCtrl, KeyRightAlt and KeyDivide share their codes with KeyCtrl, KeyAlt and
+// KeySlash, so this also reports true for those.
+func IsExtended(key Key) bool {
 	switch key {
 	case KeyInsert, KeyDelete,
 		KeyHome, KeyEnd,
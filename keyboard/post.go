@@ -1,3 +1,5 @@
+//go:build windows
+
 package keyboard
 
 import (
@@ -12,6 +14,7 @@ const (
 	WM_KEYDOWN = 0x0100
 	WM_KEYUP   = 0x0101
 	WM_CHAR    = 0x0102
+	WM_PASTE   = 0x0302
 
 	MAPVK_VSC_TO_VK = 1
 )
@@ -41,7 +44,7 @@ func makeKeyLParam(sc Key, isUp bool) uintptr {
 	lparam |= (uintptr(sc) & 0xFF) << 16
 
 	// Extended key flag (bit 24)
-	if isExtended(sc) {
+	if IsExtended(sc) {
 		lparam |= 1 << 24
 	}
 
@@ -81,26 +84,127 @@ func Press(hwnd uintptr, key Key) error {
 	return KeyUp(hwnd, key)
 }
 
+// postChar posts a single rune as WM_CHAR, splitting it into a UTF-16 surrogate pair if needed.
+func postChar(hwnd uintptr, r rune) error {
+	if r > 0xFFFF {
+		r -= 0x10000
+		high := 0xD800 + (r >> 10)
+		low := 0xDC00 + (r & 0x3FF)
+		if err := post(hwnd, WM_CHAR, uintptr(high), 1); err != nil {
+			return err
+		}
+		return post(hwnd, WM_CHAR, uintptr(low), 1)
+	}
+	return post(hwnd, WM_CHAR, uintptr(r), 1)
+}
+
+// Paste asks the specified control to insert the clipboard contents (WM_PASTE).
+func Paste(hwnd uintptr) error {
+	return post(hwnd, WM_PASTE, 0, 0)
+}
+
 // Type sends text to the specified window using WM_CHAR messages.
 // This is reliable for background input but does not support non-character keys.
 func Type(hwnd uintptr, text string) error {
+	return TypeWithDelay(hwnd, text, 30*time.Millisecond)
+}
+
+// TypeWithDelay is Type with a custom pause after each character.
+func TypeWithDelay(hwnd uintptr, text string, delay time.Duration) error {
 	for _, r := range text {
-		if r > 0xFFFF {
-			r -= 0x10000
-			high := 0xD800 + (r >> 10)
-			low := 0xDC00 + (r & 0x3FF)
-			if err := post(hwnd, WM_CHAR, uintptr(high), 1); err != nil {
+		if err := postChar(hwnd, r); err != nil {
+			return err
+		}
+		time.Sleep(delay)
+	}
+	return nil
+}
+
+// TypeWithKeys is TypeWithDelay, except that runes in keys are posted as key strokes
+// (holding Shift if needed) instead of WM_CHAR, e.g. '\n' as Enter for a target that
+// ignores a newline character.
+func TypeWithKeys(hwnd uintptr, text string, delay time.Duration, keys RuneMap) error {
+	for _, r := range text {
+		def, ok := keys[r]
+		if !ok {
+			if err := postChar(hwnd, r); err != nil {
 				return err
 			}
-			if err := post(hwnd, WM_CHAR, uintptr(low), 1); err != nil {
+			time.Sleep(delay)
+			continue
+		}
+		if def.Shifted {
+			if err := KeyDown(hwnd, KeyShift); err != nil {
+				return err
+			}
+		}
+		err := Press(hwnd, def.Code)
+		if def.Shifted {
+			if upErr := KeyUp(hwnd, KeyShift); err == nil {
+				err = upErr
+			}
+		}
+		if err != nil {
+			return err
+		}
+		time.Sleep(delay)
+	}
+	return nil
+}
+
+// TypeKeys sends text to the specified window as WM_KEYDOWN/WM_KEYUP pairs, for targets
+// that ignore WM_CHAR and build text from key events themselves.
+// Shift is posted as its own key transition and only toggled when the next character
+// needs a different state; it is always released before returning, even on error.
+// Runes without a scan code mapping are sent as WM_CHAR.
+func TypeKeys(hwnd uintptr, text string) error {
+	return TypeKeysMapped(hwnd, text, 30*time.Millisecond, 10*time.Millisecond, nil)
+}
+
+// TypeKeysMapped is TypeKeys with custom pacing, looking runes up in keys before the global
+// mappings: delay is the pause after each character and hold how long each key (and a
+// Shift transition) is held before the next message.
+func TypeKeysMapped(hwnd uintptr, text string, delay, hold time.Duration, keys RuneMap) (err error) {
+	shift := false
+	defer func() {
+		if shift {
+			if upErr := KeyUp(hwnd, KeyShift); err == nil {
+				err = upErr
+			}
+		}
+	}()
+
+	for _, r := range text {
+		k, shifted, ok := keys.Lookup(r)
+		if !ok {
+			if err := postChar(hwnd, r); err != nil {
 				return err
 			}
-		} else {
-			if err := post(hwnd, WM_CHAR, uintptr(r), 1); err != nil {
+			time.Sleep(delay)
+			continue
+		}
+
+		if shifted != shift {
+			if shifted {
+				err = KeyDown(hwnd, KeyShift)
+			} else {
+				err = KeyUp(hwnd, KeyShift)
+			}
+			if err != nil {
 				return err
 			}
+			shift = shifted
+			time.Sleep(hold)
+		}
+
+		if err := KeyDown(hwnd, k); err != nil {
+			return err
+		}
+		time.Sleep(hold)
+		if err := KeyUp(hwnd, k); err != nil {
+			return err
 		}
-		time.Sleep(30 * time.Millisecond)
+		time.Sleep(delay)
 	}
 	return nil
 }
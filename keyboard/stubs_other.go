@@ -0,0 +1,100 @@
+// Code generated by internal/stubgen; DO NOT EDIT.
+
+//go:build !windows
+
+package keyboard
+
+import (
+	"github.com/rpdg/winput/window"
+	"time"
+)
+
+const (
+	WM_KEYDOWN = 0x0100
+	WM_KEYUP   = 0x0101
+	WM_CHAR    = 0x0102
+	WM_PASTE   = 0x0302
+
+	MAPVK_VSC_TO_VK = 1
+)
+
+const MAPVK_VK_TO_VSC_EX = 4
+
+// MapScanCodeToVK converts a hardware scan code to a virtual-key code.
+func MapScanCodeToVK(sc Key) uintptr {
+	return 0
+}
+
+// KeyDown simulates a key down event for the specified window using PostMessage.
+func KeyDown(hwnd uintptr, key Key) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// KeyUp simulates a key up event for the specified window using PostMessage.
+func KeyUp(hwnd uintptr, key Key) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Press simulates a key press (down then up) for the specified window using PostMessage.
+func Press(hwnd uintptr, key Key) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Paste asks the specified control to insert the clipboard contents (WM_PASTE).
+func Paste(hwnd uintptr) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Type sends text to the specified window using WM_CHAR messages.
+// This is reliable for background input but does not support non-character keys.
+func Type(hwnd uintptr, text string) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// TypeWithDelay is Type with a custom pause after each character.
+func TypeWithDelay(hwnd uintptr, text string, delay time.Duration) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// TypeWithKeys is TypeWithDelay, except that runes in keys are posted as key strokes
+// (holding Shift if needed) instead of WM_CHAR, e.g. '\n' as Enter for a target that
+// ignores a newline character.
+func TypeWithKeys(hwnd uintptr, text string, delay time.Duration, keys RuneMap) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// TypeKeys sends text to the specified window as WM_KEYDOWN/WM_KEYUP pairs, for targets
+// that ignore WM_CHAR and build text from key events themselves.
+// Shift is posted as its own key transition and only toggled when the next character
+// needs a different state; it is always released before returning, even on error.
+// Runes without a scan code mapping are sent as WM_CHAR.
+func TypeKeys(hwnd uintptr, text string) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// TypeKeysMapped is TypeKeys with custom pacing, looking runes up in keys before the global
+// mappings: delay is the pause after each character and hold how long each key (and a
+// Shift transition) is held before the next message.
+func TypeKeysMapped(hwnd uintptr, text string, delay, hold time.Duration, keys RuneMap) (err error) {
+	return window.ErrUnsupportedPlatform
+}
+
+// VKFromKey returns the virtual-key code for a scan code, as the current keyboard layout
+// maps it (MAPVK_VSC_TO_VK). Modifiers map to the generic VK_SHIFT/VK_CONTROL/VK_MENU and
+// the navigation keys to their dedicated VKs (VK_HOME, VK_DELETE, ...), never to keypad VKs.
+// It returns 0 if the scan code has no mapping.
+//
+// This is the canonical direction: for every Key constant k, KeyFromVK(VKFromKey(k))
+// returns k.
+func VKFromKey(k Key) uint16 {
+	return 0
+}
+
+// KeyFromVK returns the scan code for a virtual-key code under the current keyboard layout
+// (MAPVK_VK_TO_VSC_EX). Left/right modifier VKs map to the corresponding scan code.
+// It returns false for VKs with no scan code and for keys a Key cannot represent: the
+// keypad digits and decimal (which share scan codes with the navigation keys) and other
+// extended keys such as the Windows keys.
+func KeyFromVK(vk uint16) (Key, bool) {
+	return *new(Key), false
+}
@@ -0,0 +1,105 @@
+//go:build windows
+
+package keyboard
+
+import "github.com/rpdg/winput/window"
+
+const MAPVK_VK_TO_VSC_EX = 4
+
+// Virtual-key codes that need explicit handling in VKFromKey / KeyFromVK.
+const (
+	vkShift    = 0x10
+	vkControl  = 0x11
+	vkMenu     = 0x12
+	vkPrior    = 0x21
+	vkNext     = 0x22
+	vkEnd      = 0x23
+	vkHome     = 0x24
+	vkLeft     = 0x25
+	vkUp       = 0x26
+	vkRight    = 0x27
+	vkDown     = 0x28
+	vkInsert   = 0x2D
+	vkDelete   = 0x2E
+	vkNumpad0  = 0x60
+	vkNumpad9  = 0x69
+	vkDecimal  = 0x6E
+	vkDivide   = 0x6F
+	vkNumLock  = 0x90
+	vkLShift   = 0xA0
+	vkRShift   = 0xA1
+	vkLControl = 0xA2
+	vkRControl = 0xA3
+	vkLMenu    = 0xA4
+	vkRMenu    = 0xA5
+)
+
+// keyToVK fixes up scan codes whose MapVirtualKeyW result is ambiguous or depends on
+// NumLock: a Key carries no E0 prefix, so the navigation cluster shares codes with the
+// numeric keypad, and the modifiers share codes with their right-hand twins.
+var keyToVK = map[Key]uint16{
+	KeyShift:     vkShift,
+	KeyCtrl:      vkControl,
+	KeyAlt:       vkMenu,
+	KeyHome:      vkHome,
+	KeyArrowUp:   vkUp,
+	KeyPageUp:    vkPrior,
+	KeyLeft:      vkLeft,
+	KeyRight:     vkRight,
+	KeyEnd:       vkEnd,
+	KeyArrowDown: vkDown,
+	KeyPageDown:  vkNext,
+	KeyInsert:    vkInsert,
+	KeyDelete:    vkDelete,
+	KeyNumLock:   vkNumLock,
+}
+
+// vkToKey is the inverse fixup table, including the left/right modifier VKs.
+var vkToKey = map[uint16]Key{
+	vkShift: KeyShift, vkLShift: KeyShift, vkRShift: 0x36,
+	vkControl: KeyCtrl, vkLControl: KeyCtrl, vkRControl: KeyRightCtrl,
+	vkMenu: KeyAlt, vkLMenu: KeyAlt, vkRMenu: KeyRightAlt,
+	vkHome: KeyHome, vkUp: KeyArrowUp, vkPrior: KeyPageUp,
+	vkLeft: KeyLeft, vkRight: KeyRight,
+	vkEnd: KeyEnd, vkDown: KeyArrowDown, vkNext: KeyPageDown,
+	vkInsert: KeyInsert, vkDelete: KeyDelete,
+	vkDivide: KeyDivide, vkNumLock: KeyNumLock,
+}
+
+// VKFromKey returns the virtual-key code for a scan code, as the current keyboard layout
+// maps it (MAPVK_VSC_TO_VK). Modifiers map to the generic VK_SHIFT/VK_CONTROL/VK_MENU and
+// the navigation keys to their dedicated VKs (VK_HOME, VK_DELETE, ...), never to keypad VKs.
+// It returns 0 if the scan code has no mapping.
+//
+// This is the canonical direction: for every Key constant k, KeyFromVK(VKFromKey(k))
+// returns k.
+func VKFromKey(k Key) uint16 {
+	if vk, ok := keyToVK[k]; ok {
+		return vk
+	}
+	return uint16(MapScanCodeToVK(k))
+}
+
+// KeyFromVK returns the scan code for a virtual-key code under the current keyboard layout
+// (MAPVK_VK_TO_VSC_EX). Left/right modifier VKs map to the corresponding scan code.
+// It returns false for VKs with no scan code and for keys a Key cannot represent: the
+// keypad digits and decimal (which share scan codes with the navigation keys) and other
+// extended keys such as the Windows keys.
+func KeyFromVK(vk uint16) (Key, bool) {
+	if k, ok := vkToKey[vk]; ok {
+		return k, true
+	}
+	if (vk >= vkNumpad0 && vk <= vkNumpad9) || vk == vkDecimal {
+		return 0, false
+	}
+	r, _, _ := window.ProcMapVirtualKeyW.Call(uintptr(vk), MAPVK_VK_TO_VSC_EX)
+	sc := uint16(r)
+	if sc == 0 {
+		return 0, false
+	}
+	k := Key(sc & 0xFF)
+	if sc>>8 == 0xE0 && !IsExtended(k) {
+		return 0, false
+	}
+	return k, true
+}
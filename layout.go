@@ -0,0 +1,96 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/rpdg/winput/window"
+)
+
+// LayoutInfo describes an installed keyboard layout (input locale).
+type LayoutInfo = window.KeyboardLayout
+
+// KeyboardLayouts returns the keyboard layouts installed for the current user,
+// with their KLIDs (e.g. "00000409"), locales and display names.
+func KeyboardLayouts() ([]LayoutInfo, error) {
+	return window.KeyboardLayouts()
+}
+
+// LayoutNotInstalledError is returned when a requested layout is not installed.
+// It lists the installed layouts so the caller can pick one.
+type LayoutNotInstalledError struct {
+	KLID      string
+	Installed []LayoutInfo
+}
+
+func (e *LayoutNotInstalledError) Error() string {
+	names := make([]string, len(e.Installed))
+	for i, l := range e.Installed {
+		names[i] = fmt.Sprintf("%s %s", l.KLID, l.Name)
+	}
+	return fmt.Sprintf("keyboard layout %s is not installed (installed: %s)", e.KLID, strings.Join(names, ", "))
+}
+
+func (e *LayoutNotInstalledError) Unwrap() error {
+	return ErrLayoutNotInstalled
+}
+
+// findLayout returns the installed layout with the given KLID (case-insensitive).
+func findLayout(klid string) (LayoutInfo, error) {
+	layouts, err := window.KeyboardLayouts()
+	if err != nil {
+		return LayoutInfo{}, err
+	}
+	for _, l := range layouts {
+		if strings.EqualFold(l.KLID, klid) {
+			return l, nil
+		}
+	}
+	return LayoutInfo{}, &LayoutNotInstalledError{KLID: klid, Installed: layouts}
+}
+
+// ActivateLayout asks the window's thread to switch to the given installed layout
+// by posting WM_INPUTLANGCHANGEREQUEST. The target applies it asynchronously and may refuse.
+func (w *Window) ActivateLayout(klid string) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.activateLayout(klid)
+}
+
+func (w *Window) activateLayout(klid string) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	l, err := findLayout(klid)
+	if err != nil {
+		return err
+	}
+	return mapAccessDenied(window.PostMessage(w.HWND, window.WM_INPUTLANGCHANGEREQUEST, 0, l.HKL))
+}
+
+// WithLayout runs fn with the given installed layout active on the calling goroutine's
+// OS thread, restoring the previous layout afterwards. This affects how this process
+// translates keys (e.g. SendInput with virtual keys, VkKeyScanEx), not the target's layout;
+// use ActivateLayout for that.
+func WithLayout(klid string, fn func() error) error {
+	l, err := findLayout(klid)
+	if err != nil {
+		return err
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	prev, err := window.ActivateKeyboardLayout(l.HKL)
+	if err != nil {
+		return err
+	}
+	defer window.ActivateKeyboardLayout(prev)
+	return fn()
+}
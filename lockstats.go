@@ -0,0 +1,298 @@
+//go:build windows
+
+package winput
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rpdg/winput/hid"
+)
+
+// Lock names reported in LockWait and StatsSnapshot.
+const (
+	// LockInput is the global input lock that serializes all input calls and sessions.
+	LockInput = "input"
+	// LockCrossProcess is the named mutex configured with SetCrossProcessLock.
+	LockCrossProcess = "cross-process"
+	// LockHID is the Interception driver lock, held exclusively while the HID backend
+	// initializes or closes.
+	LockHID = "hid"
+)
+
+// LockWait is one lock acquisition made by a call.
+type LockWait struct {
+	Lock string
+	Wait time.Duration // 0 if the lock was free
+	// Holder is the operation that held the lock when the wait began, e.g. "Window.Click"
+	// or "Session". It is empty if the lock was free or the holder is unknown.
+	Holder string
+}
+
+// SlowCall is reported to the SetSlowCallThreshold callback.
+type SlowCall struct {
+	Op       string // e.g. "Window.Click" or "ClickMouseAt"
+	Start    time.Time
+	Duration time.Duration // from entry to release of the input lock, waits included
+	Waits    []LockWait
+}
+
+// LockStats summarizes the acquisitions of one lock.
+type LockStats struct {
+	Acquisitions  uint64
+	Contended     uint64 // acquisitions that had to wait
+	TotalWait     time.Duration
+	MaxWait       time.Duration
+	MaxWaitHolder string // Holder during the longest wait
+}
+
+// StatsSnapshot is a snapshot of the lock instrumentation counters.
+type StatsSnapshot struct {
+	Locks     map[string]LockStats // keyed by LockInput, LockCrossProcess, LockHID
+	SlowCalls uint64
+}
+
+type slowCallConfig struct {
+	threshold time.Duration
+	fn        func(SlowCall)
+}
+
+var (
+	// instrumented is the only state input calls look at while instrumentation is off.
+	instrumented atomic.Bool
+
+	statsMu      sync.Mutex
+	statsEnabled bool
+	slowCall     *slowCallConfig
+	callHook     func(SlowCall)
+	stats        = StatsSnapshot{Locks: map[string]LockStats{}}
+	inputHolder  string      // operation holding the input lock
+	activeCall   *callRecord // call holding the input lock, collecting its HID waits
+)
+
+// SetLockInstrumentation enables or disables lock instrumentation. While enabled, every
+// input call records how long it waited for each lock it acquired and which operation held
+// it, feeding Stats, SetSlowCallThreshold and SetCallHook. While disabled, the cost is one
+// atomic load per call.
+func SetLockInstrumentation(enabled bool) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	statsEnabled = enabled
+	updateInstrumentation()
+}
+
+// SetSlowCallThreshold calls fn with a SlowCall report for every input call that takes
+// longer than d, so lock contention can be alerted on in production. Setting it enables
+// lock instrumentation until it is cleared with d <= 0 or a nil fn.
+// fn runs on the calling goroutine after the input lock has been released.
+func SetSlowCallThreshold(d time.Duration, fn func(SlowCall)) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	if d <= 0 || fn == nil {
+		slowCall = nil
+	} else {
+		slowCall = &slowCallConfig{threshold: d, fn: fn}
+	}
+	updateInstrumentation()
+}
+
+// SetCallHook calls fn with the report of every input call made while it is set, whatever
+// its duration: the same SlowCall that SetSlowCallThreshold delivers for calls over the
+// threshold, so the lock waits of each call can be exported (e.g. as a histogram) rather
+// than only the aggregate Stats. Setting it enables lock instrumentation until it is
+// cleared with nil. fn runs on the calling goroutine after the input lock has been
+// released, and before any slow-call callback. Calls made through a Session take no lock
+// and are not reported; the session's own acquisition is counted in Stats only.
+func SetCallHook(fn func(SlowCall)) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	callHook = fn
+	updateInstrumentation()
+}
+
+// updateInstrumentation applies the instrumentation state. statsMu must be held.
+func updateInstrumentation() {
+	on := statsEnabled || slowCall != nil || callHook != nil
+	if on == instrumented.Load() {
+		return
+	}
+	if on {
+		hid.SetLockWaitHook(recordHIDWait)
+	} else {
+		hid.SetLockWaitHook(nil)
+	}
+	instrumented.Store(on)
+}
+
+// Stats returns a snapshot of the lock counters gathered while instrumentation was enabled.
+func Stats() StatsSnapshot {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	s := StatsSnapshot{Locks: make(map[string]LockStats, len(stats.Locks)), SlowCalls: stats.SlowCalls}
+	for k, v := range stats.Locks {
+		s.Locks[k] = v
+	}
+	return s
+}
+
+// ResetStats clears the counters returned by Stats.
+func ResetStats() {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	stats = StatsSnapshot{Locks: map[string]LockStats{}}
+}
+
+// recordLockWait adds w to the counters. statsMu must be held.
+func recordLockWait(w LockWait) {
+	ls := stats.Locks[w.Lock]
+	ls.Acquisitions++
+	if w.Wait > 0 {
+		ls.Contended++
+		ls.TotalWait += w.Wait
+		if w.Wait > ls.MaxWait {
+			ls.MaxWait, ls.MaxWaitHolder = w.Wait, w.Holder
+		}
+	}
+	stats.Locks[w.Lock] = ls
+}
+
+func recordHIDWait(wait time.Duration) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	w := LockWait{Lock: LockHID, Wait: wait}
+	recordLockWait(w)
+	if activeCall != nil {
+		activeCall.waits = append(activeCall.waits, w)
+	}
+}
+
+func setInputHolder(op string, c *callRecord) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	inputHolder, activeCall = op, c
+}
+
+// callRecord collects the lock waits of one instrumented call.
+type callRecord struct {
+	op    string
+	start time.Time
+	waits []LockWait
+}
+
+func (c *callRecord) add(w LockWait) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+	recordLockWait(w)
+	c.waits = append(c.waits, w)
+}
+
+// finish reports the call to the call hook, and to the slow-call callback if it exceeded
+// the threshold.
+func (c *callRecord) finish() {
+	d := time.Since(c.start)
+	statsMu.Lock()
+	hook, cfg := callHook, slowCall
+	slow := cfg != nil && d >= cfg.threshold
+	if slow {
+		stats.SlowCalls++
+	}
+	statsMu.Unlock()
+
+	report := SlowCall{Op: c.op, Start: c.start, Duration: d, Waits: c.waits}
+	if hook != nil {
+		hook(report)
+	}
+	if slow {
+		cfg.fn(report)
+	}
+}
+
+// waitInputSem is acquireInputSem measuring the wait and who held the lock.
+func waitInputSem(ctx context.Context, session bool) (LockWait, error) {
+	w := LockWait{Lock: LockInput}
+	select {
+	case inputSem <- struct{}{}:
+		return w, nil
+	default:
+	}
+	statsMu.Lock()
+	w.Holder = inputHolder
+	statsMu.Unlock()
+	start := time.Now()
+	if err := acquireInputSem(ctx, session); err != nil {
+		return w, err
+	}
+	w.Wait = time.Since(start)
+	return w, nil
+}
+
+// lockInputInstrumented is lockInput with lock instrumentation; op names the public call.
+func lockInputInstrumented(op string) (func(), error) {
+	c := &callRecord{op: op, start: time.Now()}
+	w, err := waitInputSem(context.Background(), false)
+	if err != nil {
+		return nil, err
+	}
+	c.add(w)
+
+	xunlock, err := lockCrossProcessInstrumented(c)
+	if err != nil {
+		<-inputSem
+		return nil, err
+	}
+	setInputHolder(op, c)
+	return func() {
+		xunlock()
+		setInputHolder("", nil)
+		<-inputSem
+		c.finish()
+	}, nil
+}
+
+// lockCrossProcessInstrumented is lockCrossProcess, probing the mutex first so that
+// contention can be told apart from the cost of the acquisition itself.
+func lockCrossProcessInstrumented(c *callRecord) (func(), error) {
+	xlockMu.Lock()
+	l, timeout := xlock, xlockTimeout
+	xlockMu.Unlock()
+
+	if l == nil || getBackend() != BackendHID {
+		return func() {}, nil
+	}
+	w := LockWait{Lock: LockCrossProcess}
+	if err := l.call(xlockReq{op: 0}); err != nil {
+		start := time.Now()
+		if err := l.call(xlockReq{op: 0, timeout: timeout}); err != nil {
+			return nil, err
+		}
+		w.Wait = time.Since(start)
+	}
+	c.add(w)
+	return func() { l.call(xlockReq{op: 1}) }, nil
+}
+
+var pkgPrefix = reflect.TypeOf(Window{}).PkgPath() + "."
+
+// callerOp names the public winput function that called lockInput, e.g. "Window.Click".
+func callerOp() string {
+	var pcs [8]uintptr
+	n := runtime.Callers(3, pcs[:]) // skip Callers, callerOp and lockInput
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if name, ok := strings.CutPrefix(f.Function, pkgPrefix); ok && !strings.HasSuffix(name, ".lockReady") {
+			if i := strings.Index(name, ".func"); i >= 0 {
+				name = name[:i]
+			}
+			return strings.NewReplacer("(*", "", ")", "").Replace(name)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Package mock records winput input calls instead of sending them. It builds and runs on
+// every platform, so business logic written against winput.WindowInput can be unit-tested
+// on Linux CI, where the real API only returns ErrUnsupportedPlatform.
+package mock
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/rpdg/winput"
+)
+
+// Event is one recorded call. Only the fields that apply to Op are set.
+type Event struct {
+	Op       string             // method name, e.g. "Click", "PressHotkey" or "Type"
+	X, Y     int32              // client point; the offset for MoveRel and the start for Drag
+	ToX, ToY int32              // end point of Drag
+	Delta    int32              // Scroll and ScrollH
+	Button   winput.MouseButton // MouseDown and MouseUp
+	Keys     []winput.Key       // KeyDown, KeyUp and Press (one key), PressHotkey
+	Text     string             // Type
+	Duration time.Duration      // Hover
+	Err      error              // what the call returned, see FailOn
+}
+
+// Window is a winput.WindowInput that records every call. The zero value is ready to use,
+// and a Window is safe for concurrent use.
+type Window struct {
+	mu     sync.Mutex
+	events []Event
+	fail   map[string]error
+}
+
+var _ winput.WindowInput = (*Window)(nil)
+
+// Events returns the calls recorded so far, oldest first.
+func (m *Window) Events() []Event {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.events)
+}
+
+// Ops returns the method names of the calls recorded so far, oldest first.
+func (m *Window) Ops() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ops := make([]string, len(m.events))
+	for i, e := range m.events {
+		ops[i] = e.Op
+	}
+	return ops
+}
+
+// Reset discards the recorded calls and the failures set with FailOn.
+func (m *Window) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events, m.fail = nil, nil
+}
+
+// FailOn makes every later call of the method op (e.g. "Click") return err, to exercise
+// error paths. The call is still recorded, with Err set. A nil err clears the failure.
+func (m *Window) FailOn(op string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err == nil {
+		delete(m.fail, op)
+		return
+	}
+	if m.fail == nil {
+		m.fail = make(map[string]error)
+	}
+	m.fail[op] = err
+}
+
+// Held returns the keys and mouse buttons pressed with KeyDown or MouseDown and not yet
+// released by a successful KeyUp or MouseUp, in the order they were pressed, e.g. to
+// assert that a failing sequence cleans up after itself.
+func (m *Window) Held() ([]winput.Key, []winput.MouseButton) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var keys []winput.Key
+	var buttons []winput.MouseButton
+	for _, e := range m.events {
+		if e.Err != nil {
+			continue
+		}
+		switch e.Op {
+		case "KeyDown":
+			if !slices.Contains(keys, e.Keys[0]) {
+				keys = append(keys, e.Keys[0])
+			}
+		case "KeyUp":
+			keys = slices.DeleteFunc(keys, func(k winput.Key) bool { return k == e.Keys[0] })
+		case "MouseDown":
+			if !slices.Contains(buttons, e.Button) {
+				buttons = append(buttons, e.Button)
+			}
+		case "MouseUp":
+			buttons = slices.DeleteFunc(buttons, func(b winput.MouseButton) bool { return b == e.Button })
+		}
+	}
+	return keys, buttons
+}
+
+func (m *Window) record(e Event) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e.Err = m.fail[e.Op]
+	m.events = append(m.events, e)
+	return e.Err
+}
+
+// Move records a Move call.
+func (m *Window) Move(x, y int32) error {
+	return m.record(Event{Op: "Move", X: x, Y: y})
+}
+
+// MoveRel records a MoveRel call.
+func (m *Window) MoveRel(dx, dy int32) error {
+	return m.record(Event{Op: "MoveRel", X: dx, Y: dy})
+}
+
+// Click records a Click call.
+func (m *Window) Click(x, y int32) error {
+	return m.record(Event{Op: "Click", X: x, Y: y})
+}
+
+// ClickRight records a ClickRight call.
+func (m *Window) ClickRight(x, y int32) error {
+	return m.record(Event{Op: "ClickRight", X: x, Y: y})
+}
+
+// ClickMiddle records a ClickMiddle call.
+func (m *Window) ClickMiddle(x, y int32) error {
+	return m.record(Event{Op: "ClickMiddle", X: x, Y: y})
+}
+
+// DoubleClick records a DoubleClick call.
+func (m *Window) DoubleClick(x, y int32) error {
+	return m.record(Event{Op: "DoubleClick", X: x, Y: y})
+}
+
+// Hover records a Hover call without waiting for d.
+func (m *Window) Hover(x, y int32, d time.Duration) error {
+	return m.record(Event{Op: "Hover", X: x, Y: y, Duration: d})
+}
+
+// MouseDown records a MouseDown call.
+func (m *Window) MouseDown(button winput.MouseButton, x, y int32) error {
+	return m.record(Event{Op: "MouseDown", X: x, Y: y, Button: button})
+}
+
+// MouseUp records a MouseUp call.
+func (m *Window) MouseUp(button winput.MouseButton, x, y int32) error {
+	return m.record(Event{Op: "MouseUp", X: x, Y: y, Button: button})
+}
+
+// Drag records a Drag call.
+func (m *Window) Drag(fromX, fromY, toX, toY int32) error {
+	return m.record(Event{Op: "Drag", X: fromX, Y: fromY, ToX: toX, ToY: toY})
+}
+
+// Scroll records a Scroll call.
+func (m *Window) Scroll(x, y int32, delta int32) error {
+	return m.record(Event{Op: "Scroll", X: x, Y: y, Delta: delta})
+}
+
+// ScrollH records a ScrollH call.
+func (m *Window) ScrollH(x, y int32, delta int32) error {
+	return m.record(Event{Op: "ScrollH", X: x, Y: y, Delta: delta})
+}
+
+// KeyDown records a KeyDown call.
+func (m *Window) KeyDown(key winput.Key) error {
+	return m.record(Event{Op: "KeyDown", Keys: []winput.Key{key}})
+}
+
+// KeyUp records a KeyUp call.
+func (m *Window) KeyUp(key winput.Key) error {
+	return m.record(Event{Op: "KeyUp", Keys: []winput.Key{key}})
+}
+
+// Press records a Press call.
+func (m *Window) Press(key winput.Key) error {
+	return m.record(Event{Op: "Press", Keys: []winput.Key{key}})
+}
+
+// PressHotkey records a PressHotkey call.
+func (m *Window) PressHotkey(keys ...winput.Key) error {
+	return m.record(Event{Op: "PressHotkey", Keys: slices.Clone(keys)})
+}
+
+// Type records a Type call.
+func (m *Window) Type(text string) error {
+	return m.record(Event{Op: "Type", Text: text})
+}
@@ -0,0 +1,85 @@
+package mock_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/rpdg/winput"
+	"github.com/rpdg/winput/mock"
+)
+
+// login stands for business logic written against winput.WindowInput.
+func login(w winput.WindowInput, user string) error {
+	if err := w.Click(120, 40); err != nil {
+		return err
+	}
+	if err := w.PressHotkey(winput.KeyCtrl, winput.KeyA); err != nil {
+		return err
+	}
+	if err := w.Type(user); err != nil {
+		return err
+	}
+	return w.Press(winput.KeyEnter)
+}
+
+func TestWindowRecords(t *testing.T) {
+	var m mock.Window
+	if err := login(&m, "alice"); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if ops := m.Ops(); !slices.Equal(ops, []string{"Click", "PressHotkey", "Type", "Press"}) {
+		t.Fatalf("Ops() = %v", ops)
+	}
+	ev := m.Events()
+	if ev[0].X != 120 || ev[0].Y != 40 {
+		t.Errorf("Click at (%d,%d), want (120,40)", ev[0].X, ev[0].Y)
+	}
+	if !slices.Equal(ev[1].Keys, []winput.Key{winput.KeyCtrl, winput.KeyA}) {
+		t.Errorf("PressHotkey keys = %v", ev[1].Keys)
+	}
+	if ev[2].Text != "alice" || ev[3].Keys[0] != winput.KeyEnter {
+		t.Errorf("unexpected events: %+v", ev[2:])
+	}
+
+	m.Reset()
+	if len(m.Events()) != 0 {
+		t.Error("Reset kept the recorded events")
+	}
+}
+
+func TestWindowFailOn(t *testing.T) {
+	var m mock.Window
+	errType := errors.New("type failed")
+	m.FailOn("Type", errType)
+	if err := login(&m, "bob"); !errors.Is(err, errType) {
+		t.Fatalf("login = %v, want the injected error", err)
+	}
+	if ops := m.Ops(); !slices.Equal(ops, []string{"Click", "PressHotkey", "Type"}) {
+		t.Errorf("Ops() = %v, want login to stop at the failed Type", ops)
+	}
+	if ev := m.Events(); ev[2].Err != errType {
+		t.Errorf("Type event Err = %v", ev[2].Err)
+	}
+
+	m.FailOn("Type", nil)
+	if err := m.Type("x"); err != nil {
+		t.Errorf("Type after clearing the failure = %v", err)
+	}
+}
+
+func TestWindowHeld(t *testing.T) {
+	var m mock.Window
+	m.KeyDown(winput.KeyShift)
+	m.KeyDown(winput.KeyCtrl)
+	m.MouseDown(winput.MouseLeft, 1, 1)
+	m.KeyUp(winput.KeyShift)
+
+	m.FailOn("MouseUp", errors.New("failed"))
+	m.MouseUp(winput.MouseLeft, 1, 1)
+
+	keys, buttons := m.Held()
+	if !slices.Equal(keys, []winput.Key{winput.KeyCtrl}) || !slices.Equal(buttons, []winput.MouseButton{winput.MouseLeft}) {
+		t.Errorf("Held() = %v, %v; want [Ctrl], [Left]", keys, buttons)
+	}
+}
@@ -0,0 +1,19 @@
+//go:build windows
+
+package winput
+
+import (
+	"github.com/rpdg/winput/screen"
+	"github.com/rpdg/winput/window"
+)
+
+// Monitor returns the monitor that shows most of the window, or the nearest monitor if
+// the window is off-screen, e.g. to capture only that display. Its Bounds and WorkArea are
+// virtual desktop coordinates, as used by screen.ImageToVirtual.
+func (w *Window) Monitor() (screen.Monitor, error) {
+	h := window.MonitorFromWindow(w.HWND)
+	if h == 0 {
+		return screen.Monitor{}, ErrWindowGone
+	}
+	return screen.MonitorInfo(h)
+}
@@ -1,3 +1,5 @@
+//go:build windows
+
 package mouse
 
 import (
@@ -21,16 +23,69 @@ const (
 	WM_MBUTTONUP     = 0x0208
 	WM_MBUTTONDBLCLK = 0x0209
 	WM_MOUSEWHEEL    = 0x020A
+	WM_XBUTTONDOWN   = 0x020B
+	WM_XBUTTONUP     = 0x020C
+	WM_XBUTTONDBLCLK = 0x020D
+	WM_MOUSEHWHEEL   = 0x020E
+
+	MK_LBUTTON  = 0x0001
+	MK_RBUTTON  = 0x0002
+	MK_SHIFT    = 0x0004
+	MK_CONTROL  = 0x0008
+	MK_MBUTTON  = 0x0010
+	MK_XBUTTON1 = 0x0020
+	MK_XBUTTON2 = 0x0040
 
-	MK_LBUTTON = 0x0001
-	MK_RBUTTON = 0x0002
-	MK_MBUTTON = 0x0010
+	XBUTTON1 = 0x0001
+	XBUTTON2 = 0x0002
 
 	WHEEL_DELTA = 120
 )
 
 var ErrInvalidScrollDelta = errors.New("scroll delta must be a multiple of WHEEL_DELTA (120)")
 
+// Button identifies a mouse button.
+type Button int
+
+const (
+	Left Button = iota
+	Right
+	Middle
+	X1
+	X2
+)
+
+// messages returns the button's down and up messages, its MK_ flag and, for the X buttons,
+// the XBUTTON value carried in the high word of WPARAM.
+func (b Button) messages() (down, up uint32, mk, xbutton uintptr) {
+	switch b {
+	case Right:
+		return WM_RBUTTONDOWN, WM_RBUTTONUP, MK_RBUTTON, 0
+	case Middle:
+		return WM_MBUTTONDOWN, WM_MBUTTONUP, MK_MBUTTON, 0
+	case X1:
+		return WM_XBUTTONDOWN, WM_XBUTTONUP, MK_XBUTTON1, XBUTTON1 << 16
+	case X2:
+		return WM_XBUTTONDOWN, WM_XBUTTONUP, MK_XBUTTON2, XBUTTON2 << 16
+	default:
+		return WM_LBUTTONDOWN, WM_LBUTTONUP, MK_LBUTTON, 0
+	}
+}
+
+// dblclk returns the button's double-click message.
+func (b Button) dblclk() uint32 {
+	switch b {
+	case Right:
+		return WM_RBUTTONDBLCLK
+	case Middle:
+		return WM_MBUTTONDBLCLK
+	case X1, X2:
+		return WM_XBUTTONDBLCLK
+	default:
+		return WM_LBUTTONDBLCLK
+	}
+}
+
 // Helper to check for errors and wrap errno
 func post(hwnd uintptr, msg uint32, wparam uintptr, lparam uintptr) error {
 	r, _, e := window.ProcPostMessageW.Call(hwnd, uintptr(msg), wparam, lparam)
@@ -65,34 +120,49 @@ func Move(hwnd uintptr, x, y int32) error {
 	return post(hwnd, WM_MOUSEMOVE, 0, makeLParam(x, y))
 }
 
-// Click simulates a left mouse button click at the specified client coordinates.
-func Click(hwnd uintptr, x, y int32) error {
+// Down posts a press of button b at the specified client coordinates.
+func Down(hwnd uintptr, b Button, x, y int32) error {
+	down, _, mk, xbutton := b.messages()
+	return post(hwnd, down, mk|xbutton, makeLParam(x, y))
+}
+
+// Up posts a release of button b at the specified client coordinates.
+func Up(hwnd uintptr, b Button, x, y int32) error {
+	_, up, _, xbutton := b.messages()
+	return post(hwnd, up, xbutton, makeLParam(x, y))
+}
+
+// ClickButton posts a press and release of button b at the specified client coordinates.
+func ClickButton(hwnd uintptr, b Button, x, y int32) error {
+	return ClickWithKeys(hwnd, b, x, y, 0)
+}
+
+// ClickWithKeys posts a press and release of button b at the specified client coordinates
+// with keys (MK_CONTROL, MK_SHIFT) reported in WPARAM of both messages, e.g. for Ctrl+Click.
+// It does not press the keys themselves.
+func ClickWithKeys(hwnd uintptr, b Button, x, y int32, keys uint16) error {
+	down, up, mk, xbutton := b.messages()
 	lparam := makeLParam(x, y)
-	if err := post(hwnd, WM_LBUTTONDOWN, MK_LBUTTON, lparam); err != nil {
+	if err := post(hwnd, down, mk|xbutton|uintptr(keys), lparam); err != nil {
 		return err
 	}
 	time.Sleep(10 * time.Millisecond)
-	return post(hwnd, WM_LBUTTONUP, 0, lparam)
+	return post(hwnd, up, xbutton|uintptr(keys), lparam)
+}
+
+// Click simulates a left mouse button click at the specified client coordinates.
+func Click(hwnd uintptr, x, y int32) error {
+	return ClickButton(hwnd, Left, x, y)
 }
 
 // ClickRight simulates a right mouse button click at the specified client coordinates.
 func ClickRight(hwnd uintptr, x, y int32) error {
-	lparam := makeLParam(x, y)
-	if err := post(hwnd, WM_RBUTTONDOWN, MK_RBUTTON, lparam); err != nil {
-		return err
-	}
-	time.Sleep(10 * time.Millisecond)
-	return post(hwnd, WM_RBUTTONUP, 0, lparam)
+	return ClickButton(hwnd, Right, x, y)
 }
 
 // ClickMiddle simulates a middle mouse button click at the specified client coordinates.
 func ClickMiddle(hwnd uintptr, x, y int32) error {
-	lparam := makeLParam(x, y)
-	if err := post(hwnd, WM_MBUTTONDOWN, MK_MBUTTON, lparam); err != nil {
-		return err
-	}
-	time.Sleep(10 * time.Millisecond)
-	return post(hwnd, WM_MBUTTONUP, 0, lparam)
+	return ClickButton(hwnd, Middle, x, y)
 }
 
 // DoubleClick simulates a left mouse button double-click at the specified client coordinates.
@@ -104,6 +174,60 @@ func DoubleClick(hwnd uintptr, x, y int32) error {
 	return post(hwnd, WM_LBUTTONUP, 0, lparam)
 }
 
+// MultiClick posts count presses and releases of button b at the specified client coordinates,
+// as the system reports them to a window with CS_DBLCLKS: every second press is the button's
+// double-click message (WM_LBUTTONDBLCLK etc.), so 2 is a double click and 3 a triple click.
+func MultiClick(hwnd uintptr, b Button, x, y int32, count int) error {
+	down, _, mk, xbutton := b.messages()
+	lparam := makeLParam(x, y)
+	for i := 0; i < count; i++ {
+		if i > 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		msg := down
+		if i%2 == 1 {
+			msg = b.dblclk()
+		}
+		if err := post(hwnd, msg, mk|xbutton, lparam); err != nil {
+			return err
+		}
+		if err := Up(hwnd, b, x, y); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DoubleClickRight simulates a right mouse button double-click at the specified client coordinates.
+func DoubleClickRight(hwnd uintptr, x, y int32) error {
+	return MultiClick(hwnd, Right, x, y, 2)
+}
+
+// Drag posts a press of button b at client point (fromX, fromY), steps WM_MOUSEMOVE
+// messages with the button held along the straight line to (toX, toY), and the release there.
+// steps <= 0 means a single move.
+func Drag(hwnd uintptr, b Button, fromX, fromY, toX, toY int32, steps int) error {
+	_, _, mk, _ := b.messages()
+	if steps <= 0 {
+		steps = 1
+	}
+	if err := Down(hwnd, b, fromX, fromY); err != nil {
+		return err
+	}
+	for i := 1; i <= steps; i++ {
+		x := fromX + (toX-fromX)*int32(i)/int32(steps)
+		y := fromY + (toY-fromY)*int32(i)/int32(steps)
+		time.Sleep(10 * time.Millisecond)
+		if err := post(hwnd, WM_MOUSEMOVE, mk, makeLParam(x, y)); err != nil {
+			// Release anyway so the target is not left with the button held.
+			Up(hwnd, b, x, y)
+			return err
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	return Up(hwnd, b, toX, toY)
+}
+
 // Scroll simulates a vertical mouse wheel scroll at the specified coordinates.
 // delta must be a multiple of WHEEL_DELTA (120).
 func Scroll(hwnd uintptr, x, y int32, delta int32) error {
@@ -115,10 +239,44 @@ func Scroll(hwnd uintptr, x, y int32, delta int32) error {
 	if err != nil {
 		return err
 	}
+	return ScrollScreen(hwnd, sx, sy, delta, 0)
+}
+
+// ScrollScreen posts a vertical wheel message to hwnd for the given screen coordinates.
+// WM_MOUSEWHEEL carries screen, not client, coordinates in LPARAM.
+// keys is the MK_* state reported in the low word of WPARAM (e.g. MK_CONTROL for zoom).
+func ScrollScreen(hwnd uintptr, sx, sy int32, delta int32, keys uint16) error {
+	return wheel(hwnd, WM_MOUSEWHEEL, sx, sy, delta, keys)
+}
+
+// ScrollH simulates a horizontal mouse wheel scroll at the specified coordinates.
+// delta must be a multiple of WHEEL_DELTA (120); positive scrolls right.
+func ScrollH(hwnd uintptr, x, y int32, delta int32) error {
+	if delta%WHEEL_DELTA != 0 {
+		return ErrInvalidScrollDelta
+	}
+
+	sx, sy, err := window.ClientToScreen(hwnd, x, y)
+	if err != nil {
+		return err
+	}
+	return ScrollHScreen(hwnd, sx, sy, delta, 0)
+}
+
+// ScrollHScreen posts a horizontal wheel message (WM_MOUSEHWHEEL) to hwnd for the given
+// screen coordinates, laid out like ScrollScreen.
+func ScrollHScreen(hwnd uintptr, sx, sy int32, delta int32, keys uint16) error {
+	return wheel(hwnd, WM_MOUSEHWHEEL, sx, sy, delta, keys)
+}
+
+func wheel(hwnd uintptr, msg uint32, sx, sy int32, delta int32, keys uint16) error {
+	if delta%WHEEL_DELTA != 0 {
+		return ErrInvalidScrollDelta
+	}
 
-	// High-order word is signed delta
-	wparam := uintptr(uint16(0)) | (uintptr(int16(delta)) << 16)
+	// High-order word is signed delta, low-order word is the key state
+	wparam := uintptr(keys) | (uintptr(int16(delta)) << 16)
 	lparam := makeLParam(sx, sy)
 
-	return post(hwnd, WM_MOUSEWHEEL, wparam, lparam)
+	return post(hwnd, msg, wparam, lparam)
 }
@@ -0,0 +1,145 @@
+// Code generated by internal/stubgen; DO NOT EDIT.
+
+//go:build !windows
+
+package mouse
+
+import (
+	"errors"
+	"github.com/rpdg/winput/window"
+)
+
+const (
+	WM_MOUSEMOVE     = 0x0200
+	WM_LBUTTONDOWN   = 0x0201
+	WM_LBUTTONUP     = 0x0202
+	WM_LBUTTONDBLCLK = 0x0203
+	WM_RBUTTONDOWN   = 0x0204
+	WM_RBUTTONUP     = 0x0205
+	WM_RBUTTONDBLCLK = 0x0206
+	WM_MBUTTONDOWN   = 0x0207
+	WM_MBUTTONUP     = 0x0208
+	WM_MBUTTONDBLCLK = 0x0209
+	WM_MOUSEWHEEL    = 0x020A
+	WM_XBUTTONDOWN   = 0x020B
+	WM_XBUTTONUP     = 0x020C
+	WM_XBUTTONDBLCLK = 0x020D
+	WM_MOUSEHWHEEL   = 0x020E
+
+	MK_LBUTTON  = 0x0001
+	MK_RBUTTON  = 0x0002
+	MK_SHIFT    = 0x0004
+	MK_CONTROL  = 0x0008
+	MK_MBUTTON  = 0x0010
+	MK_XBUTTON1 = 0x0020
+	MK_XBUTTON2 = 0x0040
+
+	XBUTTON1 = 0x0001
+	XBUTTON2 = 0x0002
+
+	WHEEL_DELTA = 120
+)
+
+var ErrInvalidScrollDelta = errors.New("scroll delta must be a multiple of WHEEL_DELTA (120)")
+
+// Button identifies a mouse button.
+type Button int
+
+const (
+	Left Button = iota
+	Right
+	Middle
+	X1
+	X2
+)
+
+// Move simulates a mouse move event to the specified client coordinates using PostMessage.
+func Move(hwnd uintptr, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Down posts a press of button b at the specified client coordinates.
+func Down(hwnd uintptr, b Button, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Up posts a release of button b at the specified client coordinates.
+func Up(hwnd uintptr, b Button, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ClickButton posts a press and release of button b at the specified client coordinates.
+func ClickButton(hwnd uintptr, b Button, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ClickWithKeys posts a press and release of button b at the specified client coordinates
+// with keys (MK_CONTROL, MK_SHIFT) reported in WPARAM of both messages, e.g. for Ctrl+Click.
+// It does not press the keys themselves.
+func ClickWithKeys(hwnd uintptr, b Button, x, y int32, keys uint16) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Click simulates a left mouse button click at the specified client coordinates.
+func Click(hwnd uintptr, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ClickRight simulates a right mouse button click at the specified client coordinates.
+func ClickRight(hwnd uintptr, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ClickMiddle simulates a middle mouse button click at the specified client coordinates.
+func ClickMiddle(hwnd uintptr, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// DoubleClick simulates a left mouse button double-click at the specified client coordinates.
+func DoubleClick(hwnd uintptr, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// MultiClick posts count presses and releases of button b at the specified client coordinates,
+// as the system reports them to a window with CS_DBLCLKS: every second press is the button's
+// double-click message (WM_LBUTTONDBLCLK etc.), so 2 is a double click and 3 a triple click.
+func MultiClick(hwnd uintptr, b Button, x, y int32, count int) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// DoubleClickRight simulates a right mouse button double-click at the specified client coordinates.
+func DoubleClickRight(hwnd uintptr, x, y int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Drag posts a press of button b at client point (fromX, fromY), steps WM_MOUSEMOVE
+// messages with the button held along the straight line to (toX, toY), and the release there.
+// steps <= 0 means a single move.
+func Drag(hwnd uintptr, b Button, fromX, fromY, toX, toY int32, steps int) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// Scroll simulates a vertical mouse wheel scroll at the specified coordinates.
+// delta must be a multiple of WHEEL_DELTA (120).
+func Scroll(hwnd uintptr, x, y int32, delta int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ScrollScreen posts a vertical wheel message to hwnd for the given screen coordinates.
+// WM_MOUSEWHEEL carries screen, not client, coordinates in LPARAM.
+// keys is the MK_* state reported in the low word of WPARAM (e.g. MK_CONTROL for zoom).
+func ScrollScreen(hwnd uintptr, sx, sy int32, delta int32, keys uint16) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ScrollH simulates a horizontal mouse wheel scroll at the specified coordinates.
+// delta must be a multiple of WHEEL_DELTA (120); positive scrolls right.
+func ScrollH(hwnd uintptr, x, y int32, delta int32) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// ScrollHScreen posts a horizontal wheel message (WM_MOUSEHWHEEL) to hwnd for the given
+// screen coordinates, laid out like ScrollScreen.
+func ScrollHScreen(hwnd uintptr, sx, sy int32, delta int32, keys uint16) error {
+	return window.ErrUnsupportedPlatform
+}
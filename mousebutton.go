@@ -0,0 +1,366 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rpdg/winput/hid"
+	"github.com/rpdg/winput/mouse"
+	"github.com/rpdg/winput/window"
+)
+
+// MouseButton identifies a mouse button.
+type MouseButton int
+
+const (
+	MouseLeft MouseButton = iota
+	MouseRight
+	MouseMiddle
+	MouseX1 // the first extra button, usually "back"
+	MouseX2 // the second extra button, usually "forward"
+)
+
+// String returns a readable name for the button.
+func (b MouseButton) String() string {
+	switch b {
+	case MouseLeft:
+		return "left"
+	case MouseRight:
+		return "right"
+	case MouseMiddle:
+		return "middle"
+	case MouseX1:
+		return "x1"
+	case MouseX2:
+		return "x2"
+	default:
+		return fmt.Sprintf("MouseButton(%d)", int(b))
+	}
+}
+
+// check returns an error for a value that is not one of the MouseButton constants.
+func (b MouseButton) check() error {
+	if b < MouseLeft || b > MouseX2 {
+		return fmt.Errorf("invalid mouse button %v", b)
+	}
+	return nil
+}
+
+func (b MouseButton) message() mouse.Button {
+	return mouse.Button(b - MouseLeft)
+}
+
+func (b MouseButton) hid() hid.Button {
+	return hid.Button(b - MouseLeft)
+}
+
+// xButton returns the extra button numbered which (1 or 2).
+func xButton(which int) (MouseButton, error) {
+	switch which {
+	case 1:
+		return MouseX1, nil
+	case 2:
+		return MouseX2, nil
+	default:
+		return 0, fmt.Errorf("invalid extra mouse button %d, want 1 or 2", which)
+	}
+}
+
+// events returns the button's mouse_event down and up flags and the dwData it needs.
+func (b MouseButton) events() (down, up, data uintptr) {
+	switch b {
+	case MouseRight:
+		return 0x0008, 0x0010, 0 // MOUSEEVENTF_RIGHTDOWN, RIGHTUP
+	case MouseMiddle:
+		return 0x0020, 0x0040, 0 // MOUSEEVENTF_MIDDLEDOWN, MIDDLEUP
+	case MouseX1:
+		return 0x0080, 0x0100, 1 // MOUSEEVENTF_XDOWN, XUP; XBUTTON1
+	case MouseX2:
+		return 0x0080, 0x0100, 2 // XBUTTON2
+	default:
+		return mouseEventLeftDown, mouseEventLeftUp, 0
+	}
+}
+
+// MouseDown presses button at client point (x, y) and leaves it held until MouseUp, for
+// gestures Click and Drag do not cover (hold-to-repeat buttons, drags that decide their path
+// on the way). The Message backend posts the button-down message; the HID backend moves the
+// cursor there and presses the physical button.
+func (w *Window) MouseDown(button MouseButton, x, y int32) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.mouseButton(button, true, x, y)
+}
+
+// MouseUp releases button at client point (x, y).
+func (w *Window) MouseUp(button MouseButton, x, y int32) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.mouseButton(button, false, x, y)
+}
+
+func (w *Window) mouseButton(b MouseButton, down bool, x, y int32) error {
+	if err := b.check(); err != nil {
+		return err
+	}
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	if down {
+		w.recordAction("MouseDown", x, y)
+	} else {
+		w.recordAction("MouseUp", x, y)
+	}
+
+	if getBackend() == BackendHID {
+		sx, sy, err := clientToScreen(w.HWND, x, y)
+		if err != nil {
+			return err
+		}
+		if down {
+			return hid.Down(sx, sy, b.hid())
+		}
+		return hid.Up(sx, sy, b.hid())
+	}
+	if down {
+		return mouse.Down(w.HWND, b.message(), x, y)
+	}
+	return mouse.Up(w.HWND, b.message(), x, y)
+}
+
+// DoubleClickRight simulates a right mouse button double-click at the specified client
+// coordinates, for applications (CAD, maps) that bind actions to it. Timing follows DoubleClick.
+func (w *Window) DoubleClickRight(x, y int32) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.multiClick("DoubleClickRight", MouseRight, x, y, 2)
+}
+
+// MultiClick clicks button count times in quick succession at the specified client
+// coordinates, e.g. 3 for triple-click line selection in editors. The Message backend posts
+// the button's double-click message for every second press, as the system does; the HID
+// backend times the clicks like DoubleClick.
+func (w *Window) MultiClick(button MouseButton, x, y int32, count int) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.multiClick("MultiClick", button, x, y, count)
+}
+
+func (w *Window) multiClick(action string, b MouseButton, x, y int32, count int) error {
+	if err := b.check(); err != nil {
+		return err
+	}
+	if count < 1 {
+		return fmt.Errorf("invalid click count %d", count)
+	}
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	w.recordAction(action, x, y)
+
+	if getBackend() == BackendHID {
+		sx, sy, err := clientToScreen(w.HWND, x, y)
+		if err != nil {
+			return err
+		}
+		return hid.MultiClickWithTiming(sx, sy, b.hid(), count, w.timingFor(nil).doubleClick())
+	}
+	return mouse.MultiClick(w.HWND, b.message(), x, y, count)
+}
+
+// ClickX clicks extra mouse button which (1 for X1, usually "back"; 2 for X2, usually
+// "forward") at the specified client coordinates. The Message backend posts
+// WM_XBUTTONDOWN/UP with XBUTTON1 or XBUTTON2 in the high word of WPARAM; the HID backend
+// sends the driver's button 4 or 5 strokes.
+func (w *Window) ClickX(x, y int32, which int) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.clickX(x, y, which)
+}
+
+func (w *Window) clickX(x, y int32, which int) error {
+	b, err := xButton(which)
+	if err != nil {
+		return err
+	}
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	w.recordAction("ClickX", x, y)
+
+	if getBackend() == BackendHID {
+		sx, sy, err := clientToScreen(w.HWND, x, y)
+		if err != nil {
+			return err
+		}
+		return hid.ClickButton(sx, sy, b.hid())
+	}
+	return mouse.ClickButton(w.HWND, b.message(), x, y)
+}
+
+// ClickXMouseAt moves to the specified screen coordinates and clicks extra mouse button
+// which (1 or 2, see Window.ClickX).
+func ClickXMouseAt(x, y int32, which int) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return clickXMouseAt(x, y, which)
+}
+
+func clickXMouseAt(x, y int32, which int) error {
+	b, err := xButton(which)
+	if err != nil {
+		return err
+	}
+	return clickButtonAt(x, y, b)
+}
+
+// ClickWithModifiers left-clicks the client point (x, y) with modifier keys held, e.g.
+// KeyCtrl to add a list item to the selection or KeyShift to extend it. mods may contain
+// KeyCtrl, KeyShift and KeyAlt.
+//
+// The Message backend posts WM_KEYDOWN for each modifier, the button messages with
+// MK_CONTROL / MK_SHIFT set in WPARAM, and WM_KEYUP in reverse order; the HID backend holds
+// the physical keys across the click. The modifiers are released even if the click fails.
+func (w *Window) ClickWithModifiers(x, y int32, mods ...Key) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.clickWithModifiers(x, y, mods)
+}
+
+func (w *Window) clickWithModifiers(x, y int32, mods []Key) (err error) {
+	var keys uint16
+	for _, k := range mods {
+		switch k {
+		case KeyCtrl:
+			keys |= mouse.MK_CONTROL
+		case KeyShift:
+			keys |= mouse.MK_SHIFT
+		case KeyAlt:
+		default:
+			return fmt.Errorf("%w: key 0x%X is not a modifier", ErrUnsupportedKey, uint16(k))
+		}
+	}
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	w.recordAction("ClickWithModifiers", x, y)
+	cb := getBackend()
+
+	var held []Key
+	defer func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			if upErr := keyUpImpl(cb, w.HWND, held[i]); err == nil {
+				err = upErr
+			}
+		}
+	}()
+	for _, k := range mods {
+		if err := keyDownImpl(cb, w.HWND, k); err != nil {
+			return err
+		}
+		held = append(held, k)
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if cb == BackendHID {
+		sx, sy, err := clientToScreen(w.HWND, x, y)
+		if err != nil {
+			return err
+		}
+		return hid.Click(sx, sy)
+	}
+	return mouse.ClickWithKeys(w.HWND, mouse.Left, x, y, keys)
+}
+
+// MouseDownAt moves to the screen point (x, y) and presses button there, leaving it held
+// until MouseUpAt, e.g. to hold the left button on a pixel for two seconds. The Message
+// backend uses SetCursorPos and mouse_event; the HID backend moves and sends only the down
+// stroke. The point is subject to the work area guard (see SetWorkAreaGuard).
+func MouseDownAt(x, y int32, button MouseButton) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return mouseButtonAt(x, y, button, true)
+}
+
+// MouseUpAt moves to the screen point (x, y) and releases button there.
+func MouseUpAt(x, y int32, button MouseButton) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return mouseButtonAt(x, y, button, false)
+}
+
+func mouseButtonAt(x, y int32, b MouseButton, down bool) error {
+	if err := b.check(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	x, y, guard := guardWorkArea(x, y)
+
+	if getBackend() == BackendHID {
+		var err error
+		if down {
+			err = hid.Down(x, y, b.hid())
+		} else {
+			err = hid.Up(x, y, b.hid())
+		}
+		if err != nil {
+			return err
+		}
+		return guard
+	}
+
+	if err := window.SetCursorPos(x, y); err != nil {
+		return err
+	}
+	time.Sleep(30 * time.Millisecond)
+	downFlag, upFlag, data := b.events()
+	flag := upFlag
+	if down {
+		flag = downFlag
+	}
+	window.ProcMouseEvent.Call(flag, 0, 0, data, 0)
+	return guard
+}
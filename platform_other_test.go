@@ -0,0 +1,27 @@
+//go:build !windows
+
+package winput_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/rpdg/winput"
+	"github.com/rpdg/winput/screen"
+)
+
+func TestUnsupportedPlatform(t *testing.T) {
+	if _, err := winput.FindByTitle("Untitled - Notepad"); !errors.Is(err, winput.ErrUnsupportedPlatform) {
+		t.Errorf("FindByTitle: got %v", err)
+	}
+	w := &winput.Window{HWND: 1}
+	if err := w.Click(1, 1); !errors.Is(err, winput.ErrUnsupportedPlatform) {
+		t.Errorf("Click: got %v", err)
+	}
+	if err := winput.Type("x"); !errors.Is(err, winput.ErrUnsupportedPlatform) {
+		t.Errorf("Type: got %v", err)
+	}
+	if _, err := screen.CaptureVirtualDesktop(); !errors.Is(err, winput.ErrUnsupportedPlatform) {
+		t.Errorf("CaptureVirtualDesktop: got %v", err)
+	}
+}
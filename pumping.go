@@ -0,0 +1,105 @@
+//go:build windows
+
+package winput
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+const (
+	// pumpTTL is how long a liveness result is trusted before the window is probed again.
+	pumpTTL = 5 * time.Second
+	// pumpTimeout bounds a single WM_NULL probe.
+	pumpTimeout = 500
+)
+
+// pumpEntry is a cached IsPumping result. tid guards against a recycled HWND: a handle
+// reused by another window almost always belongs to a different thread.
+type pumpEntry struct {
+	pumping bool
+	tid     uint32
+	at      time.Time
+}
+
+var (
+	strictMode       atomic.Bool
+	strictReadyCheck atomic.Bool
+	pumpCache        sync.Map // HWND -> pumpEntry
+)
+
+// SetStrictMode enables or disables strict mode. In strict mode every window-targeted input
+// call first verifies that the window's thread is actually processing messages (a WM_NULL
+// round-trip via SendMessageTimeout, cached per window for a few seconds) and returns
+// ErrTargetNotPumping otherwise, instead of posting input into a queue nobody reads.
+func SetStrictMode(enabled bool) {
+	strictMode.Store(enabled)
+}
+
+// SetStrictReadyCheck makes every window-targeted input call fail fast with ErrWindowHung
+// when Windows considers the target hung (IsResponding is false). The check is free, unlike
+// strict mode's WM_NULL round-trip, but only catches a window after 5 seconds of not
+// responding, the point at which Windows shows it as "(Not Responding)".
+func SetStrictReadyCheck(enabled bool) {
+	strictReadyCheck.Store(enabled)
+}
+
+// IsResponding reports whether the window is responding by the system's definition
+// (IsHungAppWindow on its top-level window): false once its thread has not retrieved
+// messages for 5 seconds. Use IsPumping for a prompt answer at the cost of a round-trip.
+func (w *Window) IsResponding() bool {
+	return w.IsValid() && !window.IsHungAppWindow(rootWindow(w.HWND))
+}
+
+// IsPumping reports whether the window's thread processed a WM_NULL within 500ms.
+// The result is cached per window for a few seconds.
+func (w *Window) IsPumping() (bool, error) {
+	tid, _ := window.GetThreadProcessID(w.HWND)
+	if tid == 0 {
+		pumpCache.Delete(w.HWND)
+		return false, ErrWindowGone
+	}
+	if v, ok := pumpCache.Load(w.HWND); ok {
+		e := v.(pumpEntry)
+		if e.tid == tid && time.Since(e.at) < pumpTTL {
+			return e.pumping, nil
+		}
+	}
+	pumping := window.Ping(w.HWND, pumpTimeout) == nil
+	now := time.Now()
+	evictPumpCache(now)
+	pumpCache.Store(w.HWND, pumpEntry{pumping: pumping, tid: tid, at: now})
+	return pumping, nil
+}
+
+// evictPumpCache drops expired entries so that the cache does not grow with every window
+// ever probed.
+func evictPumpCache(now time.Time) {
+	pumpCache.Range(func(k, v any) bool {
+		if now.Sub(v.(pumpEntry).at) >= pumpTTL {
+			pumpCache.Delete(k)
+		}
+		return true
+	})
+}
+
+// checkPumping enforces strict mode and the strict ready check for w.
+func (w *Window) checkPumping() error {
+	if strictReadyCheck.Load() && !w.IsResponding() {
+		return ErrWindowHung
+	}
+	if !strictMode.Load() {
+		return nil
+	}
+	pumping, err := w.IsPumping()
+	if err != nil {
+		return err
+	}
+	if !pumping {
+		return ErrTargetNotPumping
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+//go:build windows
+
+package winput
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// readyWait is a per-window SetReadyWait policy.
+type readyWait struct {
+	timeout  time.Duration
+	interval time.Duration
+}
+
+// ReadyRetry describes one failed readiness check while waiting under SetReadyWait.
+type ReadyRetry struct {
+	HWND    uintptr
+	Attempt int           // 1 for the first failed check
+	Elapsed time.Duration // since the wait started
+	Err     error         // ErrWindowGone, ErrWindowHidden, ErrWindowMinimized or a pumping error
+}
+
+var readyRetryHook atomic.Pointer[func(ReadyRetry)]
+
+// SetReadyRetryHook installs fn to receive a ReadyRetry each time a window with a
+// SetReadyWait policy is found not ready and the call waits for it.
+// fn is called without the input lock held. Pass nil to remove it.
+func SetReadyRetryHook(fn func(ReadyRetry)) {
+	if fn == nil {
+		readyRetryHook.Store(nil)
+		return
+	}
+	readyRetryHook.Store(&fn)
+}
+
+// SetReadyWait makes input methods on this window wait up to timeout, polling every
+// interval, for the window to become valid and visible (e.g. while it is briefly minimized
+// or animating) instead of failing immediately with ErrWindowGone or ErrWindowNotVisible.
+// The wait happens before the global input lock is taken, so automation of other windows
+// keeps flowing meanwhile. Calls made through a Session do not wait, as the session
+// already holds exclusive access.
+// A timeout <= 0 restores the default of failing immediately; interval <= 0 means 50ms.
+func (w *Window) SetReadyWait(timeout, interval time.Duration) {
+	updateSettings(w.HWND, func(s *windowSettings) {
+		if timeout <= 0 {
+			s.readyWait = nil
+			return
+		}
+		if interval <= 0 {
+			interval = 50 * time.Millisecond
+		}
+		s.readyWait = &readyWait{timeout: timeout, interval: interval}
+	})
+}
+
+// lockReady acquires the input lock for an operation on w. If w has a SetReadyWait policy
+// and is not ready, it first waits for it without holding the lock. The operation's own
+// checkReady still reports the final state.
+func (w *Window) lockReady() (func(), error) {
+	if rw := settingsFor(w.HWND).readyWait; rw != nil {
+		w.waitReady(*rw)
+	}
+	return lockInput()
+}
+
+func (w *Window) waitReady(rw readyWait) {
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		err := w.checkReady()
+		elapsed := time.Since(start)
+		if err == nil || elapsed >= rw.timeout {
+			return
+		}
+		if fn := readyRetryHook.Load(); fn != nil {
+			(*fn)(ReadyRetry{HWND: w.HWND, Attempt: attempt, Elapsed: elapsed, Err: err})
+		}
+		time.Sleep(min(rw.interval, rw.timeout-elapsed))
+	}
+}
@@ -0,0 +1,277 @@
+//go:build windows
+
+package winput
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// ResolveStep is one find operation of a Resolve chain. It receives the previous step's
+// window (nil for the first step) and returns the next one.
+type ResolveStep struct {
+	// Name describes the step in errors, e.g. `ChildByClass("Edit")`.
+	Name    string
+	find    func(prev *Window) (*Window, error)
+	chained bool // needs the previous step's window
+	retry   *RetryPolicy
+}
+
+// RetryPolicy controls how a failing step is retried: the wait starts at Initial and is
+// multiplied by Multiplier after each attempt, up to Max.
+type RetryPolicy struct {
+	Initial    time.Duration // default 50ms
+	Max        time.Duration // default 1s
+	Multiplier float64       // default 2
+	// Timeout bounds the attempts of a single step. When it expires, Resolve starts the
+	// chain over from the first step, as the earlier result may have been the wrong window
+	// (e.g. a splash screen). 0 retries the step until the context is done.
+	Timeout time.Duration
+}
+
+// DefaultRetry is the policy of steps without WithRetry.
+var DefaultRetry = RetryPolicy{Initial: 50 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+// WithRetry returns the step with its own retry policy.
+func (s ResolveStep) WithRetry(p RetryPolicy) ResolveStep {
+	s.retry = &p
+	return s
+}
+
+// ResolveFunc makes a custom step from fn. fn receives the previous step's window, nil
+// for the first step; returning an error retries it.
+func ResolveFunc(name string, fn func(prev *Window) (*Window, error)) ResolveStep {
+	return ResolveStep{Name: name, find: fn}
+}
+
+// chained marks a step that works on the previous step's window.
+func chained(s ResolveStep) ResolveStep {
+	s.chained = true
+	return s
+}
+
+// ByProcessName finds the first top-level window of the process, as ordered by
+// FindByProcessName.
+func ByProcessName(name string) ResolveStep {
+	return ResolveFunc(fmt.Sprintf("ByProcessName(%q)", name), func(*Window) (*Window, error) {
+		windows, err := FindByProcessName(name)
+		if err != nil {
+			return nil, err
+		}
+		return windows[0], nil
+	})
+}
+
+// MainWindow finds the main window (see MainWindowOfPID) of the previous window's process.
+func MainWindow() ResolveStep {
+	return chained(ResolveFunc("MainWindow()", func(prev *Window) (*Window, error) {
+		pid, err := prev.ProcessID()
+		if err != nil {
+			return nil, err
+		}
+		return MainWindowOfPID(pid)
+	}))
+}
+
+// ChildByClass finds a direct child of the previous window by class name.
+func ChildByClass(class string) ResolveStep {
+	return chained(ResolveFunc(fmt.Sprintf("ChildByClass(%q)", class), func(prev *Window) (*Window, error) {
+		hwnd, err := window.FindChildByClass(prev.HWND, class)
+		if err != nil {
+			return nil, ErrWindowNotFound
+		}
+		return &Window{HWND: hwnd}, nil
+	}))
+}
+
+// ChildByID finds a direct child of the previous window by control ID (GetDlgItem).
+func ChildByID(id int) ResolveStep {
+	return chained(ResolveFunc(fmt.Sprintf("ChildByID(%d)", id), func(prev *Window) (*Window, error) {
+		hwnd, err := window.FindChildByID(prev.HWND, id)
+		if err != nil {
+			return nil, ErrWindowNotFound
+		}
+		return &Window{HWND: hwnd}, nil
+	}))
+}
+
+// InputTarget finds the control of the previous window that text should be typed into:
+// the descendant with keyboard focus if there is one, otherwise the first Edit or RichEdit
+// descendant.
+func InputTarget() ResolveStep {
+	return chained(ResolveFunc("InputTarget()", func(prev *Window) (*Window, error) {
+		if !prev.IsValid() {
+			return nil, ErrWindowGone
+		}
+		if tid, _ := window.GetThreadProcessID(prev.HWND); tid != 0 {
+			if f := window.ThreadFocus(tid); f != 0 && f != prev.HWND && isDescendant(f, prev.HWND) {
+				return &Window{HWND: f}, nil
+			}
+		}
+		for _, h := range window.EnumDescendants(prev.HWND) {
+			if window.IsEditControl(h) {
+				return &Window{HWND: h}, nil
+			}
+		}
+		return nil, ErrWindowNotFound
+	}))
+}
+
+// isDescendant reports whether h is a child, grandchild, ... of ancestor.
+func isDescendant(h, ancestor uintptr) bool {
+	for p := window.GetAncestor(h, window.GA_PARENT); p != 0; p = window.GetAncestor(p, window.GA_PARENT) {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveError reports the step a Resolve chain was stuck on when the context ended.
+// It matches ErrTimeout (or context.Canceled) and the step's last error with errors.Is.
+type ResolveError struct {
+	Step     int    // index of the failing step
+	Name     string // its ResolveStep.Name
+	Attempts int    // attempts of that step in the final pass of the chain
+	Err      error  // the step's last error
+	Reason   error  // ErrTimeout, or the context's error if it was canceled
+}
+
+func (e *ResolveError) Error() string {
+	return fmt.Sprintf("resolve step %d %s: %v after %d attempts: %v", e.Step, e.Name, e.Reason, e.Attempts, e.Err)
+}
+
+func (e *ResolveError) Unwrap() []error {
+	return []error{e.Reason, e.Err}
+}
+
+// Resolve runs a discovery chain such as
+//
+//	Resolve(ctx, ByProcessName("app.exe"), MainWindow(), ChildByClass("Edit"))
+//
+// retrying each step with backoff while the target is still starting up, and returns the
+// last step's window. A step that keeps failing past its RetryPolicy.Timeout restarts the
+// chain from the beginning. When ctx ends, the error is a *ResolveError naming the step and
+// its last error. The returned window remembers the chain for Refind.
+func Resolve(ctx context.Context, steps ...ResolveStep) (*Window, error) {
+	if len(steps) == 0 {
+		return nil, errors.New("winput: Resolve needs at least one step")
+	}
+	if steps[0].chained {
+		return nil, fmt.Errorf("winput: Resolve step %s cannot come first", steps[0].Name)
+	}
+	restart := newBackoff(DefaultRetry)
+	for {
+		w, err := resolveOnce(ctx, steps)
+		if err == nil {
+			chain := append([]ResolveStep(nil), steps...)
+			w.resolvedBy = &chain
+			return w, nil
+		}
+		var re *ResolveError
+		if !errors.As(err, &re) || re.Reason != nil {
+			return nil, err
+		}
+		if !restart.wait(ctx) {
+			re.Reason = ctxReason(ctx)
+			return nil, re
+		}
+	}
+}
+
+// resolveOnce runs the chain once. It returns a *ResolveError with a nil Reason if a
+// step timed out and the chain should restart, or with Reason set once ctx has ended.
+func resolveOnce(ctx context.Context, steps []ResolveStep) (*Window, error) {
+	var prev *Window
+	for i, s := range steps {
+		policy := DefaultRetry
+		if s.retry != nil {
+			policy = *s.retry
+		}
+		b := newBackoff(policy)
+		var deadline time.Time
+		if policy.Timeout > 0 {
+			deadline = time.Now().Add(policy.Timeout)
+		}
+		re := &ResolveError{Step: i, Name: s.Name}
+		for {
+			re.Attempts++
+			w, err := s.find(prev)
+			if err == nil {
+				if w == nil {
+					return nil, fmt.Errorf("winput: Resolve step %d %s returned no window", i, s.Name)
+				}
+				prev = w
+				break
+			}
+			re.Err = err
+			if !deadline.IsZero() && time.Now().After(deadline) {
+				return nil, re
+			}
+			if !b.wait(ctx) {
+				re.Reason = ctxReason(ctx)
+				return nil, re
+			}
+		}
+	}
+	return prev, nil
+}
+
+func ctxReason(ctx context.Context) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	return ctx.Err()
+}
+
+type backoff struct {
+	next time.Duration
+	p    RetryPolicy
+}
+
+func newBackoff(p RetryPolicy) *backoff {
+	if p.Initial <= 0 {
+		p.Initial = DefaultRetry.Initial
+	}
+	if p.Max <= 0 {
+		p.Max = DefaultRetry.Max
+	}
+	if p.Multiplier < 1 {
+		p.Multiplier = DefaultRetry.Multiplier
+	}
+	return &backoff{next: p.Initial, p: p}
+}
+
+// wait sleeps for the next backoff interval and reports false if ctx ended first.
+func (b *backoff) wait(ctx context.Context) bool {
+	t := time.NewTimer(b.next)
+	defer t.Stop()
+	b.next = min(time.Duration(float64(b.next)*b.p.Multiplier), b.p.Max)
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Refind replays the Resolve chain that produced w and points w at the window it finds
+// now, e.g. after the application restarted and the old handle went stale. Settings
+// attached to the old handle (SetTiming, RegisterRune, ...) do not carry over. It must
+// not run concurrently with other use of w. It returns ErrNotResolved if w did not come
+// from Resolve.
+func (w *Window) Refind(ctx context.Context) error {
+	if w.resolvedBy == nil {
+		return ErrNotResolved
+	}
+	nw, err := Resolve(ctx, *w.resolvedBy...)
+	if err != nil {
+		return err
+	}
+	w.HWND = nw.HWND
+	return nil
+}
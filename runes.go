@@ -0,0 +1,76 @@
+//go:build windows
+
+package winput
+
+import (
+	"strings"
+
+	"github.com/rpdg/winput/keyboard"
+)
+
+// RegisterRune maps r to a key for every later Type, KeyFromRune and ValidateTypeable
+// call, adding a character of the local layout (e.g. '§' on a German keyboard) or
+// replacing a built-in mapping. It is safe to call while other goroutines type.
+// Characters that need AltGr cannot be expressed as a KeyDef.
+func RegisterRune(r rune, def KeyDef) {
+	keyboard.RegisterRune(r, def)
+}
+
+// RegisterRunes is RegisterRune for several runes at once.
+func RegisterRunes(defs map[rune]KeyDef) {
+	keyboard.RegisterRunes(defs)
+}
+
+// RegisterRune maps r to a key for text typed into this window, ahead of the global
+// mappings. It applies to every *Window value with the same handle.
+//
+// Under the Message backend, runes registered here are posted as key strokes even when the
+// rest of the text goes out as WM_CHAR, e.g. '\n' as KeyEnter for a target that ignores a
+// newline character.
+func (w *Window) RegisterRune(r rune, def KeyDef) {
+	w.RegisterRunes(map[rune]KeyDef{r: def})
+}
+
+// RegisterRunes is RegisterRune for several runes at once.
+func (w *Window) RegisterRunes(defs map[rune]KeyDef) {
+	updateSettings(w.HWND, func(s *windowSettings) {
+		runes := make(keyboard.RuneMap, len(s.runes)+len(defs))
+		for r, def := range s.runes {
+			runes[r] = def
+		}
+		for r, def := range defs {
+			runes[r] = def
+		}
+		s.runes = runes
+	})
+}
+
+// ClearRunes removes the window's rune mappings so only the global ones apply.
+func (w *Window) ClearRunes() {
+	updateSettings(w.HWND, func(s *windowSettings) { s.runes = nil })
+}
+
+// ValidateTypeable is the package-level ValidateTypeable, also accepting the runes
+// registered on the window.
+func (w *Window) ValidateTypeable(text string) []RuneIssue {
+	return validateTypeable(text, settingsFor(w.HWND).runes)
+}
+
+// hasRune reports whether text contains any rune in keys.
+func hasRune(text string, keys keyboard.RuneMap) bool {
+	if len(keys) == 0 {
+		return false
+	}
+	return strings.IndexFunc(text, func(r rune) bool {
+		_, ok := keys[r]
+		return ok
+	}) >= 0
+}
+
+// pressKeyDef types def globally, holding Shift if needed.
+func pressKeyDef(def KeyDef) error {
+	if def.Shifted {
+		return pressHotkey(KeyShift, def.Code)
+	}
+	return press(def.Code)
+}
@@ -0,0 +1,50 @@
+//go:build windows
+
+package screen
+
+import (
+	"unsafe"
+
+	"github.com/rpdg/winput/window"
+)
+
+const abmGetAutoHideBarEx = 11 // Windows 8+; earlier versions report no bar
+
+// appBarData is APPBARDATA.
+type appBarData struct {
+	Size            uint32
+	HWnd            uintptr
+	CallbackMessage uint32
+	Edge            uint32
+	Rc              Rect
+	LParam          uintptr
+}
+
+// excludeAutoHideBars shrinks the monitor bounds by the auto-hide app bars (usually the
+// taskbar) docked to its edges, using each bar's window size as its shown thickness.
+func excludeAutoHideBars(bounds Rect) Rect {
+	work := bounds
+	for edge := uint32(0); edge < 4; edge++ { // ABE_LEFT, ABE_TOP, ABE_RIGHT, ABE_BOTTOM
+		abd := appBarData{Edge: edge, Rc: bounds}
+		abd.Size = uint32(unsafe.Sizeof(abd))
+		bar, _, _ := window.ProcSHAppBarMessage.Call(abmGetAutoHideBarEx, uintptr(unsafe.Pointer(&abd)))
+		if bar == 0 {
+			continue
+		}
+		rc, err := window.GetWindowRect(bar)
+		if err != nil {
+			continue
+		}
+		switch edge {
+		case 0:
+			work.Left += min(rc.Right-rc.Left, (bounds.Right-bounds.Left)/2)
+		case 1:
+			work.Top += min(rc.Bottom-rc.Top, (bounds.Bottom-bounds.Top)/2)
+		case 2:
+			work.Right -= min(rc.Right-rc.Left, (bounds.Right-bounds.Left)/2)
+		case 3:
+			work.Bottom -= min(rc.Bottom-rc.Top, (bounds.Bottom-bounds.Top)/2)
+		}
+	}
+	return work
+}
@@ -1,10 +1,12 @@
+//go:build windows
+
 package screen
 
 import (
+	"errors"
 	"fmt"
 	"image"
-	"runtime"
-	"sync"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/rpdg/winput/window"
@@ -21,6 +23,7 @@ const (
 	SM_YVIRTUALSCREEN  = 77
 	SM_CXVIRTUALSCREEN = 78
 	SM_CYVIRTUALSCREEN = 79
+	SM_CMONITORS       = 80
 )
 
 type BITMAPINFOHEADER struct {
@@ -48,9 +51,36 @@ var defaultOptions = CaptureOptions{
 	MaxMemoryMB:   500,
 }
 
+// ErrInsufficientDPIAwareness is returned by captures when the process DPI awareness does
+// not satisfy the DPIPolicy, so GDI could hand back bitmap-stretched pixels.
+var ErrInsufficientDPIAwareness = errors.New("process DPI awareness is insufficient for capture")
+
+// DPIPolicy decides which process DPI awareness levels captures accept.
+type DPIPolicy int32
+
+const (
+	// DPIPolicyDefault accepts Per-Monitor awareness (V1 or V2), and System awareness on
+	// single-monitor systems, where the only monitor is the one the system DPI was computed
+	// for (e.g. Windows 7/8 where Per-Monitor does not exist, or a host application that
+	// already chose System awareness).
+	DPIPolicyDefault DPIPolicy = iota
+	// DPIPolicyStrict accepts Per-Monitor awareness only.
+	DPIPolicyStrict
+	// DPIPolicyAny accepts every level. On monitors whose scaling differs from what the
+	// process was told, the image is then stretched and its coordinates are logical, not physical.
+	DPIPolicyAny
+)
+
+var dpiPolicy atomic.Int32
+
+// SetDPIPolicy sets the DPI awareness policy for captures. The default is DPIPolicyDefault.
+func SetDPIPolicy(p DPIPolicy) {
+	dpiPolicy.Store(int32(p))
+}
+
 // CaptureVirtualDesktop captures the entire virtual desktop (all monitors).
 // It returns an *image.RGBA ready for OpenCV or other processing.
-// It requires the process to be Per-Monitor DPI Aware.
+// It requires the process DPI awareness to satisfy the DPIPolicy (Per-Monitor DPI Aware by default).
 func CaptureVirtualDesktop() (*image.RGBA, error) {
 	return CaptureVirtualDesktopWithOptions(defaultOptions)
 }
@@ -58,8 +88,8 @@ func CaptureVirtualDesktop() (*image.RGBA, error) {
 // CaptureVirtualDesktopWithOptions captures the virtual desktop with custom options.
 func CaptureVirtualDesktopWithOptions(opts CaptureOptions) (*image.RGBA, error) {
 	// 1. DPI Awareness Check
-	if !window.IsPerMonitorDPIAware() {
-		return nil, fmt.Errorf("process is not Per-Monitor DPI Aware; call winput.EnablePerMonitorDPI() first")
+	if err := checkCaptureDPI(); err != nil {
+		return nil, err
 	}
 
 	// 2. Get Virtual Desktop Bounds
@@ -154,96 +184,81 @@ func CaptureVirtualDesktopWithOptions(opts CaptureOptions) (*image.RGBA, error)
 	return img, err
 }
 
+// checkCaptureDPI reports whether GDI will hand back unscaled pixels under the current DPIPolicy.
+func checkCaptureDPI() error {
+	policy := DPIPolicy(dpiPolicy.Load())
+	if policy == DPIPolicyAny {
+		return nil
+	}
+	level, _ := window.DPIAwareness()
+	if level >= window.DPIPerMonitor {
+		return nil
+	}
+	if policy == DPIPolicyDefault && level == window.DPISystemAware {
+		n, _, _ := window.ProcGetSystemMetrics.Call(SM_CMONITORS)
+		if n == 1 {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: level is %s; call winput.EnablePerMonitorDPI() at startup or relax screen.SetDPIPolicy",
+		ErrInsufficientDPIAwareness, level)
+}
+
 func convertToRGBA(ppvBits unsafe.Pointer, width, height int, preserveAlpha bool) (*image.RGBA, error) {
 	if ppvBits == nil {
 		return nil, fmt.Errorf("invalid pixel buffer pointer")
 	}
 
-	totalBytes := width * height * 4
-
 	// Create slice backed by C memory (Go 1.17+)
 	// This is safe because we copy immediately.
-	srcBytes := unsafe.Slice((*byte)(ppvBits), totalBytes)
-
-	// Allocate new Go managed memory
-	dstBytes := make([]byte, totalBytes)
+	srcBytes := unsafe.Slice((*byte)(ppvBits), width*height*4)
 
-	// Use parallel conversion for large images (> 1MB)
-	if totalBytes > 1024*1024 {
-		convertBGRAtoRGBAParallel(srcBytes, dstBytes, preserveAlpha)
-	} else {
-		convertBGRAtoRGBASerial(srcBytes, dstBytes, preserveAlpha)
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	if err := ConvertBGRAToRGBA(srcBytes, width*4, img, preserveAlpha); err != nil {
+		return nil, err
 	}
-
-	return &image.RGBA{
-		Pix:    dstBytes,
-		Stride: width * 4,
-		Rect:   image.Rect(0, 0, width, height),
-	}, nil
+	return img, nil
 }
 
-func convertBGRAtoRGBASerial(src, dst []byte, preserveAlpha bool) {
-	// Simple serial loop
-	// Bounds check elimination hint: len(src) == len(dst)
-	_ = dst[len(src)-1]
-
-	for i := 0; i < len(src); i += 4 {
-		b := src[i]
-		g := src[i+1]
-		r := src[i+2]
-		a := src[i+3]
-
-		dst[i] = r
-		dst[i+1] = g
-		dst[i+2] = b
-		if preserveAlpha {
-			dst[i+3] = a
-		} else {
-			dst[i+3] = 255
-		}
+// CaptureFrame captures the entire virtual desktop as a Frame.
+// It has the same requirements as CaptureVirtualDesktop.
+func CaptureFrame() (*Frame, error) {
+	img, err := CaptureVirtualDesktop()
+	if err != nil {
+		return nil, err
 	}
+	b := VirtualBounds()
+	return &Frame{Image: img, Origin: Point{X: b.Left, Y: b.Top}}, nil
 }
 
-func convertBGRAtoRGBAParallel(src, dst []byte, preserveAlpha bool) {
-	numCPU := runtime.NumCPU()
-	if numCPU < 2 {
-		convertBGRAtoRGBASerial(src, dst, preserveAlpha)
-		return
-	}
-
-	// Ensure chunk size aligns with 4 bytes (pixel boundary)
-	// totalBytes is guaranteed to be multiple of 4
-	chunkSize := (len(src) / numCPU) &^ 3 // Round down to multiple of 4
-
-	var wg sync.WaitGroup
-	wg.Add(numCPU)
-
-	for i := 0; i < numCPU; i++ {
-		start := i * chunkSize
-		end := start + chunkSize
-		if i == numCPU-1 {
-			end = len(src) // Last chunk takes the rest
-		}
-
-		go func(s, e int) {
-			defer wg.Done()
-			convertBGRAtoRGBASerial(src[s:e], dst[s:e], preserveAlpha)
-		}(start, end)
+// CaptureRegionFrame captures a region of the virtual desktop as a Frame.
+// If the region extends past the desktop it is clipped, and Origin reflects the clipped position.
+func CaptureRegionFrame(x, y, w, h int32) (*Frame, error) {
+	img, origin, err := captureRegion(x, y, w, h)
+	if err != nil {
+		return nil, err
 	}
-	wg.Wait()
+	return &Frame{Image: img, Origin: origin}, nil
 }
 
 // CaptureRegion captures a specific region of the virtual desktop.
 // x, y: Virtual desktop coordinates (allowed to be negative).
 // w, h: Pixel dimensions of the region to capture.
 func CaptureRegion(x, y, w, h int32) (*image.RGBA, error) {
+	img, _, err := captureRegion(x, y, w, h)
+	return img, err
+}
+
+// captureRegion is CaptureRegion that also reports the virtual desktop position of the
+// returned image's top-left pixel, which differs from (x, y) when the region is clipped.
+func captureRegion(x, y, w, h int32) (*image.RGBA, Point, error) {
 	if w <= 0 || h <= 0 {
-		return nil, fmt.Errorf("invalid region size: %dx%d", w, h)
+		return nil, Point{}, fmt.Errorf("invalid region size: %dx%d", w, h)
 	}
 
 	fullImg, err := CaptureVirtualDesktop()
 	if err != nil {
-		return nil, err
+		return nil, Point{}, err
 	}
 
 	vx, _, _ := window.ProcGetSystemMetrics.Call(SM_XVIRTUALSCREEN)
@@ -259,7 +274,7 @@ func CaptureRegion(x, y, w, h int32) (*image.RGBA, error) {
 	intersect := reqRect.Intersect(fullImg.Bounds())
 
 	if intersect.Empty() {
-		return nil, fmt.Errorf("requested region is outside virtual desktop")
+		return nil, Point{}, fmt.Errorf("requested region is outside virtual desktop")
 	}
 
 	out := image.NewRGBA(image.Rect(0, 0, intersect.Dx(), intersect.Dy()))
@@ -270,5 +285,6 @@ func CaptureRegion(x, y, w, h int32) (*image.RGBA, error) {
 		copy(out.Pix[dst:dst+intersect.Dx()*4], fullImg.Pix[src:src+intersect.Dx()*4])
 	}
 
-	return out, nil
+	origin := Point{X: int32(intersect.Min.X) + vx32, Y: int32(intersect.Min.Y) + vy32}
+	return out, origin, nil
 }
@@ -0,0 +1,102 @@
+//go:build windows
+
+package screen
+
+import (
+	"image"
+	"testing"
+)
+
+// bgraFixture returns a w×h BGRA buffer with stride bytes per row. Padding bytes are 0xEE
+// so that reading them shows up in the output.
+func bgraFixture(w, h, stride int) []byte {
+	src := make([]byte, stride*h)
+	for i := range src {
+		src[i] = 0xEE
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			i := y*stride + x*4
+			src[i], src[i+1], src[i+2], src[i+3] = byte(x), byte(y), byte(x+y), byte(x*y)
+		}
+	}
+	return src
+}
+
+func TestConvertBGRAToRGBA(t *testing.T) {
+	const w, h, stride = 7, 5, 7*4 + 12
+	src := bgraFixture(w, h, stride)
+
+	for _, preserve := range []bool{false, true} {
+		// Convert into a sub-image of a larger canvas so the dst stride is padded too.
+		canvas := image.NewRGBA(image.Rect(0, 0, w+3, h+2))
+		dst := canvas.SubImage(image.Rect(2, 1, 2+w, 1+h)).(*image.RGBA)
+		ConvertBGRAToRGBA(src, stride, dst, preserve)
+
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				c := dst.RGBAAt(2+x, 1+y)
+				wantA := byte(255)
+				if preserve {
+					wantA = byte(x * y)
+				}
+				if c.R != byte(x+y) || c.G != byte(y) || c.B != byte(x) || c.A != wantA {
+					t.Fatalf("preserveAlpha=%v: pixel (%d,%d) = %v", preserve, x, y, c)
+				}
+			}
+		}
+		if c := canvas.RGBAAt(0, 0); c.A != 0 {
+			t.Errorf("preserveAlpha=%v: pixel outside dst was written: %v", preserve, c)
+		}
+	}
+}
+
+func TestConvertBGRAToRGBAShortSource(t *testing.T) {
+	dst := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	if err := ConvertBGRAToRGBA(make([]byte, 10), 8, dst, false); err == nil {
+		t.Error("expected an error for a short source buffer")
+	}
+	if err := ConvertBGRAToRGBA(make([]byte, 32), 4, dst, false); err == nil {
+		t.Error("expected an error for a stride shorter than a row")
+	}
+	for _, b := range dst.Pix {
+		if b != 0 {
+			t.Fatal("dst was written despite the error")
+		}
+	}
+}
+
+// convertBytewise is the per-byte conversion ConvertBGRAToRGBA replaced, kept as a baseline.
+func convertBytewise(src, dst []byte) {
+	for i := 0; i < len(src); i += 4 {
+		dst[i], dst[i+1], dst[i+2], dst[i+3] = src[i+2], src[i+1], src[i], 255
+	}
+}
+
+func benchmarkConvert(b *testing.B, w, h int) {
+	src := bgraFixture(w, h, w*4)
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	b.Run("Bytewise", func(b *testing.B) {
+		b.SetBytes(int64(len(src)))
+		for i := 0; i < b.N; i++ {
+			convertBytewise(src, dst.Pix)
+		}
+	})
+	b.Run("Words", func(b *testing.B) {
+		b.SetBytes(int64(len(src)))
+		for i := 0; i < b.N; i++ {
+			for y := 0; y < h; y++ {
+				convertRow(src[y*w*4:(y+1)*w*4], dst.Pix[y*w*4:(y+1)*w*4], false)
+			}
+		}
+	})
+	b.Run("Parallel", func(b *testing.B) {
+		b.SetBytes(int64(len(src)))
+		for i := 0; i < b.N; i++ {
+			ConvertBGRAToRGBA(src, w*4, dst, false)
+		}
+	})
+}
+
+func BenchmarkConvertBGRAToRGBA1080p(b *testing.B) { benchmarkConvert(b, 1920, 1080) }
+func BenchmarkConvertBGRAToRGBA4K(b *testing.B)    { benchmarkConvert(b, 3840, 2160) }
@@ -0,0 +1,40 @@
+package screen
+
+import (
+	"image"
+)
+
+// Frame is a captured image together with the virtual desktop position of its top-left
+// pixel, so that anything located in the image can be mapped back to screen coordinates.
+type Frame struct {
+	Image  *image.RGBA
+	Origin Point
+}
+
+// ToVirtual converts a point in the frame's image to virtual desktop coordinates,
+// ready for use with winput.MoveMouseTo / winput.ClickMouseAt.
+func (f *Frame) ToVirtual(x, y int32) (int32, int32) {
+	p := f.Image.Bounds().Min
+	return x - int32(p.X) + f.Origin.X, y - int32(p.Y) + f.Origin.Y
+}
+
+// RegionsToVirtual converts rectangles in the frame's image (such as those returned by
+// DetectTextRegions) to virtual desktop coordinates. The input slice is not modified.
+func (f *Frame) RegionsToVirtual(regions []Rect) []Rect {
+	out := make([]Rect, len(regions))
+	for i, r := range regions {
+		out[i].Left, out[i].Top = f.ToVirtual(r.Left, r.Top)
+		out[i].Right, out[i].Bottom = f.ToVirtual(r.Right, r.Bottom)
+	}
+	return out
+}
+
+// DetectTextRegions runs DetectTextRegions on the frame's image and returns the
+// regions in virtual desktop coordinates.
+func (f *Frame) DetectTextRegions(opts TextRegionOptions) ([]Rect, error) {
+	regions, err := DetectTextRegions(f.Image, opts)
+	if err != nil {
+		return nil, err
+	}
+	return f.RegionsToVirtual(regions), nil
+}
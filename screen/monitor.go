@@ -1,6 +1,11 @@
+//go:build windows
+
 package screen
 
 import (
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"unsafe"
 
@@ -49,39 +54,70 @@ func ImageToVirtual(imageX, imageY int32) (int32, int32) {
 	return imageX + int32(vx), imageY + int32(vy)
 }
 
+var (
+	monitorsOnce     sync.Once
+	monitorsCallback uintptr
+	monitorsLists    sync.Map // enumeration ID -> *[]Monitor
+	monitorsNextID   atomic.Uintptr
+)
+
 // Monitors returns a list of all active monitors.
 func Monitors() ([]Monitor, error) {
+	// syscall.NewCallback slots are never freed, so every enumeration shares one callback
+	// and collects into the list registered under the ID passed as its dwData.
+	monitorsOnce.Do(func() { monitorsCallback = syscall.NewCallback(monitorsProc) })
+	id := monitorsNextID.Add(1)
 	var monitors []Monitor
+	monitorsLists.Store(id, &monitors)
+	defer monitorsLists.Delete(id)
 
-	cb := syscall.NewCallback(func(hMonitor uintptr, hdcMonitor uintptr, lprcMonitor uintptr, dwData uintptr) uintptr {
-		var mi monitorInfoExW
-		mi.Size = uint32(unsafe.Sizeof(mi))
+	window.ProcEnumDisplayMonitors.Call(0, 0, monitorsCallback, id)
+	return monitors, nil
+}
 
-		ret, _, _ := window.ProcGetMonitorInfoW.Call(hMonitor, uintptr(unsafe.Pointer(&mi)))
-		if ret != 0 {
-			mon := Monitor{
-				Handle: hMonitor,
-				Bounds: Rect{
-					Left:   mi.Monitor.Left,
-					Top:    mi.Monitor.Top,
-					Right:  mi.Monitor.Right,
-					Bottom: mi.Monitor.Bottom,
-				},
-				WorkArea: Rect{
-					Left:   mi.Work.Left,
-					Top:    mi.Work.Top,
-					Right:  mi.Work.Right,
-					Bottom: mi.Work.Bottom,
-				},
-				Primary: (mi.Flags & 1) != 0, // MONITORINFOF_PRIMARY = 1
-			}
-			monitors = append(monitors, mon)
-		}
-		return 1
-	})
+func monitorsProc(hMonitor uintptr, hdcMonitor uintptr, lprcMonitor uintptr, dwData uintptr) uintptr {
+	v, ok := monitorsLists.Load(dwData)
+	if !ok {
+		return 0
+	}
+	if mon, err := MonitorInfo(hMonitor); err == nil {
+		l := v.(*[]Monitor)
+		*l = append(*l, mon)
+	}
+	return 1
+}
 
-	window.ProcEnumDisplayMonitors.Call(0, 0, cb, 0)
-	return monitors, nil
+// MonitorInfo returns the monitor with the given handle (HMONITOR), without enumerating
+// the others.
+func MonitorInfo(hMonitor uintptr) (Monitor, error) {
+	var mi monitorInfoExW
+	mi.Size = uint32(unsafe.Sizeof(mi))
+
+	ret, _, e := window.ProcGetMonitorInfoW.Call(hMonitor, uintptr(unsafe.Pointer(&mi)))
+	if ret == 0 {
+		return Monitor{}, fmt.Errorf("GetMonitorInfo(%#x) failed: %v", hMonitor, e)
+	}
+	mon := Monitor{
+		Handle: hMonitor,
+		Bounds: Rect{
+			Left:   mi.Monitor.Left,
+			Top:    mi.Monitor.Top,
+			Right:  mi.Monitor.Right,
+			Bottom: mi.Monitor.Bottom,
+		},
+		WorkArea: Rect{
+			Left:   mi.Work.Left,
+			Top:    mi.Work.Top,
+			Right:  mi.Work.Right,
+			Bottom: mi.Work.Bottom,
+		},
+		Primary: (mi.Flags & 1) != 0, // MONITORINFOF_PRIMARY = 1
+	}
+	if mon.WorkArea == mon.Bounds {
+		// An auto-hide taskbar does not reserve a work area, but covers it when shown.
+		mon.WorkArea = excludeAutoHideBars(mon.Bounds)
+	}
+	return mon, nil
 }
 
 type monitorInfoExW struct {
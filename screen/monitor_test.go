@@ -0,0 +1,34 @@
+//go:build windows
+
+package screen
+
+import "testing"
+
+// TestMonitorsRepeated guards against allocating a callback per call: syscall.NewCallback
+// slots are never freed and the process dies after about 2000 of them.
+func TestMonitorsRepeated(t *testing.T) {
+	first, err := Monitors()
+	if err != nil {
+		t.Fatalf("Monitors failed: %v", err)
+	}
+	if len(first) == 0 {
+		t.Fatal("Monitors returned no monitors")
+	}
+	for i := 0; i < 2500; i++ {
+		monitors, err := Monitors()
+		if err != nil {
+			t.Fatalf("Monitors call %d failed: %v", i, err)
+		}
+		if len(monitors) != len(first) {
+			t.Fatalf("Monitors call %d returned %d monitors, want %d", i, len(monitors), len(first))
+		}
+	}
+
+	m, err := MonitorInfo(first[0].Handle)
+	if err != nil {
+		t.Fatalf("MonitorInfo failed: %v", err)
+	}
+	if m != first[0] {
+		t.Errorf("MonitorInfo = %+v, want %+v", m, first[0])
+	}
+}
@@ -0,0 +1,170 @@
+// Code generated by internal/stubgen; DO NOT EDIT.
+
+//go:build !windows
+
+package screen
+
+import (
+	"errors"
+	"github.com/rpdg/winput/window"
+	"image"
+)
+
+// GDI Constants
+const (
+	SRCCOPY        = 0x00CC0020
+	DIB_RGB_COLORS = 0
+	BI_RGB         = 0
+
+	// GetSystemMetrics constants
+	SM_XVIRTUALSCREEN  = 76
+	SM_YVIRTUALSCREEN  = 77
+	SM_CXVIRTUALSCREEN = 78
+	SM_CYVIRTUALSCREEN = 79
+	SM_CMONITORS       = 80
+)
+
+type BITMAPINFOHEADER struct {
+	BiSize          uint32
+	BiWidth         int32
+	BiHeight        int32
+	BiPlanes        uint16
+	BiBitCount      uint16
+	BiCompression   uint32
+	BiSizeImage     uint32
+	BiXPelsPerMeter int32
+	BiYPelsPerMeter int32
+	BiClrUsed       uint32
+	BiClrImportant  uint32
+}
+
+// CaptureOptions defines configuration for screen capture.
+type CaptureOptions struct {
+	PreserveAlpha bool
+	MaxMemoryMB   int
+}
+
+// ErrInsufficientDPIAwareness is returned by captures when the process DPI awareness does
+// not satisfy the DPIPolicy, so GDI could hand back bitmap-stretched pixels.
+var ErrInsufficientDPIAwareness = errors.New("process DPI awareness is insufficient for capture")
+
+// DPIPolicy decides which process DPI awareness levels captures accept.
+type DPIPolicy int32
+
+const (
+	// DPIPolicyDefault accepts Per-Monitor awareness (V1 or V2), and System awareness on
+	// single-monitor systems, where the only monitor is the one the system DPI was computed
+	// for (e.g. Windows 7/8 where Per-Monitor does not exist, or a host application that
+	// already chose System awareness).
+	DPIPolicyDefault DPIPolicy = iota
+	// DPIPolicyStrict accepts Per-Monitor awareness only.
+	DPIPolicyStrict
+	// DPIPolicyAny accepts every level. On monitors whose scaling differs from what the
+	// process was told, the image is then stretched and its coordinates are logical, not physical.
+	DPIPolicyAny
+)
+
+// PrintWindow flags
+const (
+	PW_CLIENTONLY        = 0x1
+	PW_RENDERFULLCONTENT = 0x2 // Windows 8.1+: capture DirectX/DWM-rendered content
+)
+
+// SetDPIPolicy sets the DPI awareness policy for captures. The default is DPIPolicyDefault.
+func SetDPIPolicy(p DPIPolicy) {}
+
+// CaptureVirtualDesktop captures the entire virtual desktop (all monitors).
+// It returns an *image.RGBA ready for OpenCV or other processing.
+// It requires the process DPI awareness to satisfy the DPIPolicy (Per-Monitor DPI Aware by default).
+func CaptureVirtualDesktop() (*image.RGBA, error) {
+	return nil, window.ErrUnsupportedPlatform
+}
+
+// CaptureVirtualDesktopWithOptions captures the virtual desktop with custom options.
+func CaptureVirtualDesktopWithOptions(opts CaptureOptions) (*image.RGBA, error) {
+	return nil, window.ErrUnsupportedPlatform
+}
+
+// CaptureFrame captures the entire virtual desktop as a Frame.
+// It has the same requirements as CaptureVirtualDesktop.
+func CaptureFrame() (*Frame, error) {
+	return nil, window.ErrUnsupportedPlatform
+}
+
+// CaptureRegionFrame captures a region of the virtual desktop as a Frame.
+// If the region extends past the desktop it is clipped, and Origin reflects the clipped position.
+func CaptureRegionFrame(x, y, w, h int32) (*Frame, error) {
+	return nil, window.ErrUnsupportedPlatform
+}
+
+// CaptureRegion captures a specific region of the virtual desktop.
+// x, y: Virtual desktop coordinates (allowed to be negative).
+// w, h: Pixel dimensions of the region to capture.
+func CaptureRegion(x, y, w, h int32) (*image.RGBA, error) {
+	return nil, window.ErrUnsupportedPlatform
+}
+
+// ConvertBGRAToRGBA converts 32-bit BGRA pixels (the GDI DIB layout) into dst, whose bounds
+// give the width and height. srcStride is the number of bytes between the starts of
+// consecutive rows in src and may exceed width*4 for padded bitmaps; dst may likewise be
+// a sub-image. If preserveAlpha is false the alpha channel is forced to 255, which suits
+// GDI captures whose alpha byte is undefined.
+// It returns an error, leaving dst untouched, if src is too short for dst's bounds at the
+// given stride.
+//
+// Pixels are loaded and stored as whole uint32 words rather than byte by byte, which
+// roughly doubles throughput. This relies on a little-endian host that tolerates unaligned
+// 32-bit access, which every Windows architecture (386, amd64, arm64) is; the file is
+// built for Windows only for that reason.
+func ConvertBGRAToRGBA(src []byte, srcStride int, dst *image.RGBA, preserveAlpha bool) error {
+	return window.ErrUnsupportedPlatform
+}
+
+// VirtualBounds returns the bounding rectangle of the entire virtual desktop.
+// This includes all monitors.
+func VirtualBounds() Rect {
+	return *new(Rect)
+}
+
+// ImageToVirtual converts coordinates from a "Full Virtual Desktop Screenshot"
+// to actual Windows Virtual Desktop coordinates.
+//
+// Use this when you capture the entire multi-monitor desktop as a single image
+// (origin 0,0) and find a match at (imageX, imageY).
+//
+// Returns (x, y) ready for use with winput.MoveMouseTo / winput.ClickMouseAt.
+//
+// Constraints:
+// 1. The image MUST be a capture of the entire virtual desktop (all monitors).
+// 2. The capture process MUST be Per-Monitor DPI Aware (matching winput).
+// 3. Do NOT modify the returned negative coordinates; they are valid.
+func ImageToVirtual(imageX, imageY int32) (int32, int32) {
+	return 0, 0
+}
+
+// Monitors returns a list of all active monitors.
+func Monitors() ([]Monitor, error) {
+	return nil, window.ErrUnsupportedPlatform
+}
+
+// MonitorInfo returns the monitor with the given handle (HMONITOR), without enumerating
+// the others.
+func MonitorInfo(hMonitor uintptr) (Monitor, error) {
+	return *new(Monitor), window.ErrUnsupportedPlatform
+}
+
+// CaptureWindow captures the window hwnd with PrintWindow, which asks the window to draw
+// itself, so the image shows it even where other windows cover it. The image spans the
+// window rect (including the frame), starting at (0, 0). It fails for minimized windows,
+// and has the same DPI awareness requirements as CaptureVirtualDesktop.
+func CaptureWindow(hwnd uintptr) (*image.RGBA, error) {
+	return nil, window.ErrUnsupportedPlatform
+}
+
+// CaptureWindowClient captures the part of the window's client area at client coordinates
+// (x, y) with size w x h, like CaptureWindow. The region is clipped to the client area, and
+// the image bounds are the clipped region in client coordinates, so img.At(x, y) is the
+// client pixel (x, y). It fails if the region is empty or lies outside the client area.
+func CaptureWindowClient(hwnd uintptr, x, y, w, h int32) (*image.RGBA, error) {
+	return nil, window.ErrUnsupportedPlatform
+}
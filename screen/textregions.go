@@ -0,0 +1,332 @@
+package screen
+
+import (
+	"fmt"
+	"image"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// TextRegionOptions configures DetectTextRegions.
+// Zero values select the defaults, which suit typical UI text at 100-200% scaling.
+type TextRegionOptions struct {
+	// Window is the side, in pixels, of the neighbourhood used for the adaptive threshold.
+	// It should be a few times the stroke width of the text. 0 means the default (15).
+	Window int
+	// Contrast is how much darker (0-255) than its neighbourhood mean a pixel must be to
+	// count as ink. 0 means the default (12).
+	Contrast int
+	// Gap is the widest horizontal gap, in pixels, bridged when joining glyphs into a line.
+	// 0 means the default (6).
+	Gap int
+	// MinWidth and MinHeight discard components smaller than a short word.
+	// 0 means the defaults (8 and 5).
+	MinWidth  int
+	MinHeight int
+	// MaxHeight discards components taller than a line of text (icons, images, borders).
+	// 0 means the default (80).
+	MaxHeight int
+}
+
+var defaultTextRegionOptions = TextRegionOptions{
+	Window:    15,
+	Contrast:  12,
+	Gap:       6,
+	MinWidth:  8,
+	MinHeight: 5,
+	MaxHeight: 80,
+}
+
+// DetectTextRegions locates text-like lines in img and returns their bounding rectangles,
+// ordered top to bottom, then left to right.
+//
+// It is a classic pure-Go pipeline - grayscale, adaptive threshold, horizontal dilation,
+// connected components - and does not recognise characters. Use it to narrow down where an
+// external OCR engine should look, or to find "the line under the cursor".
+//
+// Dark text on a light background is assumed; if the image is predominantly dark
+// (dark mode), it is inverted first.
+//
+// The returned rectangles are in img's coordinate space (Right and Bottom exclusive).
+// Use Frame.RegionsToVirtual to turn them into clickable screen coordinates.
+func DetectTextRegions(img *image.RGBA, opts TextRegionOptions) ([]Rect, error) {
+	if img == nil || img.Bounds().Empty() {
+		return nil, fmt.Errorf("invalid image: empty")
+	}
+	opts = opts.withDefaults()
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	gray := toGrayPlane(img)
+	integral := integralImage(gray, w, h)
+	mask := adaptiveThreshold(gray, integral, w, h, opts)
+	parallelRange(h, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			bridgeGaps(mask[y*w:(y+1)*w], opts.Gap)
+		}
+	})
+
+	var regions []Rect
+	for _, r := range connectedComponents(mask, w, h) {
+		rw, rh := int(r.Right-r.Left), int(r.Bottom-r.Top)
+		if rw < opts.MinWidth || rh < opts.MinHeight || rh > opts.MaxHeight {
+			continue
+		}
+		regions = append(regions, Rect{
+			Left:   r.Left + int32(b.Min.X),
+			Top:    r.Top + int32(b.Min.Y),
+			Right:  r.Right + int32(b.Min.X),
+			Bottom: r.Bottom + int32(b.Min.Y),
+		})
+	}
+
+	sort.Slice(regions, func(i, j int) bool {
+		if regions[i].Top != regions[j].Top {
+			return regions[i].Top < regions[j].Top
+		}
+		return regions[i].Left < regions[j].Left
+	})
+	return regions, nil
+}
+
+func (o TextRegionOptions) withDefaults() TextRegionOptions {
+	d := defaultTextRegionOptions
+	if o.Window <= 0 {
+		o.Window = d.Window
+	}
+	if o.Contrast <= 0 {
+		o.Contrast = d.Contrast
+	}
+	if o.Gap <= 0 {
+		o.Gap = d.Gap
+	}
+	if o.MinWidth <= 0 {
+		o.MinWidth = d.MinWidth
+	}
+	if o.MinHeight <= 0 {
+		o.MinHeight = d.MinHeight
+	}
+	if o.MaxHeight <= 0 {
+		o.MaxHeight = d.MaxHeight
+	}
+	return o
+}
+
+// parallelRange splits [0, n) into one contiguous band per CPU and runs fn on each.
+func parallelRange(n int, fn func(start, end int)) {
+	numCPU := runtime.NumCPU()
+	if numCPU < 2 || n < numCPU*16 {
+		fn(0, n)
+		return
+	}
+
+	chunk := n / numCPU
+	var wg sync.WaitGroup
+	wg.Add(numCPU)
+	for i := 0; i < numCPU; i++ {
+		start := i * chunk
+		end := start + chunk
+		if i == numCPU-1 {
+			end = n
+		}
+		go func(s, e int) {
+			defer wg.Done()
+			fn(s, e)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// toGrayPlane converts img to an 8-bit luminance plane (w*h, no padding), inverting it
+// when the average luminance is dark so that text is always darker than its background.
+func toGrayPlane(img *image.RGBA) []uint8 {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	gray := make([]uint8, w*h)
+
+	var mu sync.Mutex
+	var total uint64
+	parallelRange(h, func(y0, y1 int) {
+		var sum uint64
+		for y := y0; y < y1; y++ {
+			src := img.Pix[img.PixOffset(b.Min.X, b.Min.Y+y):]
+			dst := gray[y*w : (y+1)*w]
+			for x := range dst {
+				r, g, bl := uint32(src[x*4]), uint32(src[x*4+1]), uint32(src[x*4+2])
+				// ITU-R BT.601 luma in 16.16 fixed point.
+				l := uint8((19595*r + 38470*g + 7471*bl + 1<<15) >> 16)
+				dst[x] = l
+				sum += uint64(l)
+			}
+		}
+		mu.Lock()
+		total += sum
+		mu.Unlock()
+	})
+
+	if total/uint64(w*h) < 128 {
+		parallelRange(h, func(y0, y1 int) {
+			for i := y0 * w; i < y1*w; i++ {
+				gray[i] = 255 - gray[i]
+			}
+		})
+	}
+	return gray
+}
+
+// integralImage returns the (w+1)*(h+1) summed-area table of gray.
+// Sums are kept modulo 2^32: the table itself overflows on large images, but any window
+// sum derived from it is exact as long as the window holds fewer than 2^24 pixels.
+func integralImage(gray []uint8, w, h int) []uint32 {
+	stride := w + 1
+	sat := make([]uint32, stride*(h+1))
+
+	// Row prefix sums are independent per row...
+	parallelRange(h, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			row := sat[(y+1)*stride:]
+			src := gray[y*w : (y+1)*w]
+			var acc uint32
+			for x, v := range src {
+				acc += uint32(v)
+				row[x+1] = acc
+			}
+		}
+	})
+	// ...and the column accumulation is independent per column band.
+	parallelRange(stride, func(x0, x1 int) {
+		for y := 2; y <= h; y++ {
+			cur := sat[y*stride : (y+1)*stride]
+			prev := sat[(y-1)*stride : y*stride]
+			for x := x0; x < x1; x++ {
+				cur[x] += prev[x]
+			}
+		}
+	})
+	return sat
+}
+
+// adaptiveThreshold marks pixels that are at least opts.Contrast darker than the mean of
+// the opts.Window-sized square around them (clipped at the image edges).
+func adaptiveThreshold(gray []uint8, sat []uint32, w, h int, opts TextRegionOptions) []uint8 {
+	mask := make([]uint8, w*h)
+	stride := w + 1
+	half := opts.Window / 2
+	contrast := uint32(opts.Contrast)
+
+	parallelRange(h, func(y0, y1 int) {
+		for y := y0; y < y1; y++ {
+			top, bottom := max(y-half, 0), min(y+half+1, h)
+			for x := 0; x < w; x++ {
+				left, right := max(x-half, 0), min(x+half+1, w)
+				area := uint32((bottom - top) * (right - left))
+				sum := sat[bottom*stride+right] - sat[top*stride+right] -
+					sat[bottom*stride+left] + sat[top*stride+left]
+				// gray < mean - contrast, without dividing.
+				if (uint32(gray[y*w+x])+contrast)*area < sum {
+					mask[y*w+x] = 1
+				}
+			}
+		}
+	})
+	return mask
+}
+
+// bridgeGaps fills horizontal gaps of at most gap pixels between set pixels in row,
+// joining the glyphs of a word (and the words of a line) into one run. Unlike a plain
+// dilation it does not grow the run past its outermost ink.
+func bridgeGaps(row []uint8, gap int) {
+	last := -1
+	for x, v := range row {
+		if v == 0 {
+			continue
+		}
+		if last >= 0 && x-last-1 <= gap {
+			for i := last + 1; i < x; i++ {
+				row[i] = 1
+			}
+		}
+		last = x
+	}
+}
+
+// run is a horizontal span [x0, x1) of set pixels on row y.
+type run struct {
+	y, x0, x1 int32
+}
+
+// connectedComponents labels 8-connected regions of mask and returns their bounding
+// rectangles in mask coordinates. It works on runs rather than pixels, so its cost
+// scales with the amount of ink, not the image area.
+func connectedComponents(mask []uint8, w, h int) []Rect {
+	var runs []run
+	rowStart := make([]int, h+1)
+	for y := 0; y < h; y++ {
+		rowStart[y] = len(runs)
+		row := mask[y*w : (y+1)*w]
+		for x := 0; x < w; {
+			if row[x] == 0 {
+				x++
+				continue
+			}
+			start := x
+			for x < w && row[x] != 0 {
+				x++
+			}
+			runs = append(runs, run{int32(y), int32(start), int32(x)})
+		}
+	}
+	rowStart[h] = len(runs)
+
+	parent := make([]int32, len(runs))
+	for i := range parent {
+		parent[i] = int32(i)
+	}
+	find := func(i int32) int32 {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	for y := 1; y < h; y++ {
+		prev := rowStart[y-1]
+		for i := rowStart[y]; i < rowStart[y+1]; i++ {
+			cur := runs[i]
+			// Runs are sorted by x, so skip previous-row runs that end before this one
+			// starts and stop at the first that begins after it ends (8-connectivity).
+			for prev < rowStart[y] && runs[prev].x1 < cur.x0 {
+				prev++
+			}
+			for j := prev; j < rowStart[y] && runs[j].x0 <= cur.x1; j++ {
+				if a, b := find(int32(i)), find(int32(j)); a != b {
+					parent[a] = b
+				}
+			}
+		}
+	}
+
+	bounds := make(map[int32]*Rect)
+	var order []int32
+	for i, r := range runs {
+		root := find(int32(i))
+		bb, ok := bounds[root]
+		if !ok {
+			bounds[root] = &Rect{Left: r.x0, Top: r.y, Right: r.x1, Bottom: r.y + 1}
+			order = append(order, root)
+			continue
+		}
+		bb.Left = min(bb.Left, r.x0)
+		bb.Right = max(bb.Right, r.x1)
+		bb.Bottom = max(bb.Bottom, r.y+1)
+	}
+
+	out := make([]Rect, 0, len(order))
+	for _, root := range order {
+		out = append(out, *bounds[root])
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package screen
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"testing"
+)
+
+// drawLine paints a fake line of text: words of 4px-wide glyphs separated by 2px,
+// with 10px between words.
+func drawLine(img draw.Image, x, y, words int, c color.Color) {
+	for w := 0; w < words; w++ {
+		for g := 0; g < 5; g++ {
+			gx := x + w*40 + g*6
+			draw.Draw(img, image.Rect(gx, y, gx+4, y+12), &image.Uniform{c}, image.Point{}, draw.Src)
+		}
+	}
+}
+
+func TestDetectTextRegions(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 120))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.White}, image.Point{}, draw.Src)
+	drawLine(img, 20, 20, 3, color.Black)
+	drawLine(img, 20, 60, 2, color.Black)
+	// A tall block is not text.
+	draw.Draw(img, image.Rect(250, 5, 290, 115), &image.Uniform{color.Black}, image.Point{}, draw.Src)
+
+	got, err := DetectTextRegions(img, TextRegionOptions{Gap: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Rect{
+		{Left: 20, Top: 20, Right: 20 + 2*40 + 28, Bottom: 32},
+		{Left: 20, Top: 60, Right: 20 + 40 + 28, Bottom: 72},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("region %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	// Dark mode: light text on a dark background gives the same lines.
+	dark := image.NewRGBA(img.Bounds())
+	draw.Draw(dark, dark.Bounds(), &image.Uniform{color.Black}, image.Point{}, draw.Src)
+	drawLine(dark, 20, 20, 3, color.White)
+	got, err = DetectTextRegions(dark, TextRegionOptions{Gap: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Errorf("dark mode: got %v, want [%+v]", got, want[0])
+	}
+
+	// Sub-images report regions in their parent's coordinates.
+	sub := img.SubImage(image.Rect(10, 50, 200, 100)).(*image.RGBA)
+	got, err = DetectTextRegions(sub, TextRegionOptions{Gap: 12})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0] != want[1] {
+		t.Errorf("sub-image: got %v, want [%+v]", got, want[1])
+	}
+}
+
+func TestFrameRegionsToVirtual(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
+	f := &Frame{Image: img, Origin: Point{X: -1920, Y: 100}}
+	got := f.RegionsToVirtual([]Rect{{Left: 1, Top: 2, Right: 3, Bottom: 4}})
+	want := Rect{Left: -1919, Top: 102, Right: -1917, Bottom: 104}
+	if got[0] != want {
+		t.Errorf("got %+v, want %+v", got[0], want)
+	}
+}
+
+func BenchmarkDetectTextRegions(b *testing.B) {
+	img := image.NewRGBA(image.Rect(0, 0, 1920, 1080))
+	draw.Draw(img, img.Bounds(), &image.Uniform{color.White}, image.Point{}, draw.Src)
+	for y := 10; y < 1060; y += 30 {
+		drawLine(img, 10, y, 40, color.Black)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := DetectTextRegions(img, TextRegionOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -19,7 +19,34 @@ type Rect struct {
 type Monitor struct {
 	Handle     uintptr
 	Bounds     Rect
-	WorkArea   Rect // Excludes taskbar
+	WorkArea   Rect // Excludes taskbar (including an auto-hide taskbar's shown extent)
 	Primary    bool
 	DeviceName string
 }
+
+// WorkCenter returns the center of the monitor's work area.
+func (m Monitor) WorkCenter() Point {
+	return Point{
+		X: m.WorkArea.Left + (m.WorkArea.Right-m.WorkArea.Left)/2,
+		Y: m.WorkArea.Top + (m.WorkArea.Bottom-m.WorkArea.Top)/2,
+	}
+}
+
+// IsInWorkArea reports whether p lies in the monitor's work area, i.e. not on the
+// taskbar or another docked app bar. Right and Bottom are exclusive.
+func (m Monitor) IsInWorkArea(p Point) bool {
+	return p.X >= m.WorkArea.Left && p.X < m.WorkArea.Right &&
+		p.Y >= m.WorkArea.Top && p.Y < m.WorkArea.Bottom
+}
+
+// ClampToWorkArea returns the point of the monitor's work area nearest to p.
+func (m Monitor) ClampToWorkArea(p Point) Point {
+	return Point{
+		X: clamp(p.X, m.WorkArea.Left, m.WorkArea.Right-1),
+		Y: clamp(p.Y, m.WorkArea.Top, m.WorkArea.Bottom-1),
+	}
+}
+
+func clamp(v, lo, hi int32) int32 {
+	return max(lo, min(v, hi))
+}
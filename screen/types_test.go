@@ -0,0 +1,36 @@
+package screen
+
+import "testing"
+
+func TestMonitorWorkArea(t *testing.T) {
+	// 1920x1080 with a 40px taskbar at the bottom.
+	m := Monitor{
+		Bounds:   Rect{Left: 0, Top: 0, Right: 1920, Bottom: 1080},
+		WorkArea: Rect{Left: 0, Top: 0, Right: 1920, Bottom: 1040},
+	}
+
+	if c := m.WorkCenter(); c != (Point{960, 520}) {
+		t.Errorf("WorkCenter = %v, want (960,520)", c)
+	}
+
+	tests := []struct {
+		p     Point
+		in    bool
+		clamp Point
+	}{
+		{Point{960, 500}, true, Point{960, 500}},
+		{Point{0, 1039}, true, Point{0, 1039}},
+		{Point{960, 1040}, false, Point{960, 1039}}, // first taskbar row
+		{Point{960, 1079}, false, Point{960, 1039}},
+		{Point{-5, 2000}, false, Point{0, 1039}},
+		{Point{1920, -1}, false, Point{1919, 0}},
+	}
+	for _, tt := range tests {
+		if got := m.IsInWorkArea(tt.p); got != tt.in {
+			t.Errorf("IsInWorkArea(%v) = %v, want %v", tt.p, got, tt.in)
+		}
+		if got := m.ClampToWorkArea(tt.p); got != tt.clamp {
+			t.Errorf("ClampToWorkArea(%v) = %v, want %v", tt.p, got, tt.clamp)
+		}
+	}
+}
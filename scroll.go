@@ -0,0 +1,313 @@
+//go:build windows
+
+package winput
+
+import (
+	"time"
+
+	"github.com/rpdg/winput/hid"
+	"github.com/rpdg/winput/mouse"
+	"github.com/rpdg/winput/window"
+)
+
+// ScrollTarget returns the descendant that ScrollAtPoint would send the wheel message to:
+// the deepest visible, enabled child containing the client point (cx, cy).
+// Useful for finding out which control actually handles scrolling in a composite window.
+func (w *Window) ScrollTarget(cx, cy int32) (*Window, error) {
+	if err := w.checkReady(); err != nil {
+		return nil, err
+	}
+	sx, sy, err := window.ClientToScreen(w.HWND, cx, cy)
+	if err != nil {
+		return nil, err
+	}
+	return &Window{HWND: window.DeepestChildFromPoint(w.HWND, sx, sy)}, nil
+}
+
+// ScrollAtPoint scrolls the control under the client point (cx, cy), resolving the deepest
+// visible, enabled child (e.g. a ListView inside a dialog inside a frame) instead of posting
+// to w itself. If posting to that child fails, its ancestors up to w are tried in turn.
+// Under the HID backend the cursor is moved to the point and a physical wheel event is sent.
+func (w *Window) ScrollAtPoint(cx, cy int32, delta int32) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.scrollAtPoint(cx, cy, delta)
+}
+
+func (w *Window) scrollAtPoint(cx, cy int32, delta int32) error {
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	w.recordAction("ScrollAtPoint", cx, cy)
+	sx, sy, err := window.ClientToScreen(w.HWND, cx, cy)
+	if err != nil {
+		return err
+	}
+
+	if getBackend() == BackendHID {
+		if err := hid.Move(sx, sy); err != nil {
+			return err
+		}
+		return hid.Scroll(delta)
+	}
+
+	target := window.DeepestChildFromPoint(w.HWND, sx, sy)
+	for {
+		err = mouse.ScrollScreen(target, sx, sy, delta, 0)
+		if err == nil || target == w.HWND {
+			return err
+		}
+		parent := window.GetAncestor(target, window.GA_PARENT)
+		if parent == 0 {
+			return err
+		}
+		target = parent
+	}
+}
+
+// ScrollAt moves the cursor to the screen point (x, y) and scrolls the wheel there, e.g. for
+// a window found by screen capture. As with Window.Scroll, delta must be a multiple of
+// WHEEL_DELTA (120) and positive scrolls up. Coordinates are virtual desktop coordinates and
+// may be negative on monitors left of or above the primary one. The Message backend uses
+// SetCursorPos and mouse_event; the HID backend moves and sends a physical wheel event.
+// The point is subject to the work area guard (see SetWorkAreaGuard).
+func ScrollAt(x, y int32, delta int32) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return scrollAt(x, y, delta)
+}
+
+func scrollAt(x, y int32, delta int32) error {
+	if delta%mouse.WHEEL_DELTA != 0 {
+		return mouse.ErrInvalidScrollDelta
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	x, y, guard := guardWorkArea(x, y)
+
+	if getBackend() == BackendHID {
+		if err := hid.Move(x, y); err != nil {
+			return err
+		}
+		if err := hid.Scroll(delta); err != nil {
+			return err
+		}
+		return guard
+	}
+
+	if err := window.SetCursorPos(x, y); err != nil {
+		return err
+	}
+	time.Sleep(30 * time.Millisecond)
+	window.ProcMouseEvent.Call(mouseEventWheel, 0, 0, uintptr(delta), 0)
+	return guard
+}
+
+// ScrollH simulates a horizontal mouse wheel scroll (WM_MOUSEHWHEEL) at the client point
+// (x, y), e.g. for spreadsheets and timelines. delta must be a multiple of WHEEL_DELTA (120);
+// positive scrolls right, negative left, the opposite sense of a vertical delta's "up".
+// Under the HID backend the cursor is moved to the point and a physical horizontal wheel
+// event is sent.
+func (w *Window) ScrollH(x, y int32, delta int32) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.scrollH(x, y, delta)
+}
+
+func (w *Window) scrollH(x, y int32, delta int32) error {
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	w.recordAction("ScrollH", x, y)
+
+	if getBackend() == BackendHID {
+		if delta%mouse.WHEEL_DELTA != 0 {
+			return mouse.ErrInvalidScrollDelta
+		}
+		sx, sy, err := clientToScreen(w.HWND, x, y)
+		if err != nil {
+			return err
+		}
+		if err := hid.Move(sx, sy); err != nil {
+			return err
+		}
+		return hid.ScrollH(delta)
+	}
+	return mouse.ScrollH(w.HWND, x, y, delta)
+}
+
+// ScrollHAt moves the cursor to the screen point (x, y) and scrolls the horizontal wheel there.
+// delta follows ScrollH: positive scrolls right. The Message backend uses SetCursorPos and
+// mouse_event; the HID backend moves and sends a physical horizontal wheel event.
+// The point is subject to the work area guard (see SetWorkAreaGuard).
+func ScrollHAt(x, y int32, delta int32) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return scrollHAt(x, y, delta)
+}
+
+func scrollHAt(x, y int32, delta int32) error {
+	if delta%mouse.WHEEL_DELTA != 0 {
+		return mouse.ErrInvalidScrollDelta
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	x, y, guard := guardWorkArea(x, y)
+
+	if getBackend() == BackendHID {
+		if err := hid.Move(x, y); err != nil {
+			return err
+		}
+		if err := hid.ScrollH(delta); err != nil {
+			return err
+		}
+		return guard
+	}
+
+	if err := window.SetCursorPos(x, y); err != nil {
+		return err
+	}
+	time.Sleep(30 * time.Millisecond)
+	window.ProcMouseEvent.Call(mouseEventHWheel, 0, 0, uintptr(delta), 0)
+	return guard
+}
+
+// Modifier is a set of modifier keys reported with a mouse event.
+type Modifier uint8
+
+const (
+	ModShift Modifier = 1 << iota
+	ModCtrl
+)
+
+// mkFlags converts modifiers to the MK_* key state of mouse messages.
+func (m Modifier) mkFlags() uint16 {
+	var f uint16
+	if m&ModShift != 0 {
+		f |= mouse.MK_SHIFT
+	}
+	if m&ModCtrl != 0 {
+		f |= mouse.MK_CONTROL
+	}
+	return f
+}
+
+// holdModifiers presses the physical modifier keys under the HID backend and returns a release func.
+func holdModifiers(m Modifier) (func(), error) {
+	var held []Key
+	release := func() {
+		for i := len(held) - 1; i >= 0; i-- {
+			hid.KeyUp(uint16(held[i]))
+		}
+	}
+	for _, mk := range []struct {
+		mod Modifier
+		key Key
+	}{{ModCtrl, KeyCtrl}, {ModShift, KeyShift}} {
+		if m&mk.mod == 0 {
+			continue
+		}
+		if err := hid.KeyDown(uint16(mk.key)); err != nil {
+			release()
+			return nil, err
+		}
+		held = append(held, mk.key)
+		time.Sleep(10 * time.Millisecond)
+	}
+	return release, nil
+}
+
+// ScrollOptions configures ScrollWithOptions.
+type ScrollOptions struct {
+	// Modifiers are reported in the wheel message (MK_CONTROL / MK_SHIFT). Holding the real
+	// keys has no effect on posted messages. Under the HID backend the physical keys are held.
+	Modifiers Modifier
+}
+
+// ScrollWithOptions simulates a vertical mouse wheel scroll with the given options.
+func (w *Window) ScrollWithOptions(x, y int32, delta int32, opts ScrollOptions) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.scrollWithOptions(x, y, delta, opts)
+}
+
+func (w *Window) scrollWithOptions(x, y int32, delta int32, opts ScrollOptions) error {
+	if err := w.checkReady(); err != nil {
+		return err
+	}
+	if err := checkBackend(); err != nil {
+		return err
+	}
+	w.recordAction("ScrollWithOptions", x, y)
+
+	if getBackend() == BackendHID {
+		release, err := holdModifiers(opts.Modifiers)
+		if err != nil {
+			return err
+		}
+		defer release()
+		return hid.Scroll(delta)
+	}
+	sx, sy, err := window.ClientToScreen(w.HWND, x, y)
+	if err != nil {
+		return err
+	}
+	return mouse.ScrollScreen(w.HWND, sx, sy, delta, opts.Modifiers.mkFlags())
+}
+
+// Zoom sends Ctrl+wheel at the center of the client area, the standard zoom gesture of
+// browsers, editors and Explorer. Positive steps zoom in, negative zoom out; each step is one notch.
+func (w *Window) Zoom(steps int) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.zoom(steps)
+}
+
+func (w *Window) zoom(steps int) error {
+	if steps == 0 {
+		return nil
+	}
+	width, height, err := window.GetClientRect(w.HWND)
+	if err != nil {
+		return err
+	}
+	delta := int32(mouse.WHEEL_DELTA)
+	if steps < 0 {
+		delta, steps = -delta, -steps
+	}
+	for i := 0; i < steps; i++ {
+		if i > 0 {
+			time.Sleep(50 * time.Millisecond)
+		}
+		if err := w.scrollWithOptions(width/2, height/2, delta, ScrollOptions{Modifiers: ModCtrl}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+//go:build windows
+
+package winput
+
+import (
+	"os"
+	"sync/atomic"
+
+	"github.com/rpdg/winput/window"
+)
+
+var allowSelfTarget atomic.Bool
+
+// SetAllowSelfTarget lets the synchronous helpers (Text, Value, Selection, ReplaceText,
+// SetPosImmediate) send to windows owned by the calling thread. By default they return
+// ErrSelfTarget for such a window: the message is dispatched to its window procedure
+// inline, while the input lock may be held, so a handler that calls back into winput
+// deadlocks. Type and TypeWithOptions fall back to posted input for such a window instead.
+// Enable it only if the window procedure never calls back into winput.
+func SetAllowSelfTarget(enabled bool) {
+	allowSelfTarget.Store(enabled)
+}
+
+// IsOwnProcess reports whether the window belongs to the calling process, e.g. a test
+// harness or embedded webview. HID input aimed at such a window reenters the program's
+// own message handlers mid-operation.
+func (w *Window) IsOwnProcess() bool {
+	return window.GetWindowPID(w.HWND) == uint32(os.Getpid())
+}
+
+// checkSelfTarget refuses a synchronous send to a window owned by the calling thread.
+func (w *Window) checkSelfTarget() error {
+	if allowSelfTarget.Load() {
+		return nil
+	}
+	if tid, _ := window.GetThreadProcessID(w.HWND); tid != 0 && tid == window.CurrentThreadID() {
+		return ErrSelfTarget
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package winput
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"github.com/rpdg/winput/keyboard"
+)
+
+// sensitiveDepth counts the active WithSensitive calls.
+var sensitiveDepth atomic.Int32
+
+// WithSensitive runs fn with input reporting in sensitive mode, for typing passwords and
+// other secrets. Everything winput records about input issued while fn runs is redacted:
+// Sequence results show typed text as its length and character keys as "char", and
+// UnsupportedKeyError omits the character. Coordinates and non-character keys (Enter, Tab,
+// modifiers, navigation) are still recorded. Results and errors carry a Sensitive flag so
+// downstream consumers can redact further.
+//
+// Calls nest. The mode is process-wide, so input issued concurrently by other goroutines
+// while fn runs is redacted as well.
+func WithSensitive(fn func() error) error {
+	sensitiveDepth.Add(1)
+	defer sensitiveDepth.Add(-1)
+	return fn()
+}
+
+func isSensitive() bool {
+	return sensitiveDepth.Load() > 0
+}
+
+// textDetail formats typed text for results, or only its length if sensitive.
+func textDetail(text string, sensitive bool) string {
+	if sensitive {
+		return fmt.Sprintf("len=%d", utf8.RuneCountInString(text))
+	}
+	return fmt.Sprintf("%q", text)
+}
+
+// keyDetail formats scan codes as "0x1D+0x2E"; if sensitive, character keys show as "char".
+func keyDetail(sensitive bool, keys ...Key) string {
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		if sensitive && keyboard.IsCharacter(k) {
+			parts[i] = "char"
+		} else {
+			parts[i] = fmt.Sprintf("0x%02X", uint16(k))
+		}
+	}
+	return strings.Join(parts, "+")
+}
@@ -0,0 +1,233 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Sequence runs the input actions issued through it strictly in the order they were
+// issued, from whichever goroutines issue them. Each action takes the global input lock
+// on its own, as the equivalent package-level or *Window call would, so actions from
+// different sequences (and plain calls) interleave freely between them.
+//
+// Methods only enqueue; failures are collected and returned by Wait. A failed action
+// does not stop the ones after it. The zero value is not usable; call NewSequence.
+type Sequence struct {
+	mu      sync.Mutex
+	idle    sync.Cond
+	pending []seqAction
+	running bool
+	results []StepResult
+}
+
+type seqAction struct {
+	name, detail string
+	sensitive    bool
+	do           func() error
+}
+
+// NewSequence returns an empty Sequence. It needs no cleanup: its worker goroutine only
+// runs while actions are pending.
+func NewSequence() *Sequence {
+	sq := &Sequence{}
+	sq.idle.L = &sq.mu
+	return sq
+}
+
+func (sq *Sequence) enqueue(name, detail string, do func() error) {
+	sensitive := isSensitive()
+	sq.mu.Lock()
+	defer sq.mu.Unlock()
+	sq.pending = append(sq.pending, seqAction{name: name, detail: detail, sensitive: sensitive, do: do})
+	if !sq.running {
+		sq.running = true
+		go sq.run()
+	}
+}
+
+func (sq *Sequence) run() {
+	for {
+		sq.mu.Lock()
+		if len(sq.pending) == 0 {
+			sq.running = false
+			sq.idle.Broadcast()
+			sq.mu.Unlock()
+			return
+		}
+		a := sq.pending[0]
+		sq.pending = sq.pending[1:]
+		sq.mu.Unlock()
+
+		start := time.Now()
+		err := a.do()
+		r := StepResult{Name: a.name, Detail: a.detail, Err: err, Duration: time.Since(start), Sensitive: a.sensitive}
+
+		sq.mu.Lock()
+		sq.results = append(sq.results, r)
+		sq.mu.Unlock()
+	}
+}
+
+// Wait blocks until every action issued so far has run and returns their results, in
+// order, since the previous Wait. The error is nil if all of them succeeded, or a
+// *BatchError listing the failures.
+func (sq *Sequence) Wait() (*BatchResult, error) {
+	sq.mu.Lock()
+	for sq.running {
+		sq.idle.Wait()
+	}
+	res := &BatchResult{Steps: sq.results}
+	sq.results = nil
+	sq.mu.Unlock()
+	return res, res.Err()
+}
+
+// Do enqueues an arbitrary action, named name in results and errors.
+func (sq *Sequence) Do(name string, fn func() error) {
+	sq.enqueue(name, "", fn)
+}
+
+// -----------------------------------------------------------------------------
+// Global Input (Sequence)
+// -----------------------------------------------------------------------------
+
+// MoveMouseTo enqueues the package-level MoveMouseTo.
+func (sq *Sequence) MoveMouseTo(x, y int32) {
+	sq.enqueue("MoveMouseTo", xyDetail(x, y), func() error { return MoveMouseTo(x, y) })
+}
+
+// ClickMouseAt enqueues the package-level ClickMouseAt.
+func (sq *Sequence) ClickMouseAt(x, y int32) {
+	sq.enqueue("ClickMouseAt", xyDetail(x, y), func() error { return ClickMouseAt(x, y) })
+}
+
+// DoubleClickMouseAt enqueues the package-level DoubleClickMouseAt.
+func (sq *Sequence) DoubleClickMouseAt(x, y int32) {
+	sq.enqueue("DoubleClickMouseAt", xyDetail(x, y), func() error { return DoubleClickMouseAt(x, y) })
+}
+
+// ClickRightMouseAt enqueues the package-level ClickRightMouseAt.
+func (sq *Sequence) ClickRightMouseAt(x, y int32) {
+	sq.enqueue("ClickRightMouseAt", xyDetail(x, y), func() error { return ClickRightMouseAt(x, y) })
+}
+
+// ClickMiddleMouseAt enqueues the package-level ClickMiddleMouseAt.
+func (sq *Sequence) ClickMiddleMouseAt(x, y int32) {
+	sq.enqueue("ClickMiddleMouseAt", xyDetail(x, y), func() error { return ClickMiddleMouseAt(x, y) })
+}
+
+// KeyDown enqueues the package-level KeyDown.
+func (sq *Sequence) KeyDown(k Key) {
+	sq.enqueue("KeyDown", keyDetail(isSensitive(), k), func() error { return KeyDown(k) })
+}
+
+// KeyUp enqueues the package-level KeyUp.
+func (sq *Sequence) KeyUp(k Key) {
+	sq.enqueue("KeyUp", keyDetail(isSensitive(), k), func() error { return KeyUp(k) })
+}
+
+// Press enqueues the package-level Press.
+func (sq *Sequence) Press(k Key) {
+	sq.enqueue("Press", keyDetail(isSensitive(), k), func() error { return Press(k) })
+}
+
+// PressHotkey enqueues the package-level PressHotkey.
+func (sq *Sequence) PressHotkey(keys ...Key) {
+	sq.enqueue("PressHotkey", keyDetail(isSensitive(), keys...), func() error { return PressHotkey(keys...) })
+}
+
+// Type enqueues the package-level Type.
+func (sq *Sequence) Type(text string) {
+	sq.enqueue("Type", textDetail(text, isSensitive()), func() error { return Type(text) })
+}
+
+// -----------------------------------------------------------------------------
+// Window Input (Sequence)
+// -----------------------------------------------------------------------------
+
+// SequenceWindow exposes the *Window input methods on a Sequence.
+type SequenceWindow struct {
+	sq *Sequence
+	w  *Window
+}
+
+// Window binds w to the sequence so its input methods are enqueued on it.
+func (sq *Sequence) Window(w *Window) *SequenceWindow {
+	return &SequenceWindow{sq: sq, w: w}
+}
+
+func (sw *SequenceWindow) enqueue(name, detail string, do func() error) {
+	sw.sq.enqueue("Window."+name, fmt.Sprintf("hwnd=0x%X %s", sw.w.HWND, detail), do)
+}
+
+// Move enqueues Window.Move.
+func (sw *SequenceWindow) Move(x, y int32) {
+	sw.enqueue("Move", xyDetail(x, y), func() error { return sw.w.Move(x, y) })
+}
+
+// MoveRel enqueues Window.MoveRel.
+func (sw *SequenceWindow) MoveRel(dx, dy int32) {
+	sw.enqueue("MoveRel", xyDetail(dx, dy), func() error { return sw.w.MoveRel(dx, dy) })
+}
+
+// Click enqueues Window.Click.
+func (sw *SequenceWindow) Click(x, y int32) {
+	sw.enqueue("Click", xyDetail(x, y), func() error { return sw.w.Click(x, y) })
+}
+
+// ClickRight enqueues Window.ClickRight.
+func (sw *SequenceWindow) ClickRight(x, y int32) {
+	sw.enqueue("ClickRight", xyDetail(x, y), func() error { return sw.w.ClickRight(x, y) })
+}
+
+// ClickMiddle enqueues Window.ClickMiddle.
+func (sw *SequenceWindow) ClickMiddle(x, y int32) {
+	sw.enqueue("ClickMiddle", xyDetail(x, y), func() error { return sw.w.ClickMiddle(x, y) })
+}
+
+// DoubleClick enqueues Window.DoubleClick.
+func (sw *SequenceWindow) DoubleClick(x, y int32) {
+	sw.enqueue("DoubleClick", xyDetail(x, y), func() error { return sw.w.DoubleClick(x, y) })
+}
+
+// Scroll enqueues Window.Scroll.
+func (sw *SequenceWindow) Scroll(x, y int32, delta int32) {
+	sw.enqueue("Scroll", fmt.Sprintf("%s delta=%d", xyDetail(x, y), delta), func() error { return sw.w.Scroll(x, y, delta) })
+}
+
+// KeyDown enqueues Window.KeyDown.
+func (sw *SequenceWindow) KeyDown(key Key) {
+	sw.enqueue("KeyDown", keyDetail(isSensitive(), key), func() error { return sw.w.KeyDown(key) })
+}
+
+// KeyUp enqueues Window.KeyUp.
+func (sw *SequenceWindow) KeyUp(key Key) {
+	sw.enqueue("KeyUp", keyDetail(isSensitive(), key), func() error { return sw.w.KeyUp(key) })
+}
+
+// Press enqueues Window.Press.
+func (sw *SequenceWindow) Press(key Key) {
+	sw.enqueue("Press", keyDetail(isSensitive(), key), func() error { return sw.w.Press(key) })
+}
+
+// PressHotkey enqueues Window.PressHotkey.
+func (sw *SequenceWindow) PressHotkey(keys ...Key) {
+	sw.enqueue("PressHotkey", keyDetail(isSensitive(), keys...), func() error { return sw.w.PressHotkey(keys...) })
+}
+
+// Type enqueues Window.Type.
+func (sw *SequenceWindow) Type(text string) {
+	sw.TypeWithOptions(text, TypeOptions{})
+}
+
+// TypeWithOptions enqueues Window.TypeWithOptions.
+func (sw *SequenceWindow) TypeWithOptions(text string, opts TypeOptions) {
+	sw.enqueue("Type", textDetail(text, isSensitive()), func() error { return sw.w.TypeWithOptions(text, opts) })
+}
+
+func xyDetail(x, y int32) string {
+	return fmt.Sprintf("(%d,%d)", x, y)
+}
@@ -0,0 +1,578 @@
+//go:build windows
+
+package winput
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rpdg/winput/hid"
+	"github.com/rpdg/winput/mouse"
+	"github.com/rpdg/winput/window"
+)
+
+// inputSem serializes all input operations. It is a channel rather than a sync.Mutex
+// so that AcquireSession can wait on it with a context.
+var (
+	inputSem      = make(chan struct{}, 1)
+	activeSession atomic.Pointer[Session]
+
+	// failFastCh is closed, and replaced, whenever a FailFast session starts, waking
+	// single operations waiting on inputSem so that they fail instead of waiting it out.
+	failFastMu sync.Mutex
+	failFastCh = make(chan struct{})
+)
+
+// lockInput acquires the global input lock for a single operation.
+// Callers MUST call the returned unlock function when done.
+func lockInput() (func(), error) {
+	if instrumented.Load() {
+		return lockInputInstrumented(callerOp())
+	}
+	if err := acquireInputSem(context.Background(), false); err != nil {
+		return nil, err
+	}
+	xunlock, err := lockCrossProcess()
+	if err != nil {
+		<-inputSem
+		return nil, err
+	}
+	return func() {
+		xunlock()
+		<-inputSem
+	}, nil
+}
+
+// acquireInputSem takes inputSem, or returns ctx.Err() once ctx is done. For a single
+// operation (session false) it fails with ErrSessionActive as soon as a FailFast session
+// holds the lock, including one acquired while the caller waits: checking first and
+// waiting afterwards would block a caller behind a session that started in between.
+func acquireInputSem(ctx context.Context, session bool) error {
+	for {
+		failFastMu.Lock()
+		started := failFastCh
+		failFastMu.Unlock()
+		if s := activeSession.Load(); !session && s != nil && s.opts.FailFast {
+			return ErrSessionActive
+		}
+		select {
+		case inputSem <- struct{}{}:
+			return nil
+		case <-started:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// signalFailFast wakes the operations waiting in acquireInputSem. It must be called after
+// the FailFast session has been stored in activeSession.
+func signalFailFast() {
+	failFastMu.Lock()
+	defer failFastMu.Unlock()
+	close(failFastCh)
+	failFastCh = make(chan struct{})
+}
+
+// SessionOptions configures AcquireSessionWithOptions.
+type SessionOptions struct {
+	// MaxHold is the longest the session may be held before it is released automatically.
+	// 0 means the default (30s).
+	MaxHold time.Duration
+	// FailFast makes input calls from outside the session return ErrSessionActive
+	// instead of blocking until the session is released.
+	FailFast bool
+}
+
+var defaultSessionOptions = SessionOptions{
+	MaxHold: 30 * time.Second,
+}
+
+// heldKey identifies a key pressed through a Session (hwnd 0 = global).
+type heldKey struct {
+	hwnd uintptr
+	key  Key
+}
+
+// heldButton identifies a mouse button pressed through a Session (hwnd 0 = global).
+type heldButton struct {
+	hwnd   uintptr
+	button MouseButton
+}
+
+// Session grants exclusive access to all input APIs until Release is called.
+// Calls made through the Session run without interleaving with any other caller;
+// calls made through the package-level API or *Window block (or fail fast) meanwhile.
+type Session struct {
+	mu       sync.Mutex // held for the duration of each operation
+	opts     SessionOptions
+	held     map[heldKey]struct{}
+	buttons  map[heldButton]Point // client point of the press; unused for global presses
+	timer    *time.Timer
+	xunlock  func() // releases the cross-process lock, if taken
+	released bool
+	expired  bool
+}
+
+// AcquireSession waits until exclusive input access is available or ctx is done.
+func AcquireSession(ctx context.Context) (*Session, error) {
+	return AcquireSessionWithOptions(ctx, defaultSessionOptions)
+}
+
+// AcquireSessionWithOptions is AcquireSession with custom options.
+func AcquireSessionWithOptions(ctx context.Context, opts SessionOptions) (*Session, error) {
+	if opts.MaxHold <= 0 {
+		opts.MaxHold = defaultSessionOptions.MaxHold
+	}
+
+	if instrumented.Load() {
+		w, err := waitInputSem(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		statsMu.Lock()
+		recordLockWait(w)
+		statsMu.Unlock()
+	} else if err := acquireInputSem(ctx, true); err != nil {
+		return nil, err
+	}
+
+	xunlock, err := lockCrossProcess()
+	if err != nil {
+		<-inputSem
+		return nil, err
+	}
+
+	s := &Session{
+		opts:    opts,
+		held:    make(map[heldKey]struct{}),
+		buttons: make(map[heldButton]Point),
+		xunlock: xunlock,
+	}
+	activeSession.Store(s)
+	if opts.FailFast {
+		signalFailFast()
+	}
+	if instrumented.Load() {
+		setInputHolder("Session", nil)
+	}
+	s.timer = time.AfterFunc(opts.MaxHold, s.expire)
+	return s, nil
+}
+
+// Release releases any keys and mouse buttons still held through the session and gives up
+// exclusive access.
+// It is safe to call Release more than once.
+func (s *Session) Release() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.releaseLocked()
+}
+
+func (s *Session) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.released {
+		return
+	}
+	s.expired = true
+	s.releaseLocked()
+}
+
+func (s *Session) releaseLocked() error {
+	if s.released {
+		return nil
+	}
+	s.released = true
+	s.timer.Stop()
+
+	err := s.releaseHeld()
+	s.held, s.buttons = nil, nil
+
+	s.xunlock()
+	activeSession.CompareAndSwap(s, nil)
+	setInputHolder("", nil)
+	<-inputSem
+	return err
+}
+
+// releaseHeld releases every key and mouse button still held through the session.
+// s.mu must be held.
+func (s *Session) releaseHeld() error {
+	var errs []error
+	cb := getBackend()
+	for hb, p := range s.buttons {
+		if err := releaseButton(cb, hb, p); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(s.buttons, hb)
+	}
+	for hk := range s.held {
+		if err := keyUpImpl(cb, hk.hwnd, hk.key); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delete(s.held, hk)
+	}
+	return errors.Join(errs...)
+}
+
+// releaseButton releases a button left held through a session. A window's button is posted
+// up at the point it went down; a global one, or any under the HID backend (where the
+// physical button is down), is released where the cursor is now, without moving it.
+func releaseButton(cb Backend, hb heldButton, p Point) error {
+	if cb == BackendHID {
+		x, y, err := window.GetCursorPos()
+		if err != nil {
+			return err
+		}
+		return hid.Up(x, y, hb.button.hid())
+	}
+	if hb.hwnd != 0 {
+		return mouse.Up(hb.hwnd, hb.button.message(), p.X, p.Y)
+	}
+	_, upFlag, data := hb.button.events()
+	window.ProcMouseEvent.Call(upFlag, 0, 0, data, 0)
+	return nil
+}
+
+// do runs fn while holding the session, failing if it has been released.
+func (s *Session) do(fn func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.released {
+		if s.expired {
+			return ErrSessionExpired
+		}
+		return ErrSessionReleased
+	}
+	return fn()
+}
+
+func (s *Session) trackDown(hwnd uintptr, k Key, err error) error {
+	if err == nil {
+		s.held[heldKey{hwnd, k}] = struct{}{}
+	}
+	return err
+}
+
+func (s *Session) trackUp(hwnd uintptr, k Key, err error) error {
+	if err == nil {
+		delete(s.held, heldKey{hwnd, k})
+	}
+	return err
+}
+
+// trackButton records a button press (down) or release through the session. A press that
+// failed only on the work area guard was still performed, so it is tracked too.
+func (s *Session) trackButton(hwnd uintptr, b MouseButton, down bool, x, y int32, err error) error {
+	if err != nil && !errors.Is(err, ErrOutsideWorkArea) {
+		return err
+	}
+	if down {
+		s.buttons[heldButton{hwnd, b}] = Point{X: x, Y: y}
+	} else {
+		delete(s.buttons, heldButton{hwnd, b})
+	}
+	return err
+}
+
+// -----------------------------------------------------------------------------
+// Global Input (Session)
+// -----------------------------------------------------------------------------
+
+// MoveMouseTo is the session equivalent of the package-level MoveMouseTo.
+func (s *Session) MoveMouseTo(x, y int32) error {
+	return s.do(func() error { return moveMouseTo(x, y) })
+}
+
+// ClickMouseAt is the session equivalent of the package-level ClickMouseAt.
+func (s *Session) ClickMouseAt(x, y int32) error {
+	return s.do(func() error { return clickMouseAt(x, y) })
+}
+
+// ClickXMouseAt is the session equivalent of the package-level ClickXMouseAt.
+func (s *Session) ClickXMouseAt(x, y int32, which int) error {
+	return s.do(func() error { return clickXMouseAt(x, y, which) })
+}
+
+// DoubleClickMouseAt is the session equivalent of the package-level DoubleClickMouseAt.
+func (s *Session) DoubleClickMouseAt(x, y int32) error {
+	return s.do(func() error { return doubleClickMouseAt(x, y) })
+}
+
+// ClickRightMouseAt is the session equivalent of the package-level ClickRightMouseAt.
+func (s *Session) ClickRightMouseAt(x, y int32) error {
+	return s.do(func() error { return clickRightMouseAt(x, y) })
+}
+
+// ClickMiddleMouseAt is the session equivalent of the package-level ClickMiddleMouseAt.
+func (s *Session) ClickMiddleMouseAt(x, y int32) error {
+	return s.do(func() error { return clickMiddleMouseAt(x, y) })
+}
+
+// DragMouse is the session equivalent of the package-level DragMouse.
+func (s *Session) DragMouse(fromX, fromY, toX, toY int32) error {
+	return s.do(func() error { return dragMouse(fromX, fromY, toX, toY) })
+}
+
+// MouseDownAt is the session equivalent of the package-level MouseDownAt.
+// The button is released automatically on Release if MouseUpAt is not called.
+func (s *Session) MouseDownAt(x, y int32, button MouseButton) error {
+	return s.do(func() error {
+		return s.trackButton(0, button, true, x, y, mouseButtonAt(x, y, button, true))
+	})
+}
+
+// MouseUpAt is the session equivalent of the package-level MouseUpAt.
+func (s *Session) MouseUpAt(x, y int32, button MouseButton) error {
+	return s.do(func() error {
+		return s.trackButton(0, button, false, x, y, mouseButtonAt(x, y, button, false))
+	})
+}
+
+// ScrollAt is the session equivalent of the package-level ScrollAt.
+func (s *Session) ScrollAt(x, y int32, delta int32) error {
+	return s.do(func() error { return scrollAt(x, y, delta) })
+}
+
+// ScrollHAt is the session equivalent of the package-level ScrollHAt.
+func (s *Session) ScrollHAt(x, y int32, delta int32) error {
+	return s.do(func() error { return scrollHAt(x, y, delta) })
+}
+
+// DragBetween is the session equivalent of the package-level DragBetween.
+func (s *Session) DragBetween(src *Window, sx, sy int32, dst *Window, dx, dy int32, opts DragOptions) error {
+	return s.do(func() error { return dragBetween(src, sx, sy, dst, dx, dy, opts) })
+}
+
+// KeyDown is the session equivalent of the package-level KeyDown.
+// The key is released automatically on Release if KeyUp is not called.
+func (s *Session) KeyDown(k Key) error {
+	return s.do(func() error { return s.trackDown(0, k, keyDown(k)) })
+}
+
+// KeyUp is the session equivalent of the package-level KeyUp.
+func (s *Session) KeyUp(k Key) error {
+	return s.do(func() error { return s.trackUp(0, k, keyUp(k)) })
+}
+
+// Press is the session equivalent of the package-level Press.
+func (s *Session) Press(k Key) error {
+	return s.do(func() error { return press(k) })
+}
+
+// PressHotkey is the session equivalent of the package-level PressHotkey.
+func (s *Session) PressHotkey(keys ...Key) error {
+	return s.do(func() error { return pressHotkey(keys...) })
+}
+
+// Type is the session equivalent of the package-level Type.
+func (s *Session) Type(text string) error {
+	return s.do(func() error { return typeGlobal(text) })
+}
+
+// TypeIntoForeground is the session equivalent of the package-level TypeIntoForegroundWithOptions.
+func (s *Session) TypeIntoForeground(expected *Window, text string, opts ForegroundTypeOptions) error {
+	return s.do(func() error { return typeIntoForeground(expected, text, opts) })
+}
+
+// -----------------------------------------------------------------------------
+// Window Input (Session)
+// -----------------------------------------------------------------------------
+
+// SessionWindow exposes the *Window input methods under a Session.
+type SessionWindow struct {
+	s *Session
+	w *Window
+}
+
+// Window binds w to the session so its input methods run under exclusive access.
+func (s *Session) Window(w *Window) *SessionWindow {
+	return &SessionWindow{s: s, w: w}
+}
+
+// Move is the session equivalent of Window.Move.
+func (sw *SessionWindow) Move(x, y int32) error {
+	return sw.s.do(func() error { return sw.w.move(x, y) })
+}
+
+// MoveRel is the session equivalent of Window.MoveRel.
+func (sw *SessionWindow) MoveRel(dx, dy int32) error {
+	return sw.s.do(func() error { return sw.w.moveRel(dx, dy) })
+}
+
+// Click is the session equivalent of Window.Click.
+func (sw *SessionWindow) Click(x, y int32) error {
+	return sw.s.do(func() error { return sw.w.click(x, y) })
+}
+
+// ClickRight is the session equivalent of Window.ClickRight.
+func (sw *SessionWindow) ClickRight(x, y int32) error {
+	return sw.s.do(func() error { return sw.w.clickRight(x, y) })
+}
+
+// ClickMiddle is the session equivalent of Window.ClickMiddle.
+func (sw *SessionWindow) ClickMiddle(x, y int32) error {
+	return sw.s.do(func() error { return sw.w.clickMiddle(x, y) })
+}
+
+// DoubleClick is the session equivalent of Window.DoubleClick.
+func (sw *SessionWindow) DoubleClick(x, y int32) error {
+	return sw.s.do(func() error { return sw.w.doubleClick(x, y) })
+}
+
+// ClickX is the session equivalent of Window.ClickX.
+func (sw *SessionWindow) ClickX(x, y int32, which int) error {
+	return sw.s.do(func() error { return sw.w.clickX(x, y, which) })
+}
+
+// ClickWithModifiers is the session equivalent of Window.ClickWithModifiers.
+func (sw *SessionWindow) ClickWithModifiers(x, y int32, mods ...Key) error {
+	return sw.s.do(func() error { return sw.w.clickWithModifiers(x, y, mods) })
+}
+
+// DoubleClickRight is the session equivalent of Window.DoubleClickRight.
+func (sw *SessionWindow) DoubleClickRight(x, y int32) error {
+	return sw.s.do(func() error { return sw.w.multiClick("DoubleClickRight", MouseRight, x, y, 2) })
+}
+
+// MultiClick is the session equivalent of Window.MultiClick.
+func (sw *SessionWindow) MultiClick(button MouseButton, x, y int32, count int) error {
+	return sw.s.do(func() error { return sw.w.multiClick("MultiClick", button, x, y, count) })
+}
+
+// Hover is the session equivalent of Window.Hover.
+func (sw *SessionWindow) Hover(x, y int32, d time.Duration) error {
+	return sw.s.do(func() error { return sw.w.hover(context.Background(), x, y, d) })
+}
+
+// MouseDown is the session equivalent of Window.MouseDown.
+// The button is released automatically on Release if MouseUp is not called.
+func (sw *SessionWindow) MouseDown(button MouseButton, x, y int32) error {
+	return sw.s.do(func() error {
+		return sw.s.trackButton(sw.w.HWND, button, true, x, y, sw.w.mouseButton(button, true, x, y))
+	})
+}
+
+// MouseUp is the session equivalent of Window.MouseUp.
+func (sw *SessionWindow) MouseUp(button MouseButton, x, y int32) error {
+	return sw.s.do(func() error {
+		return sw.s.trackButton(sw.w.HWND, button, false, x, y, sw.w.mouseButton(button, false, x, y))
+	})
+}
+
+// Drag is the session equivalent of Window.Drag.
+func (sw *SessionWindow) Drag(fromX, fromY, toX, toY int32) error {
+	return sw.s.do(func() error { return sw.w.drag(fromX, fromY, toX, toY, DragOptions{}) })
+}
+
+// DragWithOptions is the session equivalent of Window.DragWithOptions.
+func (sw *SessionWindow) DragWithOptions(fromX, fromY, toX, toY int32, opts DragOptions) error {
+	return sw.s.do(func() error { return sw.w.drag(fromX, fromY, toX, toY, opts) })
+}
+
+// Scroll is the session equivalent of Window.Scroll.
+func (sw *SessionWindow) Scroll(x, y int32, delta int32) error {
+	return sw.s.do(func() error { return sw.w.scroll(x, y, delta) })
+}
+
+// ScrollH is the session equivalent of Window.ScrollH.
+func (sw *SessionWindow) ScrollH(x, y int32, delta int32) error {
+	return sw.s.do(func() error { return sw.w.scrollH(x, y, delta) })
+}
+
+// ScrollWithOptions is the session equivalent of Window.ScrollWithOptions.
+func (sw *SessionWindow) ScrollWithOptions(x, y int32, delta int32, opts ScrollOptions) error {
+	return sw.s.do(func() error { return sw.w.scrollWithOptions(x, y, delta, opts) })
+}
+
+// Zoom is the session equivalent of Window.Zoom.
+func (sw *SessionWindow) Zoom(steps int) error {
+	return sw.s.do(func() error { return sw.w.zoom(steps) })
+}
+
+// ScrollAtPoint is the session equivalent of Window.ScrollAtPoint.
+func (sw *SessionWindow) ScrollAtPoint(cx, cy int32, delta int32) error {
+	return sw.s.do(func() error { return sw.w.scrollAtPoint(cx, cy, delta) })
+}
+
+// Activate is the session equivalent of Window.Activate.
+func (sw *SessionWindow) Activate() error {
+	return sw.s.do(func() error {
+		if !sw.w.IsValid() {
+			return ErrWindowGone
+		}
+		return sw.w.activate()
+	})
+}
+
+// Focus is the session equivalent of Window.Focus.
+func (sw *SessionWindow) Focus() error {
+	return sw.s.do(func() error {
+		if !sw.w.IsValid() {
+			return ErrWindowGone
+		}
+		return sw.w.focus()
+	})
+}
+
+// KeyDown is the session equivalent of Window.KeyDown.
+// The key is released automatically on Release if KeyUp is not called.
+func (sw *SessionWindow) KeyDown(key Key) error {
+	return sw.s.do(func() error { return sw.s.trackDown(sw.w.HWND, key, sw.w.keyDown(key)) })
+}
+
+// KeyUp is the session equivalent of Window.KeyUp.
+func (sw *SessionWindow) KeyUp(key Key) error {
+	return sw.s.do(func() error { return sw.s.trackUp(sw.w.HWND, key, sw.w.keyUp(key)) })
+}
+
+// Press is the session equivalent of Window.Press.
+func (sw *SessionWindow) Press(key Key) error {
+	return sw.s.do(func() error { return sw.w.press(key) })
+}
+
+// PressHotkey is the session equivalent of Window.PressHotkey.
+func (sw *SessionWindow) PressHotkey(keys ...Key) error {
+	return sw.s.do(func() error { return sw.w.pressHotkey(keys...) })
+}
+
+// Type is the session equivalent of Window.Type.
+func (sw *SessionWindow) Type(text string) error {
+	return sw.TypeWithOptions(text, TypeOptions{})
+}
+
+// TypeWithOptions is the session equivalent of Window.TypeWithOptions.
+func (sw *SessionWindow) TypeWithOptions(text string, opts TypeOptions) error {
+	return sw.s.do(func() error { return sw.w.typeText(text, opts) })
+}
+
+// TypeBidi is the session equivalent of Window.TypeBidi.
+func (sw *SessionWindow) TypeBidi(text string) error {
+	return sw.s.do(func() error { return sw.w.typeBidi(text) })
+}
+
+// ReplaceText is the session equivalent of Window.ReplaceText.
+func (sw *SessionWindow) ReplaceText(text string) error {
+	return sw.s.do(func() error { return sw.w.replaceText(text) })
+}
+
+// Command is the session equivalent of Window.Command.
+func (sw *SessionWindow) Command(id uint16) error {
+	return sw.s.do(func() error { return sw.w.command(id) })
+}
+
+// SysCommand is the session equivalent of Window.SysCommand.
+func (sw *SessionWindow) SysCommand(sc uintptr) error {
+	return sw.s.do(func() error { return sw.w.sysCommand(sc) })
+}
+
+// ActivateLayout is the session equivalent of Window.ActivateLayout.
+func (sw *SessionWindow) ActivateLayout(klid string) error {
+	return sw.s.do(func() error { return sw.w.activateLayout(klid) })
+}
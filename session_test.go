@@ -0,0 +1,368 @@
+//go:build windows
+
+package winput_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rpdg/winput"
+)
+
+func TestSession(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+
+	t.Run("Contention", func(t *testing.T) {
+		s, err := winput.AcquireSession(context.Background())
+		if err != nil {
+			t.Fatalf("AcquireSession failed: %v", err)
+		}
+		defer s.Release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		if _, err := winput.AcquireSession(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected DeadlineExceeded while session is held, got %v", err)
+		}
+
+		// Package-level calls block until the session is released.
+		done := make(chan error, 1)
+		go func() { done <- winput.KeyUp(winput.KeyShift) }()
+
+		select {
+		case <-done:
+			t.Fatal("global KeyUp ran while session was held")
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		s.Release()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("global KeyUp did not run after Release")
+		}
+	})
+
+	t.Run("FailFast", func(t *testing.T) {
+		s, err := winput.AcquireSessionWithOptions(context.Background(), winput.SessionOptions{FailFast: true})
+		if err != nil {
+			t.Fatalf("AcquireSession failed: %v", err)
+		}
+		defer s.Release()
+
+		if err := winput.KeyUp(winput.KeyShift); !errors.Is(err, winput.ErrSessionActive) {
+			t.Fatalf("expected ErrSessionActive, got %v", err)
+		}
+	})
+
+	t.Run("FailFastWhileWaiting", func(t *testing.T) {
+		s, err := winput.AcquireSession(context.Background())
+		if err != nil {
+			t.Fatalf("AcquireSession failed: %v", err)
+		}
+		done := make(chan error, 1)
+		go func() { done <- winput.KeyUp(winput.KeyShift) }()
+		acquired := make(chan *winput.Session, 1)
+		go func() {
+			ff, _ := winput.AcquireSessionWithOptions(context.Background(), winput.SessionOptions{FailFast: true})
+			acquired <- ff
+		}()
+		time.Sleep(50 * time.Millisecond)
+		s.Release()
+
+		// Whichever waiter wins, KeyUp must not block behind the FailFast session.
+		ff := <-acquired
+		defer ff.Release()
+		select {
+		case err := <-done:
+			if err != nil && !errors.Is(err, winput.ErrSessionActive) {
+				t.Fatalf("KeyUp = %v, want nil or ErrSessionActive", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("KeyUp blocked behind a FailFast session")
+		}
+	})
+
+	t.Run("ExpiryReleasesButton", func(t *testing.T) {
+		ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer ow.Close()
+
+		s, err := winput.AcquireSessionWithOptions(context.Background(), winput.SessionOptions{MaxHold: 50 * time.Millisecond})
+		if err != nil {
+			t.Fatalf("AcquireSession failed: %v", err)
+		}
+		if err := s.Window(ow.Window).MouseDown(winput.MouseLeft, 5, 5); err != nil {
+			t.Fatalf("MouseDown failed: %v", err)
+		}
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			m, err := ow.NextMessage(time.Until(deadline))
+			if err != nil {
+				t.Fatal("WM_LBUTTONUP not received after the session expired")
+			}
+			if m.Msg == 0x0202 { // WM_LBUTTONUP
+				break
+			}
+		}
+		if err := s.Window(ow.Window).MouseUp(winput.MouseLeft, 5, 5); !errors.Is(err, winput.ErrSessionExpired) {
+			t.Errorf("MouseUp after expiry = %v, want ErrSessionExpired", err)
+		}
+	})
+
+	t.Run("ExpiryMidSequence", func(t *testing.T) {
+		s, err := winput.AcquireSessionWithOptions(context.Background(), winput.SessionOptions{MaxHold: 50 * time.Millisecond})
+		if err != nil {
+			t.Fatalf("AcquireSession failed: %v", err)
+		}
+		if err := s.KeyUp(winput.KeyShift); err != nil {
+			t.Fatalf("first step failed: %v", err)
+		}
+
+		time.Sleep(150 * time.Millisecond)
+
+		if err := s.KeyUp(winput.KeyShift); !errors.Is(err, winput.ErrSessionExpired) {
+			t.Fatalf("expected ErrSessionExpired, got %v", err)
+		}
+
+		// The expired session must not block others.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		s2, err := winput.AcquireSession(ctx)
+		if err != nil {
+			t.Fatalf("AcquireSession after expiry failed: %v", err)
+		}
+		s2.Release()
+	})
+
+	t.Run("ReleaseIdempotent", func(t *testing.T) {
+		s, err := winput.AcquireSession(context.Background())
+		if err != nil {
+			t.Fatalf("AcquireSession failed: %v", err)
+		}
+		s.Release()
+		if err := s.Release(); err != nil {
+			t.Fatalf("second Release failed: %v", err)
+		}
+		if err := s.Press(winput.KeyShift); !errors.Is(err, winput.ErrSessionReleased) {
+			t.Fatalf("expected ErrSessionReleased, got %v", err)
+		}
+	})
+
+	t.Run("SlowCall", func(t *testing.T) {
+		reports := make(chan winput.SlowCall, 1)
+		winput.SetSlowCallThreshold(20*time.Millisecond, func(c winput.SlowCall) { reports <- c })
+		defer winput.SetSlowCallThreshold(0, nil)
+		winput.ResetStats()
+
+		s, err := winput.AcquireSession(context.Background())
+		if err != nil {
+			t.Fatalf("AcquireSession failed: %v", err)
+		}
+		done := make(chan error, 1)
+		go func() { done <- winput.KeyUp(winput.KeyShift) }()
+		time.Sleep(50 * time.Millisecond)
+		s.Release()
+		if err := <-done; err != nil {
+			t.Fatalf("KeyUp failed: %v", err)
+		}
+
+		select {
+		case c := <-reports:
+			if c.Op != "KeyUp" || c.Duration < 40*time.Millisecond || len(c.Waits) == 0 {
+				t.Fatalf("unexpected report: %+v", c)
+			}
+			if w := c.Waits[0]; w.Lock != winput.LockInput || w.Holder != "Session" || w.Wait < 40*time.Millisecond {
+				t.Errorf("unexpected input lock wait: %+v", w)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("no slow call reported")
+		}
+
+		st := winput.Stats()
+		if in := st.Locks[winput.LockInput]; in.Contended == 0 || in.MaxWaitHolder != "Session" || st.SlowCalls != 1 {
+			t.Errorf("unexpected stats: %+v", st)
+		}
+	})
+
+	t.Run("CallHook", func(t *testing.T) {
+		var calls []winput.SlowCall
+		winput.SetCallHook(func(c winput.SlowCall) { calls = append(calls, c) })
+		defer winput.SetCallHook(nil)
+
+		// A fast, uncontended call is reported too, with its zero input lock wait.
+		if err := winput.KeyUp(winput.KeyShift); err != nil {
+			t.Fatalf("KeyUp failed: %v", err)
+		}
+		if len(calls) != 1 || calls[0].Op != "KeyUp" || len(calls[0].Waits) == 0 {
+			t.Fatalf("unexpected reports: %+v", calls)
+		}
+		if w := calls[0].Waits[0]; w.Lock != winput.LockInput || w.Wait != 0 {
+			t.Errorf("unexpected input lock wait: %+v", w)
+		}
+	})
+}
+
+func TestRunBatch(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+
+	errA := errors.New("step a failed")
+	errC := errors.New("step c failed")
+	var ran []string
+	step := func(name string, err error) winput.Step {
+		return winput.Step{Name: name, Do: func(*winput.Session) error {
+			ran = append(ran, name)
+			return err
+		}}
+	}
+	steps := []winput.Step{step("a", errA), step("b", nil), step("c", errC)}
+
+	t.Run("StopOnError", func(t *testing.T) {
+		ran = nil
+		res, err := winput.RunBatch(context.Background(), steps, winput.BatchOptions{})
+		if !errors.Is(err, errA) || errors.Is(err, errC) {
+			t.Fatalf("expected only errA, got %v", err)
+		}
+		if len(ran) != 1 || !res.Steps[1].Skipped || !res.Steps[2].Skipped {
+			t.Errorf("expected the batch to stop after a: ran %v, results %+v", ran, res.Steps)
+		}
+	})
+
+	t.Run("ContinueOnError", func(t *testing.T) {
+		ran = nil
+		res, err := winput.RunBatch(context.Background(), steps, winput.BatchOptions{ContinueOnError: true})
+		if !errors.Is(err, errA) || !errors.Is(err, errC) {
+			t.Fatalf("expected errA and errC, got %v", err)
+		}
+		var be *winput.BatchError
+		if !errors.As(err, &be) || len(be.Failed) != 2 || be.Total != 3 {
+			t.Errorf("unexpected BatchError: %#v", err)
+		}
+		if len(ran) != 3 || res.Steps[1].Err != nil || res.Steps[1].Skipped {
+			t.Errorf("expected every step to run: ran %v, results %+v", ran, res.Steps)
+		}
+	})
+
+	t.Run("ReleasesHeldKeys", func(t *testing.T) {
+		steps := []winput.Step{
+			{Name: "hold", Do: func(s *winput.Session) error {
+				if err := s.KeyDown(winput.KeyShift); err != nil {
+					return err
+				}
+				return errA
+			}},
+			{Name: "release", Do: func(s *winput.Session) error {
+				// Shift was already released by the cleanup; releasing again must be harmless.
+				return s.KeyUp(winput.KeyShift)
+			}},
+		}
+		res, err := winput.RunBatch(context.Background(), steps, winput.BatchOptions{ContinueOnError: true})
+		if !errors.Is(err, errA) || res.Steps[1].Err != nil {
+			t.Fatalf("unexpected result: %v %+v", err, res.Steps)
+		}
+	})
+
+	t.Run("ReleasesHeldButtons", func(t *testing.T) {
+		ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer ow.Close()
+
+		steps := []winput.Step{
+			{Name: "press", Do: func(s *winput.Session) error {
+				if err := s.Window(ow.Window).MouseDown(winput.MouseLeft, 5, 5); err != nil {
+					return err
+				}
+				return errA
+			}},
+			{Name: "check", Do: func(*winput.Session) error {
+				// The cleanup must have released the button before this step runs.
+				for {
+					m, err := ow.NextMessage(time.Second)
+					if err != nil {
+						return errors.New("WM_LBUTTONUP not received before the next step")
+					}
+					if m.Msg == 0x0202 { // WM_LBUTTONUP
+						return nil
+					}
+				}
+			}},
+		}
+		res, err := winput.RunBatch(context.Background(), steps, winput.BatchOptions{ContinueOnError: true})
+		if !errors.Is(err, errA) || res.Steps[1].Err != nil {
+			t.Fatalf("unexpected result: %v %+v", err, res.Steps)
+		}
+	})
+}
+
+func TestSequence(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+
+	errB := errors.New("b failed")
+	sq := winput.NewSequence()
+	var got []int
+	// Issue from several goroutines, each waiting for the previous issue to return:
+	// execution must follow issue order even though the actions run concurrently with issuing.
+	for i := 0; i < 20; i++ {
+		issued := make(chan struct{})
+		go func() {
+			sq.Do(fmt.Sprint(i), func() error {
+				time.Sleep(time.Millisecond)
+				got = append(got, i)
+				if i == 5 {
+					return errB
+				}
+				return nil
+			})
+			close(issued)
+		}()
+		<-issued
+	}
+	sq.KeyUp(winput.KeyShift)
+
+	res, err := sq.Wait()
+	if !errors.Is(err, errB) || len(res.Failed()) != 1 || res.Failed()[0].Name != "5" {
+		t.Fatalf("expected only step 5 to fail, got %v", err)
+	}
+	if len(res.Steps) != 21 || res.Steps[20].Name != "KeyUp" || res.Steps[20].Err != nil {
+		t.Fatalf("unexpected results: %+v", res.Steps)
+	}
+	for i, v := range got {
+		if v != i {
+			t.Fatalf("actions ran out of order: %v", got)
+		}
+	}
+
+	// Results are reset by Wait.
+	if res, err := sq.Wait(); err != nil || len(res.Steps) != 0 {
+		t.Errorf("second Wait returned %v, %+v", err, res.Steps)
+	}
+
+	t.Run("Sensitive", func(t *testing.T) {
+		noop := func() error { return nil }
+		winput.WithSensitive(func() error {
+			sq.Do("secret", noop)
+			sq.Window(&winput.Window{}).Type("hunter2")
+			return nil
+		})
+		sq.Do("plain", noop)
+		res, _ := sq.Wait()
+		if len(res.Steps) != 3 {
+			t.Fatalf("unexpected results: %+v", res.Steps)
+		}
+		if !res.Steps[0].Sensitive || !res.Steps[1].Sensitive || res.Steps[2].Sensitive {
+			t.Errorf("Sensitive flags = %v %v %v, want true true false",
+				res.Steps[0].Sensitive, res.Steps[1].Sensitive, res.Steps[2].Sensitive)
+		}
+		if d := res.Steps[1].Detail; strings.Contains(d, "hunter2") || !strings.Contains(d, "len=7") {
+			t.Errorf("Type detail = %q, want the text redacted to its length", d)
+		}
+	})
+}
@@ -0,0 +1,58 @@
+//go:build windows
+
+package winput
+
+import (
+	"sync"
+
+	"github.com/rpdg/winput/keyboard"
+	"github.com/rpdg/winput/window"
+)
+
+// windowSettings holds per-window behavior overrides. They are keyed by HWND rather than
+// stored on Window so that every *Window value referring to the same handle shares them.
+type windowSettings struct {
+	// tid is the thread that owned the window when the settings were stored. A handle
+	// recycled for another window almost always belongs to a different thread, so a
+	// mismatch means the settings are stale.
+	tid           uint32
+	bidiClipboard bool
+	timing        *Timing // nil means the global timing
+	burst         *burst  // active BeginBurst snapshot, if any
+	readyWait     *readyWait
+	runes         keyboard.RuneMap // replaced, never mutated, by RegisterRune
+}
+
+var settingsByHWND sync.Map // HWND -> *windowSettings
+
+// settingsFor returns the settings of hwnd. The entry of a window that is gone, or whose
+// handle now belongs to another thread, is dropped and the defaults are returned.
+func settingsFor(hwnd uintptr) windowSettings {
+	v, ok := settingsByHWND.Load(hwnd)
+	if !ok {
+		return windowSettings{}
+	}
+	s := v.(*windowSettings)
+	if tid, _ := window.GetThreadProcessID(hwnd); tid == 0 || tid != s.tid {
+		settingsByHWND.CompareAndDelete(hwnd, v)
+		return windowSettings{}
+	}
+	return *s
+}
+
+var settingsMu sync.Mutex
+
+// updateSettings applies fn to the settings of hwnd. Nothing is stored for a window that
+// is gone.
+func updateSettings(hwnd uintptr, fn func(*windowSettings)) {
+	settingsMu.Lock()
+	defer settingsMu.Unlock()
+	s := settingsFor(hwnd)
+	fn(&s)
+	s.tid, _ = window.GetThreadProcessID(hwnd)
+	if s.tid == 0 {
+		settingsByHWND.Delete(hwnd)
+		return
+	}
+	settingsByHWND.Store(hwnd, &s)
+}
@@ -0,0 +1,50 @@
+//go:build windows
+
+package winput
+
+import "github.com/rpdg/winput/window"
+
+// Minimize minimizes the window (SW_MINIMIZE), activating the next top-level window.
+// Input APIs return ErrWindowMinimized for a minimized window until it is restored.
+func (w *Window) Minimize() error {
+	return w.showWindow(window.SW_MINIMIZE)
+}
+
+// Maximize maximizes and activates the window (SW_MAXIMIZE), restoring it first if it
+// is minimized.
+func (w *Window) Maximize() error {
+	return w.showWindow(window.SW_MAXIMIZE)
+}
+
+// Restore returns a minimized or maximized window to its normal size and position and
+// activates it (SW_RESTORE). It does nothing for a window that is already visible in its
+// normal state, so it is safe to call whenever an input call returns ErrWindowMinimized:
+//
+//	if err := w.Click(x, y); errors.Is(err, winput.ErrWindowMinimized) {
+//		w.Restore()
+//		err = w.Click(x, y)
+//	}
+func (w *Window) Restore() error {
+	if window.IsVisible(w.HWND) && !window.IsIconic(w.HWND) && !window.IsZoomed(w.HWND) {
+		return nil // IsVisible is false for an invalid handle
+	}
+	return w.showWindow(window.SW_RESTORE)
+}
+
+// Hide hides the window (SW_HIDE). It keeps running but has no taskbar button until Show.
+func (w *Window) Hide() error {
+	return w.showWindow(window.SW_HIDE)
+}
+
+// Show shows a hidden window in its current size and position and activates it (SW_SHOW).
+func (w *Window) Show() error {
+	return w.showWindow(window.SW_SHOW)
+}
+
+func (w *Window) showWindow(cmd int) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	window.ShowWindow(w.HWND, cmd)
+	return nil
+}
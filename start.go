@@ -0,0 +1,90 @@
+//go:build windows
+
+package winput
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// StartAndFind starts the executable at path (looked up in PATH if it has no directory)
+// and waits up to timeout for its main window (see MainWindowOfPID) to be visible.
+// It returns the window and the process that owns it.
+//
+// Some executables are launchers that hand off to another process (modern Notepad is one).
+// While waiting, StartAndFind therefore also considers processes with the same executable
+// name that were not running before the start; the returned process is then that one.
+// On failure, the started process and any same-name processes that appeared since the start
+// are killed, and the error matches ErrWindowNotFound.
+func StartAndFind(path string, args []string, timeout time.Duration) (*Window, *os.Process, error) {
+	name := filepath.Base(path)
+	before := make(map[uint32]bool)
+	if pids, err := window.FindPIDsByName(name); err == nil {
+		for _, pid := range pids {
+			before[pid] = true
+		}
+	}
+
+	cmd := exec.Command(path, args...)
+	if err := cmd.Start(); err != nil {
+		return nil, nil, err
+	}
+	child := uint32(cmd.Process.Pid)
+
+	var owner uint32
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	w, err := waitForWindow(ctx, fmt.Sprintf("process %q", name), func() (*Window, error) {
+		if found, err := MainWindowOfPID(child); err == nil {
+			owner = child
+			return found, nil
+		}
+		pids, _ := window.FindPIDsByName(name)
+		for _, pid := range pids {
+			if pid == child || before[pid] {
+				continue
+			}
+			if found, err := MainWindowOfPID(pid); err == nil {
+				owner = pid
+				return found, nil
+			}
+		}
+		return nil, ErrWindowNotFound
+	})
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Process.Release()
+		killNewPIDs(name, before, child)
+		return nil, nil, err
+	}
+	if owner == child {
+		return w, cmd.Process, nil
+	}
+	cmd.Process.Release()
+	p, err := os.FindProcess(int(owner))
+	if err != nil {
+		return nil, nil, err
+	}
+	return w, p, nil
+}
+
+// killNewPIDs kills the processes named name that are neither in before nor child, i.e.
+// those a launcher may have handed off to.
+func killNewPIDs(name string, before map[uint32]bool, child uint32) {
+	pids, _ := window.FindPIDsByName(name)
+	for _, pid := range pids {
+		if pid == child || before[pid] {
+			continue
+		}
+		if p, err := os.FindProcess(int(pid)); err == nil {
+			p.Kill()
+			p.Release()
+		}
+	}
+}
@@ -0,0 +1,2441 @@
+// Code generated by internal/stubgen; DO NOT EDIT.
+
+//go:build !windows
+
+package winput
+
+import (
+	"context"
+	"github.com/rpdg/winput/hid"
+	"github.com/rpdg/winput/keyboard"
+	"github.com/rpdg/winput/screen"
+	"github.com/rpdg/winput/window"
+	"image"
+	"os"
+	"regexp"
+	"time"
+)
+
+// Step is one unit of work in a batch. Do receives the batch's Session and should send
+// input through it, so that keys it leaves held can be released if it fails.
+type Step struct {
+	Name string
+
+	Detail string
+	Do     func(s *Session) error
+}
+
+// BatchOptions configures RunBatch.
+type BatchOptions struct {
+	ContinueOnError bool
+
+	Session SessionOptions
+}
+
+// StepResult is the outcome of one Step.
+type StepResult struct {
+	Name     string
+	Detail   string
+	Err      error
+	Duration time.Duration
+
+	Skipped bool
+
+	Sensitive bool
+}
+
+// BatchResult holds one StepResult per Step, in order.
+type BatchResult struct {
+	Steps []StepResult
+}
+
+// BatchError aggregates the failures of a batch. errors.Is and errors.As see
+// through it to each individual step error.
+type BatchError struct {
+	Failed []StepResult
+	Total  int
+}
+
+// BurstOptions configures BeginBurstWithOptions.
+type BurstOptions struct {
+	TTL time.Duration
+}
+
+// System command IDs for SysCommand.
+const (
+	SC_MINIMIZE = window.SC_MINIMIZE
+	SC_MAXIMIZE = window.SC_MAXIMIZE
+	SC_CLOSE    = window.SC_CLOSE
+	SC_RESTORE  = window.SC_RESTORE
+)
+
+// MenuCommand is a menu item discovered by ListCommands.
+type MenuCommand = window.MenuItem
+
+// CoordinateRecord captures where a client coordinate landed when an action was performed,
+// together with the window geometry that determined it. Comparing it with the present state
+// (ReplayCoordinates) explains most "clicked the wrong place" bugs after the fact.
+type CoordinateRecord struct {
+	Time   time.Time
+	Action string
+	HWND   uintptr
+
+	X, Y             int32
+	ScreenX, ScreenY int32
+	WindowRect       window.RECT
+	ClientWidth      int32
+	ClientHeight     int32
+	DPI              uint32
+	Monitor          uintptr
+	Foreground       bool
+}
+
+// FileDialogStage identifies the step of OpenFileDialog that failed.
+type FileDialogStage int
+
+const (
+	// FileDialogTrigger is sending the shortcut or command that opens the dialog.
+	FileDialogTrigger FileDialogStage = iota
+	// FileDialogWait is waiting for the dialog owned by the target to appear.
+	FileDialogWait
+	// FileDialogFindEdit is locating the file name edit inside the dialog.
+	FileDialogFindEdit
+	// FileDialogSetPath is setting the file name edit's text.
+	FileDialogSetPath
+	// FileDialogConfirm is pressing Open (IDOK) and waiting for the dialog to close.
+	// It fails if the dialog stays open, e.g. behind a "file not found" message box.
+	FileDialogConfirm
+)
+
+// FileDialogError reports the stage at which OpenFileDialog failed. It matches
+// ErrFileDialog and the underlying error (e.g. ErrTimeout) with errors.Is.
+type FileDialogError struct {
+	Stage  FileDialogStage
+	Dialog uintptr
+	Err    error
+}
+
+// FileDialogOptions configures OpenFileDialogWithOptions.
+type FileDialogOptions struct {
+	Hotkey []Key
+
+	Command uint16
+
+	Timeout time.Duration
+}
+
+// Report is the result of Doctor. Probe failures are recorded in the
+// corresponding error fields instead of aborting the report.
+type Report struct {
+	OSMajor, OSMinor, OSBuild uint32
+	OSErr                     error
+
+	Arch         string
+	Capabilities Capabilities
+	DPIAwareness Level
+	Elevated     bool
+	ElevatedErr  error
+
+	SessionID     uint32
+	SessionErr    error
+	RemoteSession bool
+
+	Backend Backend
+
+	HIDErr error
+
+	Monitors    []screen.Monitor
+	MonitorsErr error
+
+	CursorTest    error
+	SendInputTest error
+	MessageTest   error
+
+	Warnings []string
+}
+
+// DragOptions configures drag gestures.
+type DragOptions struct {
+	Steps int
+
+	Dwell time.Duration
+
+	Button MouseButton
+}
+
+// WindowInfo describes a top-level window, as returned by ListWindows.
+type WindowInfo struct {
+	HWND        uintptr
+	Title       string
+	ClassName   string
+	PID         uint32
+	ProcessName string
+	Visible     bool
+	Rect        window.RECT
+}
+
+// FocusStolenError reports that the foreground window changed during TypeIntoForeground.
+// It matches ErrFocusStolen with errors.Is.
+type FocusStolenError struct {
+	Sent int
+
+	Foreground uintptr
+}
+
+// ForegroundTypeOptions configures TypeIntoForegroundWithOptions.
+type ForegroundTypeOptions struct {
+	CheckEvery int
+
+	Reactivate bool
+}
+
+// GlobalTypeMethod is one rung of the Message-backend fallback ladder used by the global Type.
+type GlobalTypeMethod int
+
+const (
+	// GlobalTypeSendInput injects Unicode key events with SendInput.
+	GlobalTypeSendInput GlobalTypeMethod = iota
+	// GlobalTypePostChar posts WM_CHAR to the focused control of the foreground window.
+	GlobalTypePostChar
+	// GlobalTypeClipboard places the text on the clipboard (overwriting it) and posts WM_PASTE
+	// to the focused control of the foreground window.
+	GlobalTypeClipboard
+)
+
+const (
+	INPUT_MOUSE      = 0
+	MOUSEEVENTF_MOVE = 0x0001
+)
+
+// LayoutInfo describes an installed keyboard layout (input locale).
+type LayoutInfo = window.KeyboardLayout
+
+// LayoutNotInstalledError is returned when a requested layout is not installed.
+// It lists the installed layouts so the caller can pick one.
+type LayoutNotInstalledError struct {
+	KLID      string
+	Installed []LayoutInfo
+}
+
+// Lock names reported in LockWait and StatsSnapshot.
+const (
+	// LockInput is the global input lock that serializes all input calls and sessions.
+	LockInput = "input"
+	// LockCrossProcess is the named mutex configured with SetCrossProcessLock.
+	LockCrossProcess = "cross-process"
+	// LockHID is the Interception driver lock, held exclusively while the HID backend
+	// initializes or closes.
+	LockHID = "hid"
+)
+
+// LockWait is one lock acquisition made by a call.
+type LockWait struct {
+	Lock string
+	Wait time.Duration
+
+	Holder string
+}
+
+// SlowCall is reported to the SetSlowCallThreshold callback.
+type SlowCall struct {
+	Op       string
+	Start    time.Time
+	Duration time.Duration
+	Waits    []LockWait
+}
+
+// LockStats summarizes the acquisitions of one lock.
+type LockStats struct {
+	Acquisitions  uint64
+	Contended     uint64
+	TotalWait     time.Duration
+	MaxWait       time.Duration
+	MaxWaitHolder string
+}
+
+// StatsSnapshot is a snapshot of the lock instrumentation counters.
+type StatsSnapshot struct {
+	Locks     map[string]LockStats
+	SlowCalls uint64
+}
+
+// MouseButton identifies a mouse button.
+type MouseButton int
+
+const (
+	MouseLeft MouseButton = iota
+	MouseRight
+	MouseMiddle
+	MouseX1 // the first extra button, usually "back"
+	MouseX2 // the second extra button, usually "forward"
+)
+
+// ReadyRetry describes one failed readiness check while waiting under SetReadyWait.
+type ReadyRetry struct {
+	HWND    uintptr
+	Attempt int
+	Elapsed time.Duration
+	Err     error
+}
+
+// ResolveStep is one find operation of a Resolve chain. It receives the previous step's
+// window (nil for the first step) and returns the next one.
+type ResolveStep struct {
+	Name string
+}
+
+// RetryPolicy controls how a failing step is retried: the wait starts at Initial and is
+// multiplied by Multiplier after each attempt, up to Max.
+type RetryPolicy struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+
+	Timeout time.Duration
+}
+
+// DefaultRetry is the policy of steps without WithRetry.
+var DefaultRetry = RetryPolicy{Initial: 50 * time.Millisecond, Max: time.Second, Multiplier: 2}
+
+// ResolveError reports the step a Resolve chain was stuck on when the context ended.
+// It matches ErrTimeout (or context.Canceled) and the step's last error with errors.Is.
+type ResolveError struct {
+	Step     int
+	Name     string
+	Attempts int
+	Err      error
+	Reason   error
+}
+
+// Modifier is a set of modifier keys reported with a mouse event.
+type Modifier uint8
+
+const (
+	ModShift Modifier = 1 << iota
+	ModCtrl
+)
+
+// ScrollOptions configures ScrollWithOptions.
+type ScrollOptions struct {
+	Modifiers Modifier
+}
+
+// Sequence runs the input actions issued through it strictly in the order they were
+// issued, from whichever goroutines issue them. Each action takes the global input lock
+// on its own, as the equivalent package-level or *Window call would, so actions from
+// different sequences (and plain calls) interleave freely between them.
+//
+// Methods only enqueue; failures are collected and returned by Wait. A failed action
+// does not stop the ones after it. The zero value is not usable; call NewSequence.
+type Sequence struct {
+}
+
+// SequenceWindow exposes the *Window input methods on a Sequence.
+type SequenceWindow struct {
+}
+
+// SessionOptions configures AcquireSessionWithOptions.
+type SessionOptions struct {
+	MaxHold time.Duration
+
+	FailFast bool
+}
+
+// Session grants exclusive access to all input APIs until Release is called.
+// Calls made through the Session run without interleaving with any other caller;
+// calls made through the package-level API or *Window block (or fail fast) meanwhile.
+type Session struct {
+}
+
+// SessionWindow exposes the *Window input methods under a Session.
+type SessionWindow struct {
+}
+
+// Message is a window message received by an OwnedWindow.
+type Message struct {
+	Msg    uint32
+	WParam uintptr
+	LParam uintptr
+}
+
+// TestWindowOptions configures NewTestWindow.
+type TestWindowOptions struct {
+	Visible bool
+	Title   string
+	X, Y    int32
+
+	Width, Height int32
+
+	Buffer int
+
+	Children []TestChild
+}
+
+// TestChild is a child control of a test window, such as {Class: "Edit"} or
+// {Class: "Button", Text: "OK"}. Any class works, including the system control classes.
+type TestChild struct {
+	Class, Text string
+	ID          int
+	Children    []TestChild
+}
+
+// OwnedWindow is a top-level window created and pumped by winput itself.
+// It embeds *Window, so every input method can target it, and records the
+// keyboard, mouse, command and activation/focus messages it receives for inspection via NextMessage.
+type OwnedWindow struct {
+	*Window
+}
+
+// Timing controls the pauses inserted between input events. Targets differ in how fast
+// they accept input: some drop keys unless they are spaced out, others are fine at 0.
+//
+// The effective timing of an operation is resolved as: per-call option (e.g.
+// TypeOptions.Timing) > per-window (Window.SetTiming) > global (SetTiming).
+// The struct is JSON-serializable so that application profiles can be stored with the
+// rest of an automation's configuration.
+type Timing struct {
+	KeyDelay time.Duration `json:"keyDelay"`
+
+	KeyHold time.Duration `json:"keyHold"`
+
+	DoubleClickHold time.Duration `json:"doubleClickHold,omitempty"`
+	DoubleClickGap  time.Duration `json:"doubleClickGap,omitempty"`
+}
+
+// DefaultTiming is the global timing in effect until SetTiming is called.
+var DefaultTiming = Timing{
+	KeyDelay: 30 * time.Millisecond,
+	KeyHold:  30 * time.Millisecond,
+}
+
+// TitleWatch delivers title changes from WatchTitle.
+type TitleWatch struct {
+	C <-chan string
+}
+
+// WindowEventKind identifies a change reported by Watch.
+type WindowEventKind int
+
+const (
+	// WindowClosed is sent when the window is destroyed. It is always the last event.
+	WindowClosed WindowEventKind = iota
+	// WindowMinimized is sent when the window is minimized.
+	WindowMinimized
+	// WindowRestored is sent when a minimized window is restored or maximized.
+	WindowRestored
+	// WindowTitleChanged is sent when the title changes. WindowEvent.Title holds the new title.
+	WindowTitleChanged
+)
+
+// WindowEvent is a change in a watched window's lifecycle.
+type WindowEvent struct {
+	Kind  WindowEventKind
+	Title string
+	Time  time.Time
+}
+
+// Window represents a handle to a window.
+type Window struct {
+	HWND uintptr
+}
+
+// Backend represents the input simulation backend.
+type Backend int
+
+const (
+	// BackendMessage uses Windows messages (PostMessage) for input simulation.
+	BackendMessage Backend = iota
+	// BackendHID uses the Interception driver for hardware-level input simulation.
+	BackendHID
+)
+
+type Key = keyboard.Key
+
+// KeyDef maps a character to the key that types it, and whether Shift is held.
+type KeyDef = keyboard.KeyDef
+
+const (
+	KeyEsc       = keyboard.KeyEsc
+	Key1         = keyboard.Key1
+	Key2         = keyboard.Key2
+	Key3         = keyboard.Key3
+	Key4         = keyboard.Key4
+	Key5         = keyboard.Key5
+	Key6         = keyboard.Key6
+	Key7         = keyboard.Key7
+	Key8         = keyboard.Key8
+	Key9         = keyboard.Key9
+	Key0         = keyboard.Key0
+	KeyMinus     = keyboard.KeyMinus
+	KeyEqual     = keyboard.KeyEqual
+	KeyBkSp      = keyboard.KeyBkSp
+	KeyTab       = keyboard.KeyTab
+	KeyQ         = keyboard.KeyQ
+	KeyW         = keyboard.KeyW
+	KeyE         = keyboard.KeyE
+	KeyR         = keyboard.KeyR
+	KeyT         = keyboard.KeyT
+	KeyY         = keyboard.KeyY
+	KeyU         = keyboard.KeyU
+	KeyI         = keyboard.KeyI
+	KeyO         = keyboard.KeyO
+	KeyP         = keyboard.KeyP
+	KeyLBr       = keyboard.KeyLBr
+	KeyRBr       = keyboard.KeyRBr
+	KeyEnter     = keyboard.KeyEnter
+	KeyCtrl      = keyboard.KeyCtrl
+	KeyA         = keyboard.KeyA
+	KeyS         = keyboard.KeyS
+	KeyD         = keyboard.KeyD
+	KeyF         = keyboard.KeyF
+	KeyG         = keyboard.KeyG
+	KeyH         = keyboard.KeyH
+	KeyJ         = keyboard.KeyJ
+	KeyK         = keyboard.KeyK
+	KeyL         = keyboard.KeyL
+	KeySemi      = keyboard.KeySemi
+	KeyQuot      = keyboard.KeyQuot
+	KeyTick      = keyboard.KeyTick
+	KeyShift     = keyboard.KeyShift
+	KeyBackslash = keyboard.KeyBackslash
+	KeyZ         = keyboard.KeyZ
+	KeyX         = keyboard.KeyX
+	KeyC         = keyboard.KeyC
+	KeyV         = keyboard.KeyV
+	KeyB         = keyboard.KeyB
+	KeyN         = keyboard.KeyN
+	KeyM         = keyboard.KeyM
+	KeyComma     = keyboard.KeyComma
+	KeyDot       = keyboard.KeyDot
+	KeySlash     = keyboard.KeySlash
+	KeyAlt       = keyboard.KeyAlt
+	KeySpace     = keyboard.KeySpace
+	KeyCaps      = keyboard.KeyCaps
+	KeyF1        = keyboard.KeyF1
+	KeyF2        = keyboard.KeyF2
+	KeyF3        = keyboard.KeyF3
+	KeyF4        = keyboard.KeyF4
+	KeyF5        = keyboard.KeyF5
+	KeyF6        = keyboard.KeyF6
+	KeyF7        = keyboard.KeyF7
+	KeyF8        = keyboard.KeyF8
+	KeyF9        = keyboard.KeyF9
+	KeyF10       = keyboard.KeyF10
+	KeyF11       = keyboard.KeyF11
+	KeyF12       = keyboard.KeyF12
+	KeyNumLock   = keyboard.KeyNumLock
+	KeyScroll    = keyboard.KeyScroll
+
+	KeyHome      = keyboard.KeyHome
+	KeyArrowUp   = keyboard.KeyArrowUp
+	KeyPageUp    = keyboard.KeyPageUp
+	KeyLeft      = keyboard.KeyLeft
+	KeyRight     = keyboard.KeyRight
+	KeyEnd       = keyboard.KeyEnd
+	KeyArrowDown = keyboard.KeyArrowDown
+	KeyPageDown  = keyboard.KeyPageDown
+	KeyInsert    = keyboard.KeyInsert
+	KeyDelete    = keyboard.KeyDelete
+)
+
+// TypeStrategy selects how the Message backend delivers text to a window.
+type TypeStrategy int
+
+const (
+	// TypeStrategyAuto uses TypeStrategySetText for RichEdit-family controls and
+	// TypeStrategyChar for everything else (default).
+	TypeStrategyAuto TypeStrategy = iota
+	// TypeStrategyChar posts one WM_CHAR per character.
+	TypeStrategyChar
+	// TypeStrategySetText inserts the whole text at the selection with EM_SETTEXTEX in a single
+	// message, keeping it on the undo stack. It only applies to RichEdit controls; others get WM_CHAR,
+	// as does a RichEdit that rejects the message.
+	TypeStrategySetText
+	// TypeStrategyKeyEvents posts WM_KEYDOWN/WM_KEYUP pairs with explicit Shift transitions,
+	// for targets that ignore WM_CHAR, paced by the Timing's KeyDelay and KeyHold. Characters
+	// without a scan code fall back to WM_CHAR.
+	TypeStrategyKeyEvents
+	// TypeStrategyClipboard puts the text on the clipboard (overwriting it) and pastes it:
+	// WM_PASTE under the Message backend, Ctrl+V under the HID backend.
+	TypeStrategyClipboard
+)
+
+// CaretPlacement selects where TypeWithOptions moves the caret before typing.
+type CaretPlacement int
+
+const (
+	// CaretPreserve types at the current caret or selection (default).
+	CaretPreserve CaretPlacement = iota
+	// CaretStart moves the caret to the start of the text.
+	CaretStart
+	// CaretEnd moves the caret to the end of the text, to append.
+	CaretEnd
+)
+
+// Point is a pair of coordinates; the API taking it says whether they are client or screen coordinates.
+type Point struct {
+	X, Y int32
+}
+
+// TypeOptions configures TypeWithOptions.
+type TypeOptions struct {
+	Strategy TypeStrategy
+
+	Timing *Timing
+
+	ClickFirst *Point
+
+	Caret CaretPlacement
+}
+
+const (
+	INPUT_KEYBOARD        = 1
+	KEYEVENTF_EXTENDEDKEY = 0x0001
+	KEYEVENTF_UNICODE     = 0x0004
+	KEYEVENTF_KEYUP       = 0x0002
+)
+
+// Level describes the DPI awareness level of the process.
+type Level = window.DPIAwarenessLevel
+
+const (
+	// LevelUnaware means the OS bitmap-stretches the process on high-DPI monitors.
+	LevelUnaware = window.DPIUnaware
+	// LevelSystemAware means coordinates are exact on the primary monitor only.
+	LevelSystemAware = window.DPISystemAware
+	// LevelPerMonitor means coordinates are exact on every monitor.
+	LevelPerMonitor = window.DPIPerMonitor
+	// LevelPerMonitorV2 is LevelPerMonitor with improved non-client scaling.
+	LevelPerMonitorV2 = window.DPIPerMonitorV2
+)
+
+// MoveInaccurateError reports where the cursor ended up when an HID move gave up;
+// EdgeConstrained tells whether screen edges were involved.
+type MoveInaccurateError = hid.MoveInaccurateError
+
+// DPIAlreadySetError reports the level a process was already locked to; see EnablePerMonitorDPI.
+type DPIAlreadySetError = window.DPIAlreadySetError
+
+// UnsupportedOSError names the Windows API a feature needs but the running version lacks.
+type UnsupportedOSError = window.UnsupportedOSError
+
+// ChildIndexError reports how many children of a class exist when FindChildByClassNth's
+// index is out of range. It matches ErrChildIndexOutOfRange with errors.Is.
+type ChildIndexError = window.ChildIndexError
+
+// Capabilities reports which version-dependent Windows APIs are available; see OSCapabilities.
+type Capabilities = window.Capabilities
+
+// Rect is a rectangle in screen or client coordinates; Right and Bottom are exclusive.
+type Rect = window.RECT
+
+// OutsideWorkAreaError reports a target that the work-area guard moved.
+type OutsideWorkAreaError struct {
+	X, Y             int32
+	ActualX, ActualY int32
+	Monitor          screen.Monitor
+}
+
+// ForegroundWindow returns the window the user is working with, which receives HID and
+// other global input. It returns ErrWindowNotFound if there is none, e.g. while the desktop
+// is switching or the secure desktop (UAC, Ctrl+Alt+Del) is shown.
+func ForegroundWindow() (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// IsForeground reports whether the window, or the top-level window containing it, is the
+// foreground window.
+func (w *Window) IsForeground() bool {
+	return false
+}
+
+// Activate brings the top-level window containing w to the foreground and gives it keyboard
+// focus, which the HID backend and global input need. A minimized window is restored first.
+//
+// Windows only lets the foreground application, or the one that received the last input
+// event, change the foreground (the foreground lock). Activate attaches to the foreground
+// thread's input state and, if that is not enough, holds Alt around the request so the
+// calling process counts as the source of the last input; the Alt release may then open the
+// target's menu bar in some applications. It returns ErrActivateFailed if the window is
+// still not in the foreground afterwards.
+func (w *Window) Activate() error {
+	return ErrUnsupportedPlatform
+}
+
+// Focus gives the window keyboard focus within its application, e.g. a child Edit control
+// of an application that routes keys by focus rather than by the window they are posted to.
+// It attaches to the window's thread input state to call SetFocus and detaches again
+// afterwards. It does not bring the application to the foreground; use Activate for that.
+// It returns ErrActivateFailed if the window does not have focus afterwards, e.g. because
+// it is disabled.
+func (w *Window) Focus() error {
+	return ErrUnsupportedPlatform
+}
+
+// FocusedChild returns the control that has keyboard focus on the window's thread
+// (GetGUIThreadInfo), e.g. to check where typed keys would go. This is usually w or one of
+// its descendants, but can be another window of the same thread, such as an open dialog.
+// It returns ErrNoFocus if no window of the thread has focus.
+func (w *Window) FocusedChild() (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// CaretPos returns the position of the text caret on the window's thread in w's client
+// coordinates: its left edge, halfway down, so Click(CaretPos()) lands on the caret's line.
+// Use it to click where text would be inserted, or to check that typing moved the caret.
+// It returns ErrNoCaret if the thread shows no caret, e.g. when no edit control has focus
+// or the application draws its own; callers can then fall back to coordinate clicks.
+func (w *Window) CaretPos() (x, y int32, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// Flash flashes the window's caption and taskbar button count times to get the operator's
+// attention, e.g. when a captcha or elevation prompt needs a human. Flash(0) flashes until
+// the window comes to the foreground. It does nothing if the window is already in the
+// foreground.
+func (w *Window) Flash(count uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+// Failed returns the results of the steps that ran and failed.
+func (r *BatchResult) Failed() []StepResult {
+	return nil
+}
+
+// Err returns nil if every step that ran succeeded, or a *BatchError otherwise.
+func (r *BatchResult) Err() error {
+	return ErrUnsupportedPlatform
+}
+
+func (e *BatchError) Error() string {
+	return ""
+}
+
+func (e *BatchError) Unwrap() []error {
+	return nil
+}
+
+// RunBatch runs steps in order under a single Session. After a failed step, any keys and
+// mouse buttons it left pressed through the session are released before the next step
+// runs, so one failure cannot leave a modifier or a button stuck for the rest of the batch.
+//
+// The returned error is the session acquisition error, BatchResult.Err(), or ctx.Err()
+// if the batch was cancelled before any step failed.
+// The result is never nil and always has one entry per step.
+func RunBatch(ctx context.Context, steps []Step, opts BatchOptions) (*BatchResult, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// SetBidiClipboard marks the window as mis-handling posted right-to-left or combining
+// WM_CHARs, so TypeBidi pastes via the clipboard instead. The setting is shared by every
+// Window with the same handle.
+func (w *Window) SetBidiClipboard(enabled bool) {}
+
+// TypeBidi types right-to-left, mixed-direction or combining-character text.
+// The text is normalized to NFC (so base letters and diacritics arrive as the target expects)
+// and sent in logical order with directional marks (U+200E, U+200F, ...) preserved.
+// It pastes via the clipboard (overwriting it) under the HID backend, which cannot type
+// characters absent from the keyboard layout, and for windows marked with SetBidiClipboard.
+func (w *Window) TypeBidi(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// BeginBurst starts a burst for the window: its client origin is resolved once and
+// subsequent HID operations on the window (Move, Click, DoubleClick, ...) and
+// ClientToScreen compute screen coordinates from the snapshot instead of calling
+// ClientToScreen per operation.
+//
+// Before each use the snapshot is checked against the window's current rectangle
+// (GetWindowRect), so an operation right after the window or an ancestor moved does not use
+// a stale origin. It is also re-resolved when a location change is reported (e.g. a frame
+// change that keeps the rectangle), or after BurstOptions.TTL. Call EndBurst when done.
+func (w *Window) BeginBurst() error {
+	return ErrUnsupportedPlatform
+}
+
+// BeginBurstWithOptions is BeginBurst with custom options.
+func (w *Window) BeginBurstWithOptions(opts BurstOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// EndBurst ends the window's burst, if any, so coordinates are resolved per operation again.
+func (w *Window) EndBurst() {}
+
+// Capture returns a screenshot of the window alone, taken with PrintWindow so that it is
+// complete even where other windows cover it. The image bounds are the window rect in client
+// coordinates: (0, 0) is the top-left pixel of the client area and the frame lies at negative
+// coordinates, so img.At(x, y) is the pixel that Click(x, y) targets.
+// It returns ErrWindowMinimized for a minimized window, which has nothing to draw.
+func (w *Window) Capture() (*image.RGBA, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// CaptureClient is Capture restricted to the client-area rectangle at (x, y) with size
+// width x height, e.g. to watch a status label without grabbing the desktop. The rectangle
+// is clipped to the client area and the image bounds are the clipped rectangle in client
+// coordinates, so img.At(x, y) is client pixel (x, y). It fails if the clipped rectangle
+// is empty.
+func (w *Window) CaptureClient(x, y, width, height int32) (*image.RGBA, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Close asks the window to close by posting WM_CLOSE, as its close button does. The
+// application may ask to save changes first or ignore the request; use ForceClose to make
+// sure it goes away. Once the window is destroyed, Close and the other methods of w return
+// ErrWindowGone.
+func (w *Window) Close() error {
+	return ErrUnsupportedPlatform
+}
+
+// ForceClose posts WM_CLOSE and waits up to timeout for the window to be destroyed. If it
+// survives, e.g. behind a "save changes?" prompt, the owning process is terminated, losing
+// unsaved work. A window of the calling process is never terminated; ForceClose returns
+// ErrTimeout for it instead. It returns ErrWindowGone if the window no longer exists on entry.
+func (w *Window) ForceClose(timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// Command posts WM_COMMAND with the given menu/accelerator ID, as if the user picked the menu item.
+// It needs no coordinates or focus and works while the window is minimized.
+func (w *Window) Command(id uint16) error {
+	return ErrUnsupportedPlatform
+}
+
+// SysCommand posts WM_SYSCOMMAND (e.g. SC_MINIMIZE, SC_RESTORE, SC_CLOSE).
+func (w *Window) SysCommand(sc uintptr) error {
+	return ErrUnsupportedPlatform
+}
+
+// ListCommands walks the window's menu bar and returns every command ID with its label,
+// so the right ID can be found once and hard-coded for use with Command.
+// Applications without a classic menu bar (e.g. ribbon UIs) return an empty list.
+func (w *Window) ListCommands() ([]MenuCommand, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// SetCoordinateRecorder installs fn to receive a CoordinateRecord for every Window mouse
+// action at a client point before it is sent: Move, Hover, Click, ClickRight, ClickMiddle,
+// DoubleClick, DoubleClickRight, MultiClick, ClickX, ClickWithModifiers, MouseDown, MouseUp,
+// Drag (its start point), Scroll, ScrollH, ScrollWithOptions (Zoom included) and
+// ScrollAtPoint. Actions without a client point, such as MoveRel, are not recorded.
+// fn is called synchronously under the input lock and should only store the record.
+// Pass nil to stop recording.
+func SetCoordinateRecorder(fn func(CoordinateRecord)) {}
+
+// RecordCoordinates captures a CoordinateRecord for client point (x, y) of the window now.
+// Fields that cannot be read (e.g. DPI on old systems) are left zero.
+func (w *Window) RecordCoordinates(action string, x, y int32) CoordinateRecord {
+	return *new(CoordinateRecord)
+}
+
+// ReplayCoordinates recomputes where the recorded client coordinate would land today and
+// explains what changed since it was recorded (window moved or resized, DPI, monitor,
+// foreground). If the window no longer exists, the recorded screen position is returned.
+func ReplayCoordinates(rec CoordinateRecord) (screenX, screenY int32, explanation string) {
+	return 0, 0, ""
+}
+
+// String returns a readable name for the stage.
+func (s FileDialogStage) String() string {
+	return ""
+}
+
+func (e *FileDialogError) Error() string {
+	return ""
+}
+
+func (e *FileDialogError) Unwrap() []error {
+	return nil
+}
+
+// OpenFileDialog opens a file through the owner's standard Open dialog: it presses Ctrl+O,
+// waits for the "#32770" dialog owned by owner, sets the file name edit (nested as
+// ComboBoxEx32 > ComboBox > Edit) to path in one message and confirms with IDOK.
+// Failures are reported as a *FileDialogError naming the stage.
+func OpenFileDialog(owner *Window, path string, timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// OpenFileDialogWithOptions is OpenFileDialog with a configurable trigger.
+func OpenFileDialogWithOptions(owner *Window, path string, opts FileDialogOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// Doctor gathers environment information relevant to input simulation and runs
+// harmless self-tests (no visible input is produced). The returned error is only
+// non-nil if the report could not be produced at all; individual probe failures
+// are recorded in the Report. Paste Report.String() when filing issues.
+func Doctor() (Report, error) {
+	return *new(Report), ErrUnsupportedPlatform
+}
+
+// String renders the report as human-readable text.
+func (r Report) String() string {
+	return ""
+}
+
+// Drag presses the left button at client point (fromX, fromY), moves to (toX, toY) with the
+// button held and releases it there, e.g. to drag a slider, move a scrollbar thumb or
+// rubber-band select. The Message backend posts the button down, WM_MOUSEMOVE messages
+// along the path and the button up; the HID backend presses the physical button and follows
+// the human-like Move trajectory. Use DragBetween for OLE drag-and-drop between windows.
+func (w *Window) Drag(fromX, fromY, toX, toY int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// DragWithOptions is Drag with another button or number of Message backend steps.
+func (w *Window) DragWithOptions(fromX, fromY, toX, toY int32, opts DragOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// DragBetween presses the left button at client point (sx, sy) of src, moves across the screen
+// to client point (dx, dy) of dst, hovers for opts.Dwell and releases.
+//
+// OLE drag-and-drop needs real cursor movement, so the Message backend drives the physical
+// cursor (SetCursorPos/mouse_event) for this call instead of posting messages.
+// If either window moves or the destination point becomes covered by another window mid-drag,
+// the drag is cancelled with Esc before the button is released, and ErrWindowMoved or
+// ErrWindowObscured is returned.
+func DragBetween(src *Window, sx, sy int32, dst *Window, dx, dy int32, opts DragOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// DragMouse presses the left button at screen point (fromX, fromY), moves to (toX, toY) with
+// the button held and releases it there, e.g. to drop a file onto a drop zone found by
+// screen capture. The Message backend drives the physical cursor with SetCursorPos and
+// mouse_event; the HID backend follows the human-like Move trajectory.
+// Both points are subject to the work area guard (see SetWorkAreaGuard).
+func DragMouse(fromX, fromY, toX, toY int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// FindByTitleContains returns the topmost top-level window whose title contains substr
+// (case-insensitive, Unicode-aware), e.g. "Notepad" for "report.txt - Notepad".
+// Invisible and tool windows are skipped unless IncludeHidden is given, and surrounding
+// white space is ignored with MatchTrimSpace.
+func FindByTitleContains(substr string, opts ...FindOption) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindAllByTitleContains is FindByTitleContains returning every match, in z-order
+// (topmost first). It returns ErrWindowNotFound if there is none.
+func FindAllByTitleContains(substr string, opts ...FindOption) ([]*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindByTitleRegex returns every top-level window whose title matches the regular
+// expression pattern, in z-order (topmost first), for titles carrying dynamic data such as
+// `^MyApp v\d+\.\d+`. Windows with an empty title are skipped. Like the other Find
+// functions it skips invisible and tool windows by default; pass IncludeHidden to search
+// every top-level window. It returns the compile error for an invalid pattern, and
+// ErrWindowNotFound if nothing matches.
+func FindByTitleRegex(pattern string, opts ...FindOption) ([]*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// WindowAt returns the deepest visible window at the screen point (WindowFromPoint), e.g. the
+// control under a template match, in virtual desktop coordinates (negative left of or above
+// the primary monitor). Use ScreenToClient on the result to address it.
+// It returns ErrWindowNotFound if the point is over the desktop or outside every monitor;
+// the desktop (Progman/WorkerW) is never returned.
+func WindowAt(x, y int32) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// TopLevelWindowAt is WindowAt returning the top-level window that contains the point's window.
+func TopLevelWindowAt(x, y int32) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindChildByTitle returns the first descendant control whose text equals text
+// (case-insensitive), e.g. the "OK" button of a dialog, in EnumChildWindows order.
+// Mnemonic markers are ignored, so "Open" matches a button labelled "&Open".
+// It returns ErrWindowNotFound if there is none.
+func (w *Window) FindChildByTitle(text string) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindChildByTitleContains is FindChildByTitle matching controls whose text contains substr.
+func (w *Window) FindChildByTitleContains(substr string) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindDescendantByClass returns the shallowest descendant with the specified class name
+// (case-insensitive, like FindWindowW), searching breadth-first so that controls nested
+// in container panes are found, nearest first. maxDepth limits the search to that many
+// levels below w (1 means direct children only); maxDepth <= 0 means no limit.
+// It returns ErrWindowNotFound if there is none.
+func (w *Window) FindDescendantByClass(class string, maxDepth int) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// ListWindows describes every top-level window, in z-order (topmost first), to find out
+// what a target's class name and title are without Spy++. Like the Find functions it skips
+// invisible and tool windows (the hundreds of hidden system windows) unless IncludeHidden is given.
+func ListWindows(opts ...FindOption) ([]WindowInfo, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// SimulateFocusLoss posts the messages a top-level window receives when the user switches
+// to another application, in the order Windows sends them: WM_NCACTIVATE(FALSE),
+// WM_ACTIVATE(WA_INACTIVE), WM_ACTIVATEAPP(FALSE), then WM_KILLFOCUS to the focused control.
+// Nothing else on the desktop changes, so it is suited to testing how an application
+// reacts to deactivation (pausing playback, locking forms).
+//
+// It is a simulation: an application that checks GetForegroundWindow or GetFocus sees that
+// it is still active. Use StealFocus for a real focus change.
+func (w *Window) SimulateFocusLoss() error {
+	return ErrUnsupportedPlatform
+}
+
+// SimulateFocusGain posts the messages of the window being activated again:
+// WM_ACTIVATEAPP(TRUE), WM_NCACTIVATE(TRUE), WM_ACTIVATE(WA_ACTIVE), then WM_SETFOCUS to
+// the focused control. See SimulateFocusLoss.
+func (w *Window) SimulateFocusGain() error {
+	return ErrUnsupportedPlatform
+}
+
+// StealFocus really deactivates the window: it brings a window owned by winput (an off-screen
+// test window) to the foreground, so the target loses activation and focus exactly as it
+// would to another application. restore reactivates the target and closes the thief.
+// It returns ErrActivateFailed if Windows refuses the foreground change (the foreground
+// lock), which happens unless the calling process owns the current foreground window or
+// received the last input event.
+func (w *Window) StealFocus() (restore func() error, err error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func (e *FocusStolenError) Error() string {
+	return ""
+}
+
+func (e *FocusStolenError) Unwrap() error {
+	return ErrUnsupportedPlatform
+}
+
+// TypeIntoForeground types text globally, but only while expected (or one of its children)
+// is the foreground window. The foreground window is verified immediately before the first
+// character and before every subsequent one; if it changes, typing stops and a
+// *FocusStolenError (errors.Is ErrFocusStolen) reports how many characters were sent.
+// This keeps text such as credentials from landing in whatever window popped up.
+func TypeIntoForeground(expected *Window, text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// TypeIntoForegroundWithOptions is TypeIntoForeground with custom options.
+func TypeIntoForegroundWithOptions(expected *Window, text string, opts ForegroundTypeOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetPosition moves the window's outer frame to (x, y): screen coordinates for a top-level
+// window, parent client coordinates for a child. It keeps the size and z-order and does not
+// activate the window.
+func (w *Window) SetPosition(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// Resize sets the outer size of the window, keeping its position. Use ResizeClient to size
+// the client area, which Click and Move coordinates are relative to.
+func (w *Window) Resize(width, height int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetBounds moves and resizes the window's outer frame to r (see SetPosition).
+func (w *Window) SetBounds(r Rect) error {
+	return ErrUnsupportedPlatform
+}
+
+// ResizeClient resizes the window so that its client area is width x height, keeping its
+// position. The frame size comes from AdjustWindowRectExForDpi for the window's styles and
+// DPI; if the resulting client area still differs (e.g. a wrapping menu bar), the size is
+// corrected once by the difference.
+func (w *Window) ResizeClient(width, height int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetPosImmediate moves and resizes the window (outer frame, screen coordinates) with DWM
+// transition animations disabled, then waits up to timeout for the change to settle:
+// the rect must stop changing, the window must have processed its queued messages
+// (including WM_WINDOWPOSCHANGED), and DWM must have composed a frame.
+// The animation attribute is re-enabled before returning.
+func (w *Window) SetPosImmediate(x, y, width, height int32, timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// WaitStableRect polls the window rect until it stops changing (unchanged for 3 consecutive
+// polls, ~50ms) or the timeout expires, in which case ErrTimeout is returned.
+func (w *Window) WaitStableRect(timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// IsTopMost reports whether the window is always-on-top (WS_EX_TOPMOST).
+func (w *Window) IsTopMost() bool {
+	return false
+}
+
+// SetTopMost makes the window always-on-top, or returns it to the normal z-order band,
+// without activating it. It returns ErrPermissionDenied if UIPI blocks the change, e.g.
+// for an elevated window.
+func (w *Window) SetTopMost(topmost bool) error {
+	return ErrUnsupportedPlatform
+}
+
+// SendToBack moves the window to the bottom of the z-order without activating it,
+// e.g. to push a window that obscures the target out of the way without changing the
+// target's state. A topmost window loses its topmost status.
+func (w *Window) SendToBack() error {
+	return ErrUnsupportedPlatform
+}
+
+// InsertAfter places the window directly below other in the z-order without activating either.
+// A non-topmost window cannot be placed among topmost windows; that case returns
+// ErrZOrderTopMost instead of silently changing the window's topmost state.
+func (w *Window) InsertAfter(other *Window) error {
+	return ErrUnsupportedPlatform
+}
+
+func (m GlobalTypeMethod) String() string {
+	return ""
+}
+
+// SetGlobalTypeMethods sets the methods the global Type tries, in order, under the Message backend.
+// The default is SendInput, then WM_CHAR, then clipboard. Calling it with no methods restores the default.
+func SetGlobalTypeMethods(methods ...GlobalTypeMethod) {}
+
+// Hover moves to the client point (x, y) and keeps the cursor there for d, so hover-activated
+// UI (tooltips, flyout menus) appears. The Message backend re-posts WM_MOUSEMOVE every 100ms
+// because applications start their hover timers from mouse moves; the HID backend moves the
+// physical cursor and waits. Returns ErrWindowGone if the window is destroyed during the dwell.
+func (w *Window) Hover(x, y int32, d time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyboardLayouts returns the keyboard layouts installed for the current user,
+// with their KLIDs (e.g. "00000409"), locales and display names.
+func KeyboardLayouts() ([]LayoutInfo, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+func (e *LayoutNotInstalledError) Error() string {
+	return ""
+}
+
+func (e *LayoutNotInstalledError) Unwrap() error {
+	return ErrUnsupportedPlatform
+}
+
+// ActivateLayout asks the window's thread to switch to the given installed layout
+// by posting WM_INPUTLANGCHANGEREQUEST. The target applies it asynchronously and may refuse.
+func (w *Window) ActivateLayout(klid string) error {
+	return ErrUnsupportedPlatform
+}
+
+// WithLayout runs fn with the given installed layout active on the calling goroutine's
+// OS thread, restoring the previous layout afterwards. This affects how this process
+// translates keys (e.g. SendInput with virtual keys, VkKeyScanEx), not the target's layout;
+// use ActivateLayout for that.
+func WithLayout(klid string, fn func() error) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetLockInstrumentation enables or disables lock instrumentation. While enabled, every
+// input call records how long it waited for each lock it acquired and which operation held
+// it, feeding Stats, SetSlowCallThreshold and SetCallHook. While disabled, the cost is one
+// atomic load per call.
+func SetLockInstrumentation(enabled bool) {}
+
+// SetSlowCallThreshold calls fn with a SlowCall report for every input call that takes
+// longer than d, so lock contention can be alerted on in production. Setting it enables
+// lock instrumentation until it is cleared with d <= 0 or a nil fn.
+// fn runs on the calling goroutine after the input lock has been released.
+func SetSlowCallThreshold(d time.Duration, fn func(SlowCall)) {}
+
+// SetCallHook calls fn with the report of every input call made while it is set, whatever
+// its duration: the same SlowCall that SetSlowCallThreshold delivers for calls over the
+// threshold, so the lock waits of each call can be exported (e.g. as a histogram) rather
+// than only the aggregate Stats. Setting it enables lock instrumentation until it is
+// cleared with nil. fn runs on the calling goroutine after the input lock has been
+// released, and before any slow-call callback. Calls made through a Session take no lock
+// and are not reported; the session's own acquisition is counted in Stats only.
+func SetCallHook(fn func(SlowCall)) {}
+
+// Stats returns a snapshot of the lock counters gathered while instrumentation was enabled.
+func Stats() StatsSnapshot {
+	return *new(StatsSnapshot)
+}
+
+// ResetStats clears the counters returned by Stats.
+func ResetStats() {}
+
+// Monitor returns the monitor that shows most of the window, or the nearest monitor if
+// the window is off-screen, e.g. to capture only that display. Its Bounds and WorkArea are
+// virtual desktop coordinates, as used by screen.ImageToVirtual.
+func (w *Window) Monitor() (screen.Monitor, error) {
+	return *new(screen.Monitor), ErrUnsupportedPlatform
+}
+
+// String returns a readable name for the button.
+func (b MouseButton) String() string {
+	return ""
+}
+
+// MouseDown presses button at client point (x, y) and leaves it held until MouseUp, for
+// gestures Click and Drag do not cover (hold-to-repeat buttons, drags that decide their path
+// on the way). The Message backend posts the button-down message; the HID backend moves the
+// cursor there and presses the physical button.
+func (w *Window) MouseDown(button MouseButton, x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// MouseUp releases button at client point (x, y).
+func (w *Window) MouseUp(button MouseButton, x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// DoubleClickRight simulates a right mouse button double-click at the specified client
+// coordinates, for applications (CAD, maps) that bind actions to it. Timing follows DoubleClick.
+func (w *Window) DoubleClickRight(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// MultiClick clicks button count times in quick succession at the specified client
+// coordinates, e.g. 3 for triple-click line selection in editors. The Message backend posts
+// the button's double-click message for every second press, as the system does; the HID
+// backend times the clicks like DoubleClick.
+func (w *Window) MultiClick(button MouseButton, x, y int32, count int) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickX clicks extra mouse button which (1 for X1, usually "back"; 2 for X2, usually
+// "forward") at the specified client coordinates. The Message backend posts
+// WM_XBUTTONDOWN/UP with XBUTTON1 or XBUTTON2 in the high word of WPARAM; the HID backend
+// sends the driver's button 4 or 5 strokes.
+func (w *Window) ClickX(x, y int32, which int) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickXMouseAt moves to the specified screen coordinates and clicks extra mouse button
+// which (1 or 2, see Window.ClickX).
+func ClickXMouseAt(x, y int32, which int) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickWithModifiers left-clicks the client point (x, y) with modifier keys held, e.g.
+// KeyCtrl to add a list item to the selection or KeyShift to extend it. mods may contain
+// KeyCtrl, KeyShift and KeyAlt.
+//
+// The Message backend posts WM_KEYDOWN for each modifier, the button messages with
+// MK_CONTROL / MK_SHIFT set in WPARAM, and WM_KEYUP in reverse order; the HID backend holds
+// the physical keys across the click. The modifiers are released even if the click fails.
+func (w *Window) ClickWithModifiers(x, y int32, mods ...Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// MouseDownAt moves to the screen point (x, y) and presses button there, leaving it held
+// until MouseUpAt, e.g. to hold the left button on a pixel for two seconds. The Message
+// backend uses SetCursorPos and mouse_event; the HID backend moves and sends only the down
+// stroke. The point is subject to the work area guard (see SetWorkAreaGuard).
+func MouseDownAt(x, y int32, button MouseButton) error {
+	return ErrUnsupportedPlatform
+}
+
+// MouseUpAt moves to the screen point (x, y) and releases button there.
+func MouseUpAt(x, y int32, button MouseButton) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetStrictMode enables or disables strict mode. In strict mode every window-targeted input
+// call first verifies that the window's thread is actually processing messages (a WM_NULL
+// round-trip via SendMessageTimeout, cached per window for a few seconds) and returns
+// ErrTargetNotPumping otherwise, instead of posting input into a queue nobody reads.
+func SetStrictMode(enabled bool) {}
+
+// SetStrictReadyCheck makes every window-targeted input call fail fast with ErrWindowHung
+// when Windows considers the target hung (IsResponding is false). The check is free, unlike
+// strict mode's WM_NULL round-trip, but only catches a window after 5 seconds of not
+// responding, the point at which Windows shows it as "(Not Responding)".
+func SetStrictReadyCheck(enabled bool) {}
+
+// IsResponding reports whether the window is responding by the system's definition
+// (IsHungAppWindow on its top-level window): false once its thread has not retrieved
+// messages for 5 seconds. Use IsPumping for a prompt answer at the cost of a round-trip.
+func (w *Window) IsResponding() bool {
+	return false
+}
+
+// IsPumping reports whether the window's thread processed a WM_NULL within 500ms.
+// The result is cached per window for a few seconds.
+func (w *Window) IsPumping() (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// SetReadyRetryHook installs fn to receive a ReadyRetry each time a window with a
+// SetReadyWait policy is found not ready and the call waits for it.
+// fn is called without the input lock held. Pass nil to remove it.
+func SetReadyRetryHook(fn func(ReadyRetry)) {}
+
+// SetReadyWait makes input methods on this window wait up to timeout, polling every
+// interval, for the window to become valid and visible (e.g. while it is briefly minimized
+// or animating) instead of failing immediately with ErrWindowGone or ErrWindowNotVisible.
+// The wait happens before the global input lock is taken, so automation of other windows
+// keeps flowing meanwhile. Calls made through a Session do not wait, as the session
+// already holds exclusive access.
+// A timeout <= 0 restores the default of failing immediately; interval <= 0 means 50ms.
+func (w *Window) SetReadyWait(timeout, interval time.Duration) {}
+
+// WithRetry returns the step with its own retry policy.
+func (s ResolveStep) WithRetry(p RetryPolicy) ResolveStep {
+	return *new(ResolveStep)
+}
+
+// ResolveFunc makes a custom step from fn. fn receives the previous step's window, nil
+// for the first step; returning an error retries it.
+func ResolveFunc(name string, fn func(prev *Window) (*Window, error)) ResolveStep {
+	return *new(ResolveStep)
+}
+
+// ByProcessName finds the first top-level window of the process, as ordered by
+// FindByProcessName.
+func ByProcessName(name string) ResolveStep {
+	return *new(ResolveStep)
+}
+
+// MainWindow finds the main window (see MainWindowOfPID) of the previous window's process.
+func MainWindow() ResolveStep {
+	return *new(ResolveStep)
+}
+
+// ChildByClass finds a direct child of the previous window by class name.
+func ChildByClass(class string) ResolveStep {
+	return *new(ResolveStep)
+}
+
+// ChildByID finds a direct child of the previous window by control ID (GetDlgItem).
+func ChildByID(id int) ResolveStep {
+	return *new(ResolveStep)
+}
+
+// InputTarget finds the control of the previous window that text should be typed into:
+// the descendant with keyboard focus if there is one, otherwise the first Edit or RichEdit
+// descendant.
+func InputTarget() ResolveStep {
+	return *new(ResolveStep)
+}
+
+func (e *ResolveError) Error() string {
+	return ""
+}
+
+func (e *ResolveError) Unwrap() []error {
+	return nil
+}
+
+// Resolve runs a discovery chain such as
+//
+//	Resolve(ctx, ByProcessName("app.exe"), MainWindow(), ChildByClass("Edit"))
+//
+// retrying each step with backoff while the target is still starting up, and returns the
+// last step's window. A step that keeps failing past its RetryPolicy.Timeout restarts the
+// chain from the beginning. When ctx ends, the error is a *ResolveError naming the step and
+// its last error. The returned window remembers the chain for Refind.
+func Resolve(ctx context.Context, steps ...ResolveStep) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Refind replays the Resolve chain that produced w and points w at the window it finds
+// now, e.g. after the application restarted and the old handle went stale. Settings
+// attached to the old handle (SetTiming, RegisterRune, ...) do not carry over. It must
+// not run concurrently with other use of w. It returns ErrNotResolved if w did not come
+// from Resolve.
+func (w *Window) Refind(ctx context.Context) error {
+	return ErrUnsupportedPlatform
+}
+
+// RegisterRune maps r to a key for every later Type, KeyFromRune and ValidateTypeable
+// call, adding a character of the local layout (e.g. '§' on a German keyboard) or
+// replacing a built-in mapping. It is safe to call while other goroutines type.
+// Characters that need AltGr cannot be expressed as a KeyDef.
+func RegisterRune(r rune, def KeyDef) {}
+
+// RegisterRunes is RegisterRune for several runes at once.
+func RegisterRunes(defs map[rune]KeyDef) {}
+
+// RegisterRune maps r to a key for text typed into this window, ahead of the global
+// mappings. It applies to every *Window value with the same handle.
+//
+// Under the Message backend, runes registered here are posted as key strokes even when the
+// rest of the text goes out as WM_CHAR, e.g. '\n' as KeyEnter for a target that ignores a
+// newline character.
+func (w *Window) RegisterRune(r rune, def KeyDef) {}
+
+// RegisterRunes is RegisterRune for several runes at once.
+func (w *Window) RegisterRunes(defs map[rune]KeyDef) {}
+
+// ClearRunes removes the window's rune mappings so only the global ones apply.
+func (w *Window) ClearRunes() {}
+
+// ValidateTypeable is the package-level ValidateTypeable, also accepting the runes
+// registered on the window.
+func (w *Window) ValidateTypeable(text string) []RuneIssue {
+	return nil
+}
+
+// ScrollTarget returns the descendant that ScrollAtPoint would send the wheel message to:
+// the deepest visible, enabled child containing the client point (cx, cy).
+// Useful for finding out which control actually handles scrolling in a composite window.
+func (w *Window) ScrollTarget(cx, cy int32) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// ScrollAtPoint scrolls the control under the client point (cx, cy), resolving the deepest
+// visible, enabled child (e.g. a ListView inside a dialog inside a frame) instead of posting
+// to w itself. If posting to that child fails, its ancestors up to w are tried in turn.
+// Under the HID backend the cursor is moved to the point and a physical wheel event is sent.
+func (w *Window) ScrollAtPoint(cx, cy int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollAt moves the cursor to the screen point (x, y) and scrolls the wheel there, e.g. for
+// a window found by screen capture. As with Window.Scroll, delta must be a multiple of
+// WHEEL_DELTA (120) and positive scrolls up. Coordinates are virtual desktop coordinates and
+// may be negative on monitors left of or above the primary one. The Message backend uses
+// SetCursorPos and mouse_event; the HID backend moves and sends a physical wheel event.
+// The point is subject to the work area guard (see SetWorkAreaGuard).
+func ScrollAt(x, y int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollH simulates a horizontal mouse wheel scroll (WM_MOUSEHWHEEL) at the client point
+// (x, y), e.g. for spreadsheets and timelines. delta must be a multiple of WHEEL_DELTA (120);
+// positive scrolls right, negative left, the opposite sense of a vertical delta's "up".
+// Under the HID backend the cursor is moved to the point and a physical horizontal wheel
+// event is sent.
+func (w *Window) ScrollH(x, y int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollHAt moves the cursor to the screen point (x, y) and scrolls the horizontal wheel there.
+// delta follows ScrollH: positive scrolls right. The Message backend uses SetCursorPos and
+// mouse_event; the HID backend moves and sends a physical horizontal wheel event.
+// The point is subject to the work area guard (see SetWorkAreaGuard).
+func ScrollHAt(x, y int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollWithOptions simulates a vertical mouse wheel scroll with the given options.
+func (w *Window) ScrollWithOptions(x, y int32, delta int32, opts ScrollOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// Zoom sends Ctrl+wheel at the center of the client area, the standard zoom gesture of
+// browsers, editors and Explorer. Positive steps zoom in, negative zoom out; each step is one notch.
+func (w *Window) Zoom(steps int) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetAllowSelfTarget lets the synchronous helpers (Text, Value, Selection, ReplaceText,
+// SetPosImmediate) send to windows owned by the calling thread. By default they return
+// ErrSelfTarget for such a window: the message is dispatched to its window procedure
+// inline, while the input lock may be held, so a handler that calls back into winput
+// deadlocks. Type and TypeWithOptions fall back to posted input for such a window instead.
+// Enable it only if the window procedure never calls back into winput.
+func SetAllowSelfTarget(enabled bool) {}
+
+// IsOwnProcess reports whether the window belongs to the calling process, e.g. a test
+// harness or embedded webview. HID input aimed at such a window reenters the program's
+// own message handlers mid-operation.
+func (w *Window) IsOwnProcess() bool {
+	return false
+}
+
+// NewSequence returns an empty Sequence. It needs no cleanup: its worker goroutine only
+// runs while actions are pending.
+func NewSequence() *Sequence {
+	return nil
+}
+
+// Wait blocks until every action issued so far has run and returns their results, in
+// order, since the previous Wait. The error is nil if all of them succeeded, or a
+// *BatchError listing the failures.
+func (sq *Sequence) Wait() (*BatchResult, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Do enqueues an arbitrary action, named name in results and errors.
+func (sq *Sequence) Do(name string, fn func() error) {}
+
+// MoveMouseTo enqueues the package-level MoveMouseTo.
+func (sq *Sequence) MoveMouseTo(x, y int32) {}
+
+// ClickMouseAt enqueues the package-level ClickMouseAt.
+func (sq *Sequence) ClickMouseAt(x, y int32) {}
+
+// DoubleClickMouseAt enqueues the package-level DoubleClickMouseAt.
+func (sq *Sequence) DoubleClickMouseAt(x, y int32) {}
+
+// ClickRightMouseAt enqueues the package-level ClickRightMouseAt.
+func (sq *Sequence) ClickRightMouseAt(x, y int32) {}
+
+// ClickMiddleMouseAt enqueues the package-level ClickMiddleMouseAt.
+func (sq *Sequence) ClickMiddleMouseAt(x, y int32) {}
+
+// KeyDown enqueues the package-level KeyDown.
+func (sq *Sequence) KeyDown(k Key) {}
+
+// KeyUp enqueues the package-level KeyUp.
+func (sq *Sequence) KeyUp(k Key) {}
+
+// Press enqueues the package-level Press.
+func (sq *Sequence) Press(k Key) {}
+
+// PressHotkey enqueues the package-level PressHotkey.
+func (sq *Sequence) PressHotkey(keys ...Key) {}
+
+// Type enqueues the package-level Type.
+func (sq *Sequence) Type(text string) {}
+
+// Window binds w to the sequence so its input methods are enqueued on it.
+func (sq *Sequence) Window(w *Window) *SequenceWindow {
+	return nil
+}
+
+// Move enqueues Window.Move.
+func (sw *SequenceWindow) Move(x, y int32) {}
+
+// MoveRel enqueues Window.MoveRel.
+func (sw *SequenceWindow) MoveRel(dx, dy int32) {}
+
+// Click enqueues Window.Click.
+func (sw *SequenceWindow) Click(x, y int32) {}
+
+// ClickRight enqueues Window.ClickRight.
+func (sw *SequenceWindow) ClickRight(x, y int32) {}
+
+// ClickMiddle enqueues Window.ClickMiddle.
+func (sw *SequenceWindow) ClickMiddle(x, y int32) {}
+
+// DoubleClick enqueues Window.DoubleClick.
+func (sw *SequenceWindow) DoubleClick(x, y int32) {}
+
+// Scroll enqueues Window.Scroll.
+func (sw *SequenceWindow) Scroll(x, y int32, delta int32) {}
+
+// KeyDown enqueues Window.KeyDown.
+func (sw *SequenceWindow) KeyDown(key Key) {}
+
+// KeyUp enqueues Window.KeyUp.
+func (sw *SequenceWindow) KeyUp(key Key) {}
+
+// Press enqueues Window.Press.
+func (sw *SequenceWindow) Press(key Key) {}
+
+// PressHotkey enqueues Window.PressHotkey.
+func (sw *SequenceWindow) PressHotkey(keys ...Key) {}
+
+// Type enqueues Window.Type.
+func (sw *SequenceWindow) Type(text string) {}
+
+// TypeWithOptions enqueues Window.TypeWithOptions.
+func (sw *SequenceWindow) TypeWithOptions(text string, opts TypeOptions) {}
+
+// AcquireSession waits until exclusive input access is available or ctx is done.
+func AcquireSession(ctx context.Context) (*Session, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// AcquireSessionWithOptions is AcquireSession with custom options.
+func AcquireSessionWithOptions(ctx context.Context, opts SessionOptions) (*Session, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Release releases any keys and mouse buttons still held through the session and gives up
+// exclusive access.
+// It is safe to call Release more than once.
+func (s *Session) Release() error {
+	return ErrUnsupportedPlatform
+}
+
+// MoveMouseTo is the session equivalent of the package-level MoveMouseTo.
+func (s *Session) MoveMouseTo(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickMouseAt is the session equivalent of the package-level ClickMouseAt.
+func (s *Session) ClickMouseAt(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickXMouseAt is the session equivalent of the package-level ClickXMouseAt.
+func (s *Session) ClickXMouseAt(x, y int32, which int) error {
+	return ErrUnsupportedPlatform
+}
+
+// DoubleClickMouseAt is the session equivalent of the package-level DoubleClickMouseAt.
+func (s *Session) DoubleClickMouseAt(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickRightMouseAt is the session equivalent of the package-level ClickRightMouseAt.
+func (s *Session) ClickRightMouseAt(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickMiddleMouseAt is the session equivalent of the package-level ClickMiddleMouseAt.
+func (s *Session) ClickMiddleMouseAt(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// DragMouse is the session equivalent of the package-level DragMouse.
+func (s *Session) DragMouse(fromX, fromY, toX, toY int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// MouseDownAt is the session equivalent of the package-level MouseDownAt.
+// The button is released automatically on Release if MouseUpAt is not called.
+func (s *Session) MouseDownAt(x, y int32, button MouseButton) error {
+	return ErrUnsupportedPlatform
+}
+
+// MouseUpAt is the session equivalent of the package-level MouseUpAt.
+func (s *Session) MouseUpAt(x, y int32, button MouseButton) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollAt is the session equivalent of the package-level ScrollAt.
+func (s *Session) ScrollAt(x, y int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollHAt is the session equivalent of the package-level ScrollHAt.
+func (s *Session) ScrollHAt(x, y int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// DragBetween is the session equivalent of the package-level DragBetween.
+func (s *Session) DragBetween(src *Window, sx, sy int32, dst *Window, dx, dy int32, opts DragOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyDown is the session equivalent of the package-level KeyDown.
+// The key is released automatically on Release if KeyUp is not called.
+func (s *Session) KeyDown(k Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyUp is the session equivalent of the package-level KeyUp.
+func (s *Session) KeyUp(k Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// Press is the session equivalent of the package-level Press.
+func (s *Session) Press(k Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// PressHotkey is the session equivalent of the package-level PressHotkey.
+func (s *Session) PressHotkey(keys ...Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// Type is the session equivalent of the package-level Type.
+func (s *Session) Type(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// TypeIntoForeground is the session equivalent of the package-level TypeIntoForegroundWithOptions.
+func (s *Session) TypeIntoForeground(expected *Window, text string, opts ForegroundTypeOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// Window binds w to the session so its input methods run under exclusive access.
+func (s *Session) Window(w *Window) *SessionWindow {
+	return nil
+}
+
+// Move is the session equivalent of Window.Move.
+func (sw *SessionWindow) Move(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// MoveRel is the session equivalent of Window.MoveRel.
+func (sw *SessionWindow) MoveRel(dx, dy int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// Click is the session equivalent of Window.Click.
+func (sw *SessionWindow) Click(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickRight is the session equivalent of Window.ClickRight.
+func (sw *SessionWindow) ClickRight(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickMiddle is the session equivalent of Window.ClickMiddle.
+func (sw *SessionWindow) ClickMiddle(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// DoubleClick is the session equivalent of Window.DoubleClick.
+func (sw *SessionWindow) DoubleClick(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickX is the session equivalent of Window.ClickX.
+func (sw *SessionWindow) ClickX(x, y int32, which int) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickWithModifiers is the session equivalent of Window.ClickWithModifiers.
+func (sw *SessionWindow) ClickWithModifiers(x, y int32, mods ...Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// DoubleClickRight is the session equivalent of Window.DoubleClickRight.
+func (sw *SessionWindow) DoubleClickRight(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// MultiClick is the session equivalent of Window.MultiClick.
+func (sw *SessionWindow) MultiClick(button MouseButton, x, y int32, count int) error {
+	return ErrUnsupportedPlatform
+}
+
+// Hover is the session equivalent of Window.Hover.
+func (sw *SessionWindow) Hover(x, y int32, d time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// MouseDown is the session equivalent of Window.MouseDown.
+// The button is released automatically on Release if MouseUp is not called.
+func (sw *SessionWindow) MouseDown(button MouseButton, x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// MouseUp is the session equivalent of Window.MouseUp.
+func (sw *SessionWindow) MouseUp(button MouseButton, x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// Drag is the session equivalent of Window.Drag.
+func (sw *SessionWindow) Drag(fromX, fromY, toX, toY int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// DragWithOptions is the session equivalent of Window.DragWithOptions.
+func (sw *SessionWindow) DragWithOptions(fromX, fromY, toX, toY int32, opts DragOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// Scroll is the session equivalent of Window.Scroll.
+func (sw *SessionWindow) Scroll(x, y int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollH is the session equivalent of Window.ScrollH.
+func (sw *SessionWindow) ScrollH(x, y int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollWithOptions is the session equivalent of Window.ScrollWithOptions.
+func (sw *SessionWindow) ScrollWithOptions(x, y int32, delta int32, opts ScrollOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// Zoom is the session equivalent of Window.Zoom.
+func (sw *SessionWindow) Zoom(steps int) error {
+	return ErrUnsupportedPlatform
+}
+
+// ScrollAtPoint is the session equivalent of Window.ScrollAtPoint.
+func (sw *SessionWindow) ScrollAtPoint(cx, cy int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// Activate is the session equivalent of Window.Activate.
+func (sw *SessionWindow) Activate() error {
+	return ErrUnsupportedPlatform
+}
+
+// Focus is the session equivalent of Window.Focus.
+func (sw *SessionWindow) Focus() error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyDown is the session equivalent of Window.KeyDown.
+// The key is released automatically on Release if KeyUp is not called.
+func (sw *SessionWindow) KeyDown(key Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyUp is the session equivalent of Window.KeyUp.
+func (sw *SessionWindow) KeyUp(key Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// Press is the session equivalent of Window.Press.
+func (sw *SessionWindow) Press(key Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// PressHotkey is the session equivalent of Window.PressHotkey.
+func (sw *SessionWindow) PressHotkey(keys ...Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// Type is the session equivalent of Window.Type.
+func (sw *SessionWindow) Type(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// TypeWithOptions is the session equivalent of Window.TypeWithOptions.
+func (sw *SessionWindow) TypeWithOptions(text string, opts TypeOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// TypeBidi is the session equivalent of Window.TypeBidi.
+func (sw *SessionWindow) TypeBidi(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// ReplaceText is the session equivalent of Window.ReplaceText.
+func (sw *SessionWindow) ReplaceText(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// Command is the session equivalent of Window.Command.
+func (sw *SessionWindow) Command(id uint16) error {
+	return ErrUnsupportedPlatform
+}
+
+// SysCommand is the session equivalent of Window.SysCommand.
+func (sw *SessionWindow) SysCommand(sc uintptr) error {
+	return ErrUnsupportedPlatform
+}
+
+// ActivateLayout is the session equivalent of Window.ActivateLayout.
+func (sw *SessionWindow) ActivateLayout(klid string) error {
+	return ErrUnsupportedPlatform
+}
+
+// Minimize minimizes the window (SW_MINIMIZE), activating the next top-level window.
+// Input APIs return ErrWindowMinimized for a minimized window until it is restored.
+func (w *Window) Minimize() error {
+	return ErrUnsupportedPlatform
+}
+
+// Maximize maximizes and activates the window (SW_MAXIMIZE), restoring it first if it
+// is minimized.
+func (w *Window) Maximize() error {
+	return ErrUnsupportedPlatform
+}
+
+// Restore returns a minimized or maximized window to its normal size and position and
+// activates it (SW_RESTORE). It does nothing for a window that is already visible in its
+// normal state, so it is safe to call whenever an input call returns ErrWindowMinimized:
+//
+//	if err := w.Click(x, y); errors.Is(err, winput.ErrWindowMinimized) {
+//		w.Restore()
+//		err = w.Click(x, y)
+//	}
+func (w *Window) Restore() error {
+	return ErrUnsupportedPlatform
+}
+
+// Hide hides the window (SW_HIDE). It keeps running but has no taskbar button until Show.
+func (w *Window) Hide() error {
+	return ErrUnsupportedPlatform
+}
+
+// Show shows a hidden window in its current size and position and activates it (SW_SHOW).
+func (w *Window) Show() error {
+	return ErrUnsupportedPlatform
+}
+
+// StartAndFind starts the executable at path (looked up in PATH if it has no directory)
+// and waits up to timeout for its main window (see MainWindowOfPID) to be visible.
+// It returns the window and the process that owns it.
+//
+// Some executables are launchers that hand off to another process (modern Notepad is one).
+// While waiting, StartAndFind therefore also considers processes with the same executable
+// name that were not running before the start; the returned process is then that one.
+// On failure, the started process and any same-name processes that appeared since the start
+// are killed, and the error matches ErrWindowNotFound.
+func StartAndFind(path string, args []string, timeout time.Duration) (*Window, *os.Process, error) {
+	return nil, nil, ErrUnsupportedPlatform
+}
+
+// Style returns the window's style bits (GWL_STYLE), e.g. to check for WS_DISABLED
+// (0x08000000) before sending background input.
+func (w *Window) Style() (uint32, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// ExStyle returns the window's extended style bits (GWL_EXSTYLE), such as
+// WS_EX_TRANSPARENT (0x20), WS_EX_LAYERED (0x80000) or WS_EX_NOACTIVATE (0x8000000).
+func (w *Window) ExStyle() (uint32, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// IsDisabled reports whether the window has the WS_DISABLED style and so ignores mouse
+// and keyboard input, e.g. a parent window while its modal dialog is open.
+func (w *Window) IsDisabled() bool {
+	return false
+}
+
+// IsLayered reports whether the window has the WS_EX_LAYERED style (per-window opacity
+// or a color key), as used by overlays and many custom-drawn applications.
+func (w *Window) IsLayered() bool {
+	return false
+}
+
+// SetOpacity makes the window translucent, from 0 (invisible) to 255 (opaque), e.g. to see
+// through an overlay to the matches beneath it. It adds WS_EX_LAYERED and sets the alpha with
+// SetLayeredWindowAttributes; SetOpacity(255) removes WS_EX_LAYERED again, so do not use it
+// on windows that are layered by design. It returns ErrPermissionDenied for a window of a
+// higher-integrity process.
+func (w *Window) SetOpacity(alpha byte) error {
+	return ErrUnsupportedPlatform
+}
+
+// NewTestWindow creates a top-level window owned by winput, running its own message
+// pump on a dedicated OS thread. Use it to assert exactly what an API posts, or to
+// rehearse a sequence before targeting a real application. Call Close when done.
+func NewTestWindow(opts TestWindowOptions) (*OwnedWindow, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// NextMessage returns the oldest recorded message, waiting up to timeout for one to arrive.
+// It returns ErrTimeout if none arrives in time.
+func (ow *OwnedWindow) NextMessage(timeout time.Duration) (Message, error) {
+	return *new(Message), ErrUnsupportedPlatform
+}
+
+// Close destroys the window and stops its message pump. It is safe to call more than once.
+func (ow *OwnedWindow) Close() error {
+	return ErrUnsupportedPlatform
+}
+
+// SetTiming sets the global timing, used by package-level input functions and by windows
+// without their own timing.
+func SetTiming(t Timing) {}
+
+// GetTiming returns the global timing.
+func GetTiming() Timing {
+	return *new(Timing)
+}
+
+// SetTiming attaches a timing profile to the window. It applies to every *Window value
+// with the same handle and overrides the global timing for input sent to it. Like the other
+// per-window settings, it is discarded once the window is destroyed, so a recycled handle
+// does not inherit it.
+func (w *Window) SetTiming(t Timing) {}
+
+// ClearTiming removes the window's timing profile so the global timing applies again.
+func (w *Window) ClearTiming() {}
+
+// Timing returns the timing in effect for the window: its own profile if set,
+// otherwise the global timing.
+func (w *Window) Timing() Timing {
+	return *new(Timing)
+}
+
+// Title returns the window's title (caption), read at its full length, or "" if the window
+// has none. Unlike Text it never sends the window a message, so it cannot block on a hung target.
+func (w *Window) Title() (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// Err returns why C was closed: ErrWindowGone if the window was destroyed, or the context's
+// error if it was cancelled. Call it only after C has been closed.
+func (tw *TitleWatch) Err() error {
+	return ErrUnsupportedPlatform
+}
+
+// WatchTitle polls the window title every interval (default 100ms) and sends it on the
+// returned watch's channel whenever it changes, e.g. to follow "Downloading (43%) - App"
+// or a dirty marker "document.txt *". The channel is not sent the initial title.
+// Slow receivers only see the most recent title once they catch up.
+func (w *Window) WatchTitle(ctx context.Context, interval time.Duration) (*TitleWatch, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// WaitTitle waits until the window title contains substr.
+func (w *Window) WaitTitle(substr string, timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// WaitTitleMatch waits until the window title matches re.
+func (w *Window) WaitTitleMatch(re *regexp.Regexp, timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// WaitTitleFunc waits until match returns true for the window title, e.g. until a save
+// completes and the dirty marker disappears:
+//
+//	w.WaitTitleFunc(func(t string) bool { return !strings.HasSuffix(t, "*") }, 10*time.Second)
+//
+// It returns ErrTimeout if the title does not match in time, or ErrWindowGone if the window is destroyed.
+func (w *Window) WaitTitleFunc(match func(title string) bool, timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetWaitInterval sets how often the WaitForWindow functions poll for the window.
+// d <= 0 restores the default of 100ms.
+func SetWaitInterval(d time.Duration) {}
+
+// WaitForWindowByClass waits until a visible top-level window of the class exists, e.g.
+// right after starting its application. It returns ErrWindowNotFound if none appears
+// within timeout.
+func WaitForWindowByClass(class string, timeout time.Duration) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// WaitForWindowByClassContext is WaitForWindowByClass, waiting until ctx is done.
+func WaitForWindowByClassContext(ctx context.Context, class string) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// WaitForWindowByTitle waits until a visible top-level window with the exact title exists.
+// It returns ErrWindowNotFound if none appears within timeout.
+func WaitForWindowByTitle(title string, timeout time.Duration) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// WaitForWindowByTitleContext is WaitForWindowByTitle, waiting until ctx is done.
+func WaitForWindowByTitleContext(ctx context.Context, title string) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// WaitForWindowByProcessName waits until the process is running and has a visible
+// top-level window, and returns the first one in FindByProcessName order. It returns
+// ErrWindowNotFound if none appears within timeout.
+func WaitForWindowByProcessName(name string, timeout time.Duration) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// WaitForWindowByProcessNameContext is WaitForWindowByProcessName, waiting until ctx is done.
+func WaitForWindowByProcessNameContext(ctx context.Context, name string) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// WaitIdle waits until the window's process has finished starting up and is waiting for
+// user input (WaitForInputIdle), e.g. right after WaitForWindowByProcessName. It returns
+// ErrTimeout if the process is still busy after timeout. It only covers the first time the
+// process goes idle: a process that was already idle returns at once.
+func (w *Window) WaitIdle(timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// String returns a readable name for the kind.
+func (k WindowEventKind) String() string {
+	return ""
+}
+
+// Watch polls the window every interval (default 100ms) in a goroutine and reports when it
+// is closed, minimized, restored or retitled, so a long session notices a vanished target
+// instead of getting ErrWindowGone halfway through a sequence. The channel is closed after
+// WindowClosed or once stop is called; stop may be called more than once.
+//
+// Events a slow receiver has not yet read are coalesced: only the latest title is kept, and a
+// Minimized followed by a Restored cancel out, so at most one event of each kind is pending.
+func (w *Window) Watch(interval time.Duration) (events <-chan WindowEvent, stop func()) {
+	return nil, nil
+}
+
+// OnClosed calls f in a new goroutine once the window is destroyed, polling every 100ms.
+// Call stop to stop watching; f is then never called.
+func (w *Window) OnClosed(f func()) (stop func()) {
+	return nil
+}
+
+// FindByTitle searches for a top-level window matching the exact title.
+//
+// With MatchCaseInsensitive or MatchTrimSpace the titles are compared in that normalized
+// form instead, returning the topmost match; as with FindByTitleContains, invisible and
+// tool windows are then skipped unless IncludeHidden is also given.
+func FindByTitle(title string, opts ...FindOption) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindByClass searches for a top-level window matching the specified class name.
+func FindByClass(class string) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindByPID returns all top-level windows belonging to the specified Process ID.
+//
+// Results are ordered deterministically: visible windows first, then by descending
+// client area, then by ascending HWND. Owned popups (dialogs, tool palettes) whose
+// owner is also in the result are omitted, so each application window appears once.
+// Use MainWindowOfPID to pick the single most likely main window.
+func FindByPID(pid uint32) ([]*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// MainWindowOfPID returns the window most likely to be the main window of the process:
+// visible, unowned, not a tool window, preferring WS_EX_APPWINDOW and larger windows.
+func MainWindowOfPID(pid uint32) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindByProcessName searches for all top-level windows belonging to a process with the given executable name.
+// Results use the same ordering as FindByPID.
+func FindByProcessName(name string) ([]*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindByProcessPath returns the top-level windows of every process whose full executable path
+// contains substr (case-insensitive, Unicode-aware). Use it to tell apart processes that share
+// an executable name but are installed in different directories.
+// Results use the same ordering as FindByPID.
+func FindByProcessPath(substr string) ([]*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindChildByClass searches for a child window with the specified class name.
+func (w *Window) FindChildByClass(class string) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindChildByClassNth returns the n-th (zero-based) direct child with the specified class
+// name, in z-order, e.g. the second of a dialog's "Edit" controls. It returns
+// ErrWindowNotFound if there is no such child at all, and a *ChildIndexError
+// (ErrChildIndexOutOfRange) carrying the count if n is out of range.
+func (w *Window) FindChildByClassNth(class string, n int) (*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Children returns every descendant window (children, their children, ...) in
+// EnumChildWindows order: depth-first, each child before its own children. A window
+// without children yields an empty slice.
+func (w *Window) Children() ([]*Window, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Text returns the current text/value of the target window or control.
+// It is most reliable for standard Win32 text controls such as Edit and RichEdit.
+func (w *Window) Text() (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// Value returns the current best-effort textual value of the target window or control.
+// It first tries Win32 text retrieval, then falls back to UI Automation for modern controls.
+func (w *Window) Value() (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// IsValid checks if the window handle is valid.
+func (w *Window) IsValid() bool {
+	return false
+}
+
+// IsVisible checks if the window is visible and not minimized.
+func (w *Window) IsVisible() bool {
+	return false
+}
+
+// IsMinimized reports whether the window is minimized (iconic), i.e. Restore would bring it back.
+func (w *Window) IsMinimized() bool {
+	return false
+}
+
+// IsMaximized reports whether the window is maximized.
+func (w *Window) IsMaximized() bool {
+	return false
+}
+
+// ClassName returns the window class name, e.g. "Notepad" or "Chrome_WidgetWin_1",
+// to pick the right window among several FindByPID results.
+func (w *Window) ClassName() (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// ProcessID returns the ID of the process that owns the window.
+func (w *Window) ProcessID() (uint32, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// ThreadID returns the ID of the thread that created the window and runs its message
+// loop, e.g. for AttachThreadInput.
+func (w *Window) ThreadID() (uint32, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// ProcessName returns the executable name of the process that owns the window,
+// e.g. "notepad.exe". Unlike the full image path it is available for protected processes too.
+func (w *Window) ProcessName() (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// SetBackend sets the input simulation backend.
+// If BackendHID is selected, it attempts to initialize the Interception driver immediately.
+// Returns an error if the driver or DLL cannot be loaded.
+func SetBackend(b Backend) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetHIDLibraryPath sets the path to the interception.dll library. Once the HID backend
+// has loaded a library from another path it fails with ErrHIDLibraryLoaded instead of
+// silently keeping the old one; use ReloadHIDLibrary to switch then.
+func SetHIDLibraryPath(path string) error {
+	return ErrUnsupportedPlatform
+}
+
+// HIDLibraryPath returns the path the HID backend loaded interception.dll from, as given
+// to SetHIDLibraryPath, or "" if it has not loaded it.
+func HIDLibraryPath() string {
+	return ""
+}
+
+// ReloadHIDLibrary switches the HID backend to the interception library at path without
+// restarting the process, e.g. between a test build and the real one. The old context is
+// closed and the new library loaded under the driver lock; if that fails, the previous
+// library is restored when possible. It fails with ErrHIDLibraryInUse instead of waiting
+// if input is in progress.
+func ReloadHIDLibrary(path string) error {
+	return ErrUnsupportedPlatform
+}
+
+// Move simulates mouse movement to the specified client coordinates.
+func (w *Window) Move(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// MoveRel simulates relative mouse movement from the current cursor position.
+func (w *Window) MoveRel(dx, dy int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// Click simulates a left mouse button click at the specified client coordinates.
+func (w *Window) Click(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickRight simulates a right mouse button click at the specified client coordinates.
+func (w *Window) ClickRight(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickMiddle simulates a middle mouse button click at the specified client coordinates.
+func (w *Window) ClickMiddle(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// DoubleClick simulates a left mouse button double-click at the specified client coordinates.
+func (w *Window) DoubleClick(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// Scroll simulates a vertical mouse wheel scroll.
+func (w *Window) Scroll(x, y int32, delta int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// MoveMouseTo moves the mouse cursor to the specified absolute screen coordinates (Virtual Desktop).
+func MoveMouseTo(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickMouseAt moves to the specified screen coordinates and performs a left click.
+func ClickMouseAt(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// DoubleClickMouseAt moves to the specified screen coordinates and performs a left double-click.
+func DoubleClickMouseAt(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickRightMouseAt moves to the specified screen coordinates and performs a right click.
+func ClickRightMouseAt(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// ClickMiddleMouseAt moves to the specified screen coordinates and performs a middle click.
+func ClickMiddleMouseAt(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyFromRune attempts to map a unicode character to a Key.
+func KeyFromRune(r rune) (Key, bool) {
+	return *new(Key), false
+}
+
+// KeyFromVK maps a virtual-key code (as used by AutoHotkey, AutoIt and the Windows docs)
+// to a Key under the current keyboard layout. See keyboard.KeyFromVK for the keys it rejects.
+func KeyFromVK(vk uint16) (Key, bool) {
+	return *new(Key), false
+}
+
+// VKFromKey maps a Key to its virtual-key code under the current keyboard layout.
+// For every Key constant k, KeyFromVK(VKFromKey(k)) returns k; the reverse does not hold
+// for VKs that collapse onto one scan code (e.g. VK_LSHIFT and VK_SHIFT).
+func VKFromKey(k Key) uint16 {
+	return 0
+}
+
+// KeyDown sends a key down event to the window.
+func (w *Window) KeyDown(key Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyUp sends a key up event to the window.
+func (w *Window) KeyUp(key Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// Press simulates a key press (down then up).
+func (w *Window) Press(key Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// PressHotkey presses a combination of keys (e.g., Ctrl+A).
+func (w *Window) PressHotkey(keys ...Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// Type simulates typing text.
+func (w *Window) Type(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// TypeWithOptions simulates typing text with the given options.
+func (w *Window) TypeWithOptions(text string, opts TypeOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// ReplaceText replaces the entire content of an Edit or RichEdit control in one message
+// (EM_SETTEXTEX for RichEdit, keeping undo; WM_SETTEXT otherwise). It is message-based under both backends.
+func (w *Window) ReplaceText(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// Selection returns the selection of an Edit or RichEdit control in UTF-16 code units.
+// When start == end there is no selection and start is the caret position.
+func (w *Window) Selection() (start, end int, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// KeyDown simulates a global key down event.
+func KeyDown(k Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyUp simulates a global key up event.
+func KeyUp(k Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// Press simulates a global key press (down then up).
+func Press(k Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// PressHotkey simulates a global combination of keys.
+func PressHotkey(keys ...Key) error {
+	return ErrUnsupportedPlatform
+}
+
+// Type simulates typing text globally.
+func Type(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// GetCursorPos returns the current cursor position in screen coordinates.
+func GetCursorPos() (int32, int32, error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// EnablePerMonitorDPI sets the process to be Per-Monitor DPI aware.
+// On systems without Per-Monitor support it falls back to System Aware;
+// use DPIAwarenessLevel to find out which level was achieved.
+//
+// It is idempotent and safe for concurrent use. Because the OS lets the awareness be set
+// only once, it returns a *DPIAlreadySetError (matching ErrDPIAlreadySet) with the current
+// level if a manifest or host application already chose a level other than Per-Monitor V2.
+func EnablePerMonitorDPI() error {
+	return ErrUnsupportedPlatform
+}
+
+// OSCapabilities reports which version-dependent APIs the running Windows provides, e.g. to
+// find out up front how the package will degrade on Windows 7 or 8. Features whose API is
+// missing either use a documented fallback or return an *UnsupportedOSError naming it.
+func OSCapabilities() Capabilities {
+	return *new(Capabilities)
+}
+
+// DPIAwareness reports the DPI awareness level in effect for the calling thread (the process
+// level unless the thread overrode it). Libraries embedded in a host application can use it
+// to adapt to the host's choice. The error is non-nil only if no query API is available.
+func DPIAwareness() (Level, error) {
+	return *new(Level), ErrUnsupportedPlatform
+}
+
+// DPIAwarenessLevel reports the DPI awareness level currently in effect for the calling
+// thread, which is the process level unless the thread overrode it with
+// SetThreadDpiAwarenessContext. Call it after EnablePerMonitorDPI to find out which step of
+// the fallback chain succeeded.
+func DPIAwarenessLevel() Level {
+	return *new(Level)
+}
+
+// IsPerMonitorDPIAware reports whether the calling thread is Per-Monitor DPI Aware (V1 or V2),
+// which is the process's awareness unless the thread overrode it.
+func IsPerMonitorDPIAware() bool {
+	return false
+}
+
+// DPI returns the DPI of the window.
+func (w *Window) DPI() (uint32, uint32, error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// ClientRect returns the client area dimensions of the window.
+func (w *Window) ClientRect() (width, height int32, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// ScreenToClient converts screen coordinates to client coordinates.
+func (w *Window) ScreenToClient(x, y int32) (cx, cy int32, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// ClientToScreen converts client coordinates to screen coordinates.
+// During a burst (see BeginBurst) the result is computed from the snapshot.
+func (w *Window) ClientToScreen(x, y int32) (sx, sy int32, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// SetWorkAreaGuard enables or disables the work-area guard for the global mouse functions
+// (MoveMouseTo, ClickMouseAt, DoubleClickMouseAt, ClickRightMouseAt, ClickMiddleMouseAt).
+// While enabled, a target on the taskbar or another docked app bar of its monitor is clamped
+// into that monitor's work area before the action, and the call returns an
+// *OutsideWorkAreaError after performing it, so callers learn the point was not where they
+// thought. Auto-hide taskbars are guarded too, although they do not reserve a work area.
+// Targets outside every monitor are left alone.
+func SetWorkAreaGuard(enabled bool) {}
+
+func (e *OutsideWorkAreaError) Error() string {
+	return ""
+}
+
+func (e *OutsideWorkAreaError) Unwrap() error {
+	return ErrUnsupportedPlatform
+}
+
+// SetCrossProcessLock makes HID input (and sessions acquired while BackendHID is selected)
+// also hold a named system mutex, so several winput-based programs using the HID device
+// take turns instead of interleaving strokes. All cooperating programs must use the same name;
+// prefix it with `Global\` to coordinate across logon sessions. An empty name disables the lock.
+//
+// Message-backend operations do not take it, since they target distinct HWNDs.
+// A mutex abandoned by a crashed process is recovered by the next waiter.
+func SetCrossProcessLock(name string) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetCrossProcessLockTimeout sets how long to wait for another process to release the
+// cross-process lock before failing with ErrInputBusy (default 5s).
+func SetCrossProcessLockTimeout(timeout time.Duration) {}
@@ -0,0 +1,67 @@
+//go:build windows
+
+package winput
+
+import "github.com/rpdg/winput/window"
+
+// Style returns the window's style bits (GWL_STYLE), e.g. to check for WS_DISABLED
+// (0x08000000) before sending background input.
+func (w *Window) Style() (uint32, error) {
+	return w.windowLong(window.GWL_STYLE)
+}
+
+// ExStyle returns the window's extended style bits (GWL_EXSTYLE), such as
+// WS_EX_TRANSPARENT (0x20), WS_EX_LAYERED (0x80000) or WS_EX_NOACTIVATE (0x8000000).
+func (w *Window) ExStyle() (uint32, error) {
+	return w.windowLong(window.GWL_EXSTYLE)
+}
+
+// IsDisabled reports whether the window has the WS_DISABLED style and so ignores mouse
+// and keyboard input, e.g. a parent window while its modal dialog is open.
+func (w *Window) IsDisabled() bool {
+	style, err := w.Style()
+	return err == nil && style&window.WS_DISABLED != 0
+}
+
+// IsLayered reports whether the window has the WS_EX_LAYERED style (per-window opacity
+// or a color key), as used by overlays and many custom-drawn applications.
+func (w *Window) IsLayered() bool {
+	ex, err := w.ExStyle()
+	return err == nil && ex&window.WS_EX_LAYERED != 0
+}
+
+// SetOpacity makes the window translucent, from 0 (invisible) to 255 (opaque), e.g. to see
+// through an overlay to the matches beneath it. It adds WS_EX_LAYERED and sets the alpha with
+// SetLayeredWindowAttributes; SetOpacity(255) removes WS_EX_LAYERED again, so do not use it
+// on windows that are layered by design. It returns ErrPermissionDenied for a window of a
+// higher-integrity process.
+func (w *Window) SetOpacity(alpha byte) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	ex := window.GetWindowLong(w.HWND, window.GWL_EXSTYLE)
+	if alpha == 255 {
+		if ex&window.WS_EX_LAYERED == 0 {
+			return nil
+		}
+		return mapAccessDenied(window.SetWindowLong(w.HWND, window.GWL_EXSTYLE, ex&^window.WS_EX_LAYERED))
+	}
+	if ex&window.WS_EX_LAYERED == 0 {
+		if err := window.SetWindowLong(w.HWND, window.GWL_EXSTYLE, ex|window.WS_EX_LAYERED); err != nil {
+			return mapAccessDenied(err)
+		}
+	}
+	return mapAccessDenied(window.SetLayeredWindowAttributes(w.HWND, 0, alpha, window.LWA_ALPHA))
+}
+
+func (w *Window) windowLong(index int32) (uint32, error) {
+	if !w.IsValid() {
+		return 0, ErrWindowGone
+	}
+	v := uint32(window.GetWindowLong(w.HWND, index))
+	// GetWindowLong returns 0 on failure, which is also a possible style.
+	if v == 0 && !w.IsValid() {
+		return 0, ErrWindowGone
+	}
+	return v, nil
+}
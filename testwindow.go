@@ -0,0 +1,256 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/rpdg/winput/window"
+)
+
+// Message is a window message received by an OwnedWindow.
+type Message struct {
+	Msg    uint32
+	WParam uintptr
+	LParam uintptr
+}
+
+// TestWindowOptions configures NewTestWindow.
+type TestWindowOptions struct {
+	// Visible places the window on screen. Otherwise it is shown off-screen without
+	// a taskbar button (input APIs refuse hidden windows, so it cannot be truly hidden).
+	Visible bool
+	Title   string
+	X, Y    int32
+	// Width and Height default to 400x300.
+	Width, Height int32
+	// Buffer is the number of messages kept for NextMessage (default 256). Excess messages are dropped.
+	Buffer int
+	// Children are created inside the window, in order, stacked top to bottom.
+	Children []TestChild
+}
+
+// TestChild is a child control of a test window, such as {Class: "Edit"} or
+// {Class: "Button", Text: "OK"}. Any class works, including the system control classes.
+type TestChild struct {
+	Class, Text string
+	ID          int // control ID, as returned by GetDlgCtrlID
+	Children    []TestChild
+}
+
+// OwnedWindow is a top-level window created and pumped by winput itself.
+// It embeds *Window, so every input method can target it, and records the
+// keyboard, mouse, command and activation/focus messages it receives for inspection via NextMessage.
+type OwnedWindow struct {
+	*Window
+
+	msgs      chan Message
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+const (
+	wsOverlappedWindow = 0x00CF0000
+	wsChild            = 0x40000000
+	wsVisible          = 0x10000000
+	wsExToolWindow     = 0x00000080
+	wsExNoActivate     = 0x08000000
+	swShowNoActivate   = 4
+
+	wmDestroy    = 0x0002
+	wmClose      = 0x0010
+	wmCommand    = 0x0111
+	wmSysCommand = 0x0112
+	wmKeyFirst   = 0x0100
+	wmKeyLast    = 0x0109
+	wmMouseFirst = 0x0200
+	wmMouseLast  = 0x020E
+)
+
+type winMsg struct {
+	HWND    uintptr
+	Message uint32
+	WParam  uintptr
+	LParam  uintptr
+	Time    uint32
+	Pt      window.POINT
+	Private uint32
+}
+
+var (
+	ownedWindows   sync.Map // HWND -> *OwnedWindow
+	testClassOnce  sync.Once
+	testClassName  *uint16
+	testClassErr   error
+	testWindowProc = syscall.NewCallback(testWndProc)
+)
+
+func testWndProc(hwnd uintptr, msg uint32, wparam, lparam uintptr) uintptr {
+	if v, ok := ownedWindows.Load(hwnd); ok && recordable(msg) {
+		select {
+		case v.(*OwnedWindow).msgs <- Message{Msg: msg, WParam: wparam, LParam: lparam}:
+		default:
+		}
+	}
+	if msg == wmDestroy {
+		window.ProcPostQuitMessage.Call(0)
+		return 0
+	}
+	r, _, _ := window.ProcDefWindowProcW.Call(hwnd, uintptr(msg), wparam, lparam)
+	return r
+}
+
+func recordable(msg uint32) bool {
+	return (msg >= wmKeyFirst && msg <= wmKeyLast) ||
+		(msg >= wmMouseFirst && msg <= wmMouseLast) ||
+		msg == wmCommand || msg == wmSysCommand ||
+		msg == window.WM_ACTIVATE || msg == window.WM_ACTIVATEAPP ||
+		msg == window.WM_SETFOCUS || msg == window.WM_KILLFOCUS
+}
+
+func registerTestClass() error {
+	testClassOnce.Do(func() {
+		// CS_DBLCLKS lets tests observe how the system interprets injected clicks.
+		testClassName, testClassErr = window.RegisterWindowClassEx("winput_test_window", window.CS_DBLCLKS, testWindowProc)
+	})
+	return testClassErr
+}
+
+// NewTestWindow creates a top-level window owned by winput, running its own message
+// pump on a dedicated OS thread. Use it to assert exactly what an API posts, or to
+// rehearse a sequence before targeting a real application. Call Close when done.
+func NewTestWindow(opts TestWindowOptions) (*OwnedWindow, error) {
+	if opts.Width <= 0 {
+		opts.Width = 400
+	}
+	if opts.Height <= 0 {
+		opts.Height = 300
+	}
+	if opts.Buffer <= 0 {
+		opts.Buffer = 256
+	}
+	if opts.Title == "" {
+		opts.Title = "winput test window"
+	}
+	if err := registerTestClass(); err != nil {
+		return nil, err
+	}
+
+	ow := &OwnedWindow{
+		msgs: make(chan Message, opts.Buffer),
+		done: make(chan struct{}),
+	}
+	created := make(chan error, 1)
+
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(ow.done)
+
+		x, y := opts.X, opts.Y
+		var exStyle uintptr
+		if !opts.Visible {
+			x, y = -10000, -10000
+			exStyle = wsExToolWindow | wsExNoActivate
+		}
+		title, _ := syscall.UTF16PtrFromString(opts.Title)
+		inst, _, _ := window.ProcGetModuleHandleW.Call(0)
+		hwnd, _, e := window.ProcCreateWindowExW.Call(
+			exStyle,
+			uintptr(unsafe.Pointer(testClassName)),
+			uintptr(unsafe.Pointer(title)),
+			wsOverlappedWindow,
+			uintptr(x), uintptr(y),
+			uintptr(opts.Width), uintptr(opts.Height),
+			0, 0, inst, 0,
+		)
+		if hwnd == 0 {
+			created <- fmt.Errorf("CreateWindowExW failed: %v", e)
+			return
+		}
+		if err := createTestChildren(hwnd, inst, opts.Children); err != nil {
+			window.DestroyWindow(hwnd)
+			created <- err
+			return
+		}
+		ow.Window = &Window{HWND: hwnd}
+		ownedWindows.Store(hwnd, ow)
+		defer ownedWindows.Delete(hwnd)
+		window.ProcShowWindow.Call(hwnd, swShowNoActivate)
+		created <- nil
+
+		var m winMsg
+		for {
+			r, _, _ := window.ProcGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+			if int32(r) <= 0 {
+				return
+			}
+			window.ProcTranslateMessage.Call(uintptr(unsafe.Pointer(&m)))
+			window.ProcDispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+		}
+	}()
+
+	if err := <-created; err != nil {
+		return nil, err
+	}
+	return ow, nil
+}
+
+// createTestChildren creates children (and their own children) inside parent.
+func createTestChildren(parent, inst uintptr, children []TestChild) error {
+	for i, c := range children {
+		class, err := syscall.UTF16PtrFromString(c.Class)
+		if err != nil {
+			return err
+		}
+		text, err := syscall.UTF16PtrFromString(c.Text)
+		if err != nil {
+			return err
+		}
+		hwnd, _, e := window.ProcCreateWindowExW.Call(
+			0,
+			uintptr(unsafe.Pointer(class)),
+			uintptr(unsafe.Pointer(text)),
+			wsChild|wsVisible,
+			0, uintptr(i*30),
+			120, 24,
+			parent, uintptr(c.ID), inst, 0,
+		)
+		if hwnd == 0 {
+			return fmt.Errorf("CreateWindowExW(%q) failed: %v", c.Class, e)
+		}
+		if err := createTestChildren(hwnd, inst, c.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NextMessage returns the oldest recorded message, waiting up to timeout for one to arrive.
+// It returns ErrTimeout if none arrives in time.
+func (ow *OwnedWindow) NextMessage(timeout time.Duration) (Message, error) {
+	select {
+	case m := <-ow.msgs:
+		return m, nil
+	case <-time.After(timeout):
+		return Message{}, ErrTimeout
+	}
+}
+
+// Close destroys the window and stops its message pump. It is safe to call more than once.
+func (ow *OwnedWindow) Close() error {
+	ow.closeOnce.Do(func() {
+		window.ProcPostMessageW.Call(ow.HWND, wmClose, 0, 0)
+	})
+	select {
+	case <-ow.done:
+		return nil
+	case <-time.After(5 * time.Second):
+		return ErrTimeout
+	}
+}
@@ -0,0 +1,92 @@
+//go:build windows
+
+package winput
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rpdg/winput/hid"
+)
+
+// Timing controls the pauses inserted between input events. Targets differ in how fast
+// they accept input: some drop keys unless they are spaced out, others are fine at 0.
+//
+// The effective timing of an operation is resolved as: per-call option (e.g.
+// TypeOptions.Timing) > per-window (Window.SetTiming) > global (SetTiming).
+// The struct is JSON-serializable so that application profiles can be stored with the
+// rest of an automation's configuration.
+type Timing struct {
+	// KeyDelay is the pause after each typed character.
+	KeyDelay time.Duration `json:"keyDelay"`
+	// KeyHold is how long Press holds a key down before releasing it.
+	KeyHold time.Duration `json:"keyHold"`
+	// DoubleClickHold and DoubleClickGap tune HID double clicks: how long each press is held
+	// and the pause between the first release and the second press. Zero selects the defaults
+	// (25ms, and a third of the system double-click time). Both are clamped so the second
+	// press lands within the system double-click time.
+	DoubleClickHold time.Duration `json:"doubleClickHold,omitempty"`
+	DoubleClickGap  time.Duration `json:"doubleClickGap,omitempty"`
+}
+
+// doubleClick returns the HID double-click timing of t.
+func (t Timing) doubleClick() hid.DoubleClickTiming {
+	return hid.DoubleClickTiming{Hold: t.DoubleClickHold, Gap: t.DoubleClickGap}
+}
+
+// DefaultTiming is the global timing in effect until SetTiming is called.
+var DefaultTiming = Timing{
+	KeyDelay: 30 * time.Millisecond,
+	KeyHold:  30 * time.Millisecond,
+}
+
+var (
+	timingMu     sync.RWMutex
+	globalTiming = DefaultTiming
+)
+
+// SetTiming sets the global timing, used by package-level input functions and by windows
+// without their own timing.
+func SetTiming(t Timing) {
+	timingMu.Lock()
+	defer timingMu.Unlock()
+	globalTiming = t
+}
+
+// GetTiming returns the global timing.
+func GetTiming() Timing {
+	timingMu.RLock()
+	defer timingMu.RUnlock()
+	return globalTiming
+}
+
+// SetTiming attaches a timing profile to the window. It applies to every *Window value
+// with the same handle and overrides the global timing for input sent to it. Like the other
+// per-window settings, it is discarded once the window is destroyed, so a recycled handle
+// does not inherit it.
+func (w *Window) SetTiming(t Timing) {
+	updateSettings(w.HWND, func(s *windowSettings) { s.timing = &t })
+}
+
+// ClearTiming removes the window's timing profile so the global timing applies again.
+func (w *Window) ClearTiming() {
+	updateSettings(w.HWND, func(s *windowSettings) { s.timing = nil })
+}
+
+// Timing returns the timing in effect for the window: its own profile if set,
+// otherwise the global timing.
+func (w *Window) Timing() Timing {
+	return w.timingFor(nil)
+}
+
+// timingFor resolves the effective timing for an operation on w, preferring the
+// per-call override when non-nil.
+func (w *Window) timingFor(call *Timing) Timing {
+	if call != nil {
+		return *call
+	}
+	if t := settingsFor(w.HWND).timing; t != nil {
+		return *t
+	}
+	return GetTiming()
+}
@@ -0,0 +1,70 @@
+//go:build windows
+
+package winput_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rpdg/winput"
+)
+
+func TestTimingResolution(t *testing.T) {
+	defer winput.SetTiming(winput.DefaultTiming)
+
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+	w := &winput.Window{HWND: ow.HWND}
+	global := winput.Timing{KeyDelay: 5 * time.Millisecond, KeyHold: 5 * time.Millisecond}
+	winput.SetTiming(global)
+	if got := w.Timing(); got != global {
+		t.Errorf("without a profile, Timing() = %+v, want global %+v", got, global)
+	}
+
+	erp := winput.Timing{KeyDelay: 80 * time.Millisecond}
+	w.SetTiming(erp)
+	defer w.ClearTiming()
+	if got := (&winput.Window{HWND: w.HWND}).Timing(); got != erp {
+		t.Errorf("profile not shared by handle: got %+v, want %+v", got, erp)
+	}
+
+	w.ClearTiming()
+	if got := w.Timing(); got != global {
+		t.Errorf("after ClearTiming, Timing() = %+v, want %+v", got, global)
+	}
+
+	// A destroyed window's profile is dropped, so a recycled handle cannot inherit it.
+	w.SetTiming(erp)
+	ow.Close()
+	if got := w.Timing(); got != global {
+		t.Errorf("after the window was destroyed, Timing() = %+v, want global %+v", got, global)
+	}
+}
+
+func TestTimingKeyEvents(t *testing.T) {
+	defer winput.SetTiming(winput.DefaultTiming)
+	winput.SetBackend(winput.BackendMessage)
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	typeKeys := func(tm winput.Timing) time.Duration {
+		t.Helper()
+		winput.SetTiming(tm)
+		start := time.Now()
+		if err := ow.TypeWithOptions("abcde", winput.TypeOptions{Strategy: winput.TypeStrategyKeyEvents}); err != nil {
+			t.Fatalf("TypeWithOptions failed: %v", err)
+		}
+		return time.Since(start)
+	}
+	fast := typeKeys(winput.Timing{KeyDelay: time.Millisecond, KeyHold: time.Millisecond})
+	slow := typeKeys(winput.Timing{KeyDelay: 100 * time.Millisecond, KeyHold: time.Millisecond})
+	if slow < 500*time.Millisecond || fast > 400*time.Millisecond {
+		t.Errorf("KeyEvents typing took %v with a 1ms KeyDelay and %v with 100ms; SetTiming not applied", fast, slow)
+	}
+}
@@ -0,0 +1,123 @@
+//go:build windows
+
+package winput
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// Title returns the window's title (caption), read at its full length, or "" if the window
+// has none. Unlike Text it never sends the window a message, so it cannot block on a hung target.
+func (w *Window) Title() (string, error) {
+	if !w.IsValid() {
+		return "", ErrWindowGone
+	}
+	title, err := window.GetTitle(w.HWND)
+	if err != nil {
+		if !w.IsValid() {
+			return "", ErrWindowGone
+		}
+		return "", err
+	}
+	return title, nil
+}
+
+// TitleWatch delivers title changes from WatchTitle.
+type TitleWatch struct {
+	// C receives the new title after each change. It is closed when watching stops.
+	C   <-chan string
+	err error
+}
+
+// Err returns why C was closed: ErrWindowGone if the window was destroyed, or the context's
+// error if it was cancelled. Call it only after C has been closed.
+func (tw *TitleWatch) Err() error {
+	return tw.err
+}
+
+// WatchTitle polls the window title every interval (default 100ms) and sends it on the
+// returned watch's channel whenever it changes, e.g. to follow "Downloading (43%) - App"
+// or a dirty marker "document.txt *". The channel is not sent the initial title.
+// Slow receivers only see the most recent title once they catch up.
+func (w *Window) WatchTitle(ctx context.Context, interval time.Duration) (*TitleWatch, error) {
+	if !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+	last, err := window.GetTitle(w.HWND)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan string, 1)
+	tw := &TitleWatch{C: ch}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				tw.err = ctx.Err()
+				close(ch)
+				return
+			case <-ticker.C:
+			}
+			if !w.IsValid() {
+				tw.err = ErrWindowGone
+				close(ch)
+				return
+			}
+			title, err := window.GetTitle(w.HWND)
+			if err != nil || title == last {
+				continue
+			}
+			last = title
+			// Replace an unread title rather than blocking the poller.
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- title
+		}
+	}()
+	return tw, nil
+}
+
+// WaitTitle waits until the window title contains substr.
+func (w *Window) WaitTitle(substr string, timeout time.Duration) error {
+	return w.WaitTitleFunc(func(t string) bool { return strings.Contains(t, substr) }, timeout)
+}
+
+// WaitTitleMatch waits until the window title matches re.
+func (w *Window) WaitTitleMatch(re *regexp.Regexp, timeout time.Duration) error {
+	return w.WaitTitleFunc(re.MatchString, timeout)
+}
+
+// WaitTitleFunc waits until match returns true for the window title, e.g. until a save
+// completes and the dirty marker disappears:
+//
+//	w.WaitTitleFunc(func(t string) bool { return !strings.HasSuffix(t, "*") }, 10*time.Second)
+//
+// It returns ErrTimeout if the title does not match in time, or ErrWindowGone if the window is destroyed.
+func (w *Window) WaitTitleFunc(match func(title string) bool, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		if !w.IsValid() {
+			return ErrWindowGone
+		}
+		if title, err := window.GetTitle(w.HWND); err == nil && match(title) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrTimeout
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,84 @@
+package winput
+
+import (
+	"fmt"
+
+	"github.com/rpdg/winput/keyboard"
+)
+
+// UnsupportedKeyError reports a character that has no key mapping, and where it was in
+// the text being typed. It matches ErrUnsupportedKey with errors.Is.
+type UnsupportedKeyError struct {
+	// Rune is the character that could not be mapped. It is 0 under WithSensitive.
+	Rune rune
+	// Index is the byte offset of Rune in the text.
+	Index int
+	// Sensitive is set if the text was typed under WithSensitive; Rune is then not recorded.
+	Sensitive bool
+}
+
+// newUnsupportedKeyError reports r at index, leaving the character out of the error under
+// WithSensitive so that it does not leak through %+v, JSON logging or error reporters.
+func newUnsupportedKeyError(r rune, index int) *UnsupportedKeyError {
+	if isSensitive() {
+		return &UnsupportedKeyError{Index: index, Sensitive: true}
+	}
+	return &UnsupportedKeyError{Rune: r, Index: index}
+}
+
+func (e *UnsupportedKeyError) Error() string {
+	if e.Sensitive {
+		return fmt.Sprintf("%v at index %d", ErrUnsupportedKey, e.Index)
+	}
+	return fmt.Sprintf("%v: %q (%U) at index %d", ErrUnsupportedKey, e.Rune, e.Rune, e.Index)
+}
+
+func (e *UnsupportedKeyError) Unwrap() error {
+	return ErrUnsupportedKey
+}
+
+// RuneIssue describes a character ValidateTypeable found that cannot be typed as key events.
+type RuneIssue struct {
+	Rune rune
+	// Index is the byte offset of Rune in the text.
+	Index int
+	// Suggestion is a typeable replacement (e.g. a straight quote for a curly one),
+	// or "" if there is none.
+	Suggestion string
+}
+
+func (i RuneIssue) String() string {
+	if i.Suggestion == "" {
+		return fmt.Sprintf("%q (%U) at index %d", i.Rune, i.Rune, i.Index)
+	}
+	return fmt.Sprintf("%q (%U) at index %d, use %q", i.Rune, i.Rune, i.Index, i.Suggestion)
+}
+
+// typeableReplacements maps common pasted typography to keyboard characters.
+var typeableReplacements = map[rune]string{
+	'‘': "'", '’': "'", '‚': "'", '′': "'",
+	'“': `"`, '”': `"`, '„': `"`, '″': `"`, '«': `"`, '»': `"`,
+	'‐': "-", '‑': "-", '‒': "-", '–': "-", '—': "-", '−': "-",
+	'…':      "...",
+	'\u00a0': " ", '\u2007': " ", '\u2009': " ", '\u202f': " ", // no-break and thin spaces
+	'•': "*", '×': "x",
+}
+
+// ValidateTypeable reports every character in text that has no key mapping, without
+// sending anything. Such characters make Type fail with ErrUnsupportedKey under the HID
+// backend; the Message backend sends them as WM_CHAR instead.
+// Issues are returned in order; nil means the whole text is typeable.
+func ValidateTypeable(text string) []RuneIssue {
+	return validateTypeable(text, nil)
+}
+
+func validateTypeable(text string, keys keyboard.RuneMap) []RuneIssue {
+	var issues []RuneIssue
+	for i, r := range text {
+		if _, _, ok := keys.Lookup(r); ok {
+			continue
+		}
+		issues = append(issues, RuneIssue{Rune: r, Index: i, Suggestion: typeableReplacements[r]})
+	}
+	return issues
+}
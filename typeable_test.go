@@ -0,0 +1,128 @@
+package winput
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/rpdg/winput/keyboard"
+)
+
+func TestValidateTypeable(t *testing.T) {
+	if issues := ValidateTypeable("Hello, World! 123\n"); issues != nil {
+		t.Fatalf("plain ASCII: got %v", issues)
+	}
+
+	text := "it’s “done” – \U0001F600"
+	issues := ValidateTypeable(text)
+	want := []RuneIssue{
+		{'’', 2, "'"},
+		{'“', 7, `"`},
+		{'”', 14, `"`},
+		{'–', 18, "-"},
+		{'\U0001F600', 22, ""},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues %v, want %d", len(issues), issues, len(want))
+	}
+	for i, w := range want {
+		if issues[i] != w {
+			t.Errorf("issue %d = %+v, want %+v", i, issues[i], w)
+		}
+		if !strings.HasPrefix(text[issues[i].Index:], string(issues[i].Rune)) {
+			t.Errorf("issue %d: Index %d does not point at %q", i, issues[i].Index, issues[i].Rune)
+		}
+	}
+}
+
+func TestUnsupportedKeyError(t *testing.T) {
+	var err error = &UnsupportedKeyError{Rune: '“', Index: 7}
+	if !errors.Is(err, ErrUnsupportedKey) {
+		t.Error("errors.Is(err, ErrUnsupportedKey) = false")
+	}
+	if msg := err.Error(); !strings.Contains(msg, "U+201C") || !strings.Contains(msg, "index 7") {
+		t.Errorf("Error() = %q, want the code point and index", msg)
+	}
+
+	err = &UnsupportedKeyError{Rune: '“', Index: 7, Sensitive: true}
+	if msg := err.Error(); strings.Contains(msg, "U+201C") || strings.Contains(msg, "“") || !strings.Contains(msg, "index 7") {
+		t.Errorf("sensitive Error() = %q, want only the index", msg)
+	}
+
+	WithSensitive(func() error {
+		e := newUnsupportedKeyError('“', 7)
+		if e.Rune != 0 || !e.Sensitive || e.Index != 7 {
+			t.Errorf("newUnsupportedKeyError under WithSensitive = %+v, want Rune 0, Index 7, Sensitive", *e)
+		}
+		if s := fmt.Sprintf("%+v %#v", *e, *e); strings.Contains(s, "8220") || strings.Contains(s, "“") {
+			t.Errorf("sensitive error leaks the character: %s", s)
+		}
+		return nil
+	})
+	if e := newUnsupportedKeyError('“', 7); e.Rune != '“' || e.Sensitive {
+		t.Errorf("newUnsupportedKeyError = %+v, want Rune '“' and not Sensitive", *e)
+	}
+}
+
+func TestWithSensitive(t *testing.T) {
+	errFn := errors.New("fn")
+	err := WithSensitive(func() error {
+		if err := WithSensitive(func() error { return nil }); err != nil {
+			return err
+		}
+		if !isSensitive() {
+			t.Error("nested WithSensitive ended the outer one")
+		}
+		if d := textDetail("hunter2", isSensitive()); d != "len=7" {
+			t.Errorf("textDetail = %q, want len=7", d)
+		}
+		if d := keyDetail(isSensitive(), KeyCtrl, KeyA, KeyEnter); d != "0x1D+char+0x1C" {
+			t.Errorf("keyDetail = %q, want 0x1D+char+0x1C", d)
+		}
+		return errFn
+	})
+	if err != errFn {
+		t.Errorf("WithSensitive returned %v, want fn's error", err)
+	}
+	if isSensitive() {
+		t.Error("still sensitive after WithSensitive returned")
+	}
+	if d := textDetail("hunter2", isSensitive()); d != `"hunter2"` {
+		t.Errorf("textDetail = %q, want the quoted text", d)
+	}
+}
+
+func TestRegisterRune(t *testing.T) {
+	const r = '\uE000' // private use, never mapped by default
+	if issues := ValidateTypeable(string(r)); len(issues) != 1 {
+		t.Fatalf("before RegisterRune: got %v", issues)
+	}
+
+	perWindow := keyboard.RuneMap{r: {Code: KeyF1}}
+	if issues := validateTypeable(string(r), perWindow); issues != nil {
+		t.Errorf("with per-window mapping: got %v", issues)
+	}
+	if k, _, ok := perWindow.Lookup('a'); !ok || k != KeyA {
+		t.Errorf("per-window Lookup('a') = %v, %v; want the global KeyA", k, ok)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			keyboard.LookupKey('a')
+		}
+	}()
+	keyboard.RegisterRune(r, KeyDef{Code: KeyF2, Shifted: true})
+	wg.Wait()
+
+	if k, shifted, ok := keyboard.LookupKey(r); !ok || k != KeyF2 || !shifted {
+		t.Errorf("LookupKey after RegisterRune = %v, %v, %v", k, shifted, ok)
+	}
+	if k, _, _ := perWindow.Lookup(r); k != KeyF1 {
+		t.Errorf("per-window mapping should win over the global one, got %v", k)
+	}
+}
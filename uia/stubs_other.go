@@ -0,0 +1,14 @@
+// Code generated by internal/stubgen; DO NOT EDIT.
+
+//go:build !windows
+
+package uia
+
+import (
+	"github.com/rpdg/winput/window"
+)
+
+// GetText reads text from a window/control using Windows UI Automation.
+func GetText(hwnd uintptr) (string, error) {
+	return "", window.ErrUnsupportedPlatform
+}
@@ -0,0 +1,10 @@
+package winput
+
+// BehaviorVersion identifies the library's observable input semantics: timing defaults,
+// coordinate conventions, which paths a method falls back to. It is bumped whenever one of
+// them changes in a way that can alter what an existing script does, independently of
+// the module version. Store it alongside long-lived automation assets (target maps,
+// recorded coordinates) and compare on load.
+//
+// Version 1 is the behavior documented in API.md at the time it was introduced.
+const BehaviorVersion = 1
@@ -0,0 +1,116 @@
+//go:build windows
+
+package winput
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+const defaultWaitInterval = 100 * time.Millisecond
+
+var waitInterval atomic.Int64 // time.Duration; 0 means defaultWaitInterval
+
+// SetWaitInterval sets how often the WaitForWindow functions poll for the window.
+// d <= 0 restores the default of 100ms.
+func SetWaitInterval(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	waitInterval.Store(int64(d))
+}
+
+// WaitForWindowByClass waits until a visible top-level window of the class exists, e.g.
+// right after starting its application. It returns ErrWindowNotFound if none appears
+// within timeout.
+func WaitForWindowByClass(class string, timeout time.Duration) (*Window, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return WaitForWindowByClassContext(ctx, class)
+}
+
+// WaitForWindowByClassContext is WaitForWindowByClass, waiting until ctx is done.
+func WaitForWindowByClassContext(ctx context.Context, class string) (*Window, error) {
+	return waitForWindow(ctx, fmt.Sprintf("class %q", class), func() (*Window, error) {
+		return FindByClass(class)
+	})
+}
+
+// WaitForWindowByTitle waits until a visible top-level window with the exact title exists.
+// It returns ErrWindowNotFound if none appears within timeout.
+func WaitForWindowByTitle(title string, timeout time.Duration) (*Window, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return WaitForWindowByTitleContext(ctx, title)
+}
+
+// WaitForWindowByTitleContext is WaitForWindowByTitle, waiting until ctx is done.
+func WaitForWindowByTitleContext(ctx context.Context, title string) (*Window, error) {
+	return waitForWindow(ctx, fmt.Sprintf("title %q", title), func() (*Window, error) {
+		return FindByTitle(title)
+	})
+}
+
+// WaitForWindowByProcessName waits until the process is running and has a visible
+// top-level window, and returns the first one in FindByProcessName order. It returns
+// ErrWindowNotFound if none appears within timeout.
+func WaitForWindowByProcessName(name string, timeout time.Duration) (*Window, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return WaitForWindowByProcessNameContext(ctx, name)
+}
+
+// WaitForWindowByProcessNameContext is WaitForWindowByProcessName, waiting until ctx is done.
+func WaitForWindowByProcessNameContext(ctx context.Context, name string) (*Window, error) {
+	return waitForWindow(ctx, fmt.Sprintf("process %q", name), func() (*Window, error) {
+		windows, err := FindByProcessName(name)
+		if err != nil {
+			return nil, err
+		}
+		return windows[0], nil
+	})
+}
+
+// WaitIdle waits until the window's process has finished starting up and is waiting for
+// user input (WaitForInputIdle), e.g. right after WaitForWindowByProcessName. It returns
+// ErrTimeout if the process is still busy after timeout. It only covers the first time the
+// process goes idle: a process that was already idle returns at once.
+func (w *Window) WaitIdle(timeout time.Duration) error {
+	pid := window.GetWindowPID(w.HWND)
+	if pid == 0 {
+		return ErrWindowGone
+	}
+	idle, err := window.WaitForInputIdle(pid, uint32(timeout/time.Millisecond))
+	if err != nil {
+		return mapAccessDenied(err)
+	}
+	if !idle {
+		return fmt.Errorf("%w: process %d not idle after %v", ErrTimeout, pid, timeout)
+	}
+	return nil
+}
+
+// waitForWindow polls find until it returns a visible window. When ctx ends, the error
+// wraps both ErrWindowNotFound and the context's error.
+func waitForWindow(ctx context.Context, what string, find func() (*Window, error)) (*Window, error) {
+	interval := time.Duration(waitInterval.Load())
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if w, err := find(); err == nil && w.IsVisible() {
+			return w, nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: no visible window with %s: %w", ErrWindowNotFound, what, ctx.Err())
+		}
+	}
+}
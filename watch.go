@@ -0,0 +1,156 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rpdg/winput/window"
+)
+
+// WindowEventKind identifies a change reported by Watch.
+type WindowEventKind int
+
+const (
+	// WindowClosed is sent when the window is destroyed. It is always the last event.
+	WindowClosed WindowEventKind = iota
+	// WindowMinimized is sent when the window is minimized.
+	WindowMinimized
+	// WindowRestored is sent when a minimized window is restored or maximized.
+	WindowRestored
+	// WindowTitleChanged is sent when the title changes. WindowEvent.Title holds the new title.
+	WindowTitleChanged
+)
+
+// String returns a readable name for the kind.
+func (k WindowEventKind) String() string {
+	switch k {
+	case WindowClosed:
+		return "closed"
+	case WindowMinimized:
+		return "minimized"
+	case WindowRestored:
+		return "restored"
+	case WindowTitleChanged:
+		return "title changed"
+	default:
+		return fmt.Sprintf("WindowEventKind(%d)", int(k))
+	}
+}
+
+// WindowEvent is a change in a watched window's lifecycle.
+type WindowEvent struct {
+	Kind  WindowEventKind
+	Title string    // the new title, for WindowTitleChanged
+	Time  time.Time // when the change was observed
+}
+
+// Watch polls the window every interval (default 100ms) in a goroutine and reports when it
+// is closed, minimized, restored or retitled, so a long session notices a vanished target
+// instead of getting ErrWindowGone halfway through a sequence. The channel is closed after
+// WindowClosed or once stop is called; stop may be called more than once.
+//
+// Events a slow receiver has not yet read are coalesced: only the latest title is kept, and a
+// Minimized followed by a Restored cancel out, so at most one event of each kind is pending.
+func (w *Window) Watch(interval time.Duration) (events <-chan WindowEvent, stop func()) {
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+	ch := make(chan WindowEvent)
+	done := make(chan struct{})
+	var once sync.Once
+	go w.watch(interval, ch, done)
+	return ch, func() { once.Do(func() { close(done) }) }
+}
+
+// OnClosed calls f in a new goroutine once the window is destroyed, polling every 100ms.
+// Call stop to stop watching; f is then never called.
+func (w *Window) OnClosed(f func()) (stop func()) {
+	events, stop := w.Watch(0)
+	go func() {
+		for ev := range events {
+			if ev.Kind == WindowClosed {
+				f()
+			}
+		}
+	}()
+	return stop
+}
+
+func (w *Window) watch(interval time.Duration, out chan<- WindowEvent, done <-chan struct{}) {
+	defer close(out)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	minimized := window.IsIconic(w.HWND)
+	title, _ := window.GetTitle(w.HWND)
+	closed := false
+	var pending []WindowEvent
+	for {
+		if !closed {
+			if !window.IsValid(w.HWND) {
+				closed = true
+				pending = append(pending, WindowEvent{Kind: WindowClosed, Time: time.Now()})
+			} else {
+				if m := window.IsIconic(w.HWND); m != minimized {
+					minimized = m
+					kind := WindowRestored
+					if m {
+						kind = WindowMinimized
+					}
+					pending = queueWindowEvent(pending, WindowEvent{Kind: kind, Time: time.Now()})
+				}
+				if t, err := window.GetTitle(w.HWND); err == nil && t != title {
+					title = t
+					pending = queueWindowEvent(pending, WindowEvent{Kind: WindowTitleChanged, Title: t, Time: time.Now()})
+				}
+			}
+		}
+		if closed && len(pending) == 0 {
+			return
+		}
+
+		// Only offer an event while one is pending; a nil channel never sends.
+		var send chan<- WindowEvent
+		var next WindowEvent
+		if len(pending) > 0 {
+			send, next = out, pending[0]
+		}
+		select {
+		case <-done:
+			return
+		case send <- next:
+			pending = pending[1:]
+		case <-ticker.C:
+		}
+	}
+}
+
+// queueWindowEvent adds ev to the unread events, replacing an older event of the same
+// kind and dropping a Minimized/Restored pair that the receiver never saw.
+func queueWindowEvent(pending []WindowEvent, ev WindowEvent) []WindowEvent {
+	opposite := WindowEventKind(-1)
+	switch ev.Kind {
+	case WindowMinimized:
+		opposite = WindowRestored
+	case WindowRestored:
+		opposite = WindowMinimized
+	}
+	kept := pending[:0]
+	cancelled := false
+	for _, p := range pending {
+		switch {
+		case p.Kind == ev.Kind:
+		case p.Kind == opposite:
+			cancelled = true
+		default:
+			kept = append(kept, p)
+		}
+	}
+	if cancelled {
+		return kept
+	}
+	return append(kept, ev)
+}
@@ -0,0 +1,59 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+const (
+	CF_UNICODETEXT = 13
+	GMEM_MOVEABLE  = 0x0002
+)
+
+// SetClipboardText replaces the clipboard contents with text.
+// OpenClipboard is retried briefly because other processes may hold the clipboard.
+func SetClipboardText(text string) error {
+	buf, err := syscall.UTF16FromString(text)
+	if err != nil {
+		return err
+	}
+
+	var opened bool
+	for i := 0; i < 10; i++ {
+		if r, _, _ := ProcOpenClipboard.Call(0); r != 0 {
+			opened = true
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !opened {
+		return fmt.Errorf("OpenClipboard failed")
+	}
+	defer ProcCloseClipboard.Call()
+
+	ProcEmptyClipboard.Call()
+
+	size := uintptr(len(buf)) * 2
+	h, _, e := ProcGlobalAlloc.Call(GMEM_MOVEABLE, size)
+	if h == 0 {
+		return fmt.Errorf("GlobalAlloc failed: %v", e)
+	}
+	p, _, e := ProcGlobalLock.Call(h)
+	if p == 0 {
+		ProcGlobalFree.Call(h)
+		return fmt.Errorf("GlobalLock failed: %v", e)
+	}
+	ProcRtlMoveMemory.Call(p, uintptr(unsafe.Pointer(&buf[0])), size)
+	ProcGlobalUnlock.Call(h)
+
+	if r, _, e := ProcSetClipboardData.Call(CF_UNICODETEXT, h); r == 0 {
+		// Ownership is only transferred on success.
+		ProcGlobalFree.Call(h)
+		return fmt.Errorf("SetClipboardData failed: %v", e)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+//go:build windows
+
+package window
+
+import "syscall"
+
+// findProc resolves an export that older Windows versions may lack. Every such call site
+// goes through it, so that tests can simulate a missing export; calling a LazyProc that
+// failed to resolve panics.
+var findProc = func(p *syscall.LazyProc) error {
+	return p.Find()
+}
+
+// Capabilities reports which version-dependent APIs the running Windows provides.
+// Features whose API is missing either fall back (documented on the feature) or return
+// an *UnsupportedOSError naming it.
+type Capabilities struct {
+	// PerMonitorV2 reports SetProcessDpiAwarenessContext (Windows 10 1703+).
+	// Without it EnablePerMonitorDPI falls back to Per-Monitor v1, then System Aware.
+	PerMonitorV2 bool
+	// PerMonitor reports SetProcessDpiAwareness (Windows 8.1+). Without it and
+	// PerMonitorV2 the process can only be System Aware, so capture refuses multi-monitor setups.
+	PerMonitor bool
+	// GetDpiForWindow (Windows 10 1607+). Without it GetDPI reports the system DPI.
+	GetDpiForWindow bool
+	// ThreadDPIAwareness reports GetThreadDpiAwarenessContext (Windows 10 1607+). Without it
+	// DPIAwareness reports the process level.
+	ThreadDPIAwareness bool
+	// DWM reports DwmSetWindowAttribute and DwmFlush (Vista+, absent on Server Core).
+	// Without them SetTransitionsDisabled fails and DwmFlush does not wait.
+	DWM bool
+	// NormalizeString (Vista+). Without it text normalization returns an *UnsupportedOSError.
+	NormalizeString bool
+	// AutoHideBars reports ABM_GETAUTOHIDEBAREX (Windows 8+). Without it the work area
+	// of a monitor with an auto-hide taskbar includes the taskbar.
+	AutoHideBars bool
+
+	// Missing lists the unavailable exports by name.
+	Missing []string
+}
+
+// OSCapabilities probes the version-dependent APIs. It never calls them.
+func OSCapabilities() Capabilities {
+	var c Capabilities
+	probe := func(procs ...*syscall.LazyProc) bool {
+		ok := true
+		for _, p := range procs {
+			if findProc(p) != nil {
+				c.Missing = append(c.Missing, p.Name)
+				ok = false
+			}
+		}
+		return ok
+	}
+	c.PerMonitorV2 = probe(ProcSetProcessDpiAwarenessCtx)
+	c.PerMonitor = probe(ProcSetProcessDpiAwareness)
+	c.GetDpiForWindow = probe(ProcGetDpiForWindow)
+	c.ThreadDPIAwareness = probe(ProcGetThreadDpiAwarenessCtx, ProcAreDpiAwarenessContextsEqual)
+	c.DWM = probe(ProcDwmSetWindowAttribute, ProcDwmFlush)
+	c.NormalizeString = probe(ProcNormalizeString)
+	if major, minor, _, err := OSVersion(); err == nil {
+		c.AutoHideBars = major > 6 || major == 6 && minor >= 2
+	}
+	return c
+}
@@ -0,0 +1,83 @@
+//go:build windows
+
+package window
+
+import (
+	"errors"
+	"slices"
+	"syscall"
+	"testing"
+)
+
+// simulateMissing makes findProc report the named exports as missing, as on older Windows.
+func simulateMissing(t *testing.T, names ...string) {
+	t.Helper()
+	orig := findProc
+	findProc = func(p *syscall.LazyProc) error {
+		if slices.Contains(names, p.Name) {
+			return errors.New("simulated missing export " + p.Name)
+		}
+		return orig(p)
+	}
+	t.Cleanup(func() { findProc = orig })
+}
+
+func wantUnsupported(t *testing.T, err error, api string) {
+	t.Helper()
+	var ue *UnsupportedOSError
+	if !errors.Is(err, ErrUnsupportedOS) || !errors.As(err, &ue) || ue.API != api {
+		t.Errorf("got %v, want *UnsupportedOSError for %s", err, api)
+	}
+}
+
+func TestWindows7Fallbacks(t *testing.T) {
+	hwnd := WindowFromPoint(0, 0)
+	exStyle := GetWindowLong(hwnd, GWL_EXSTYLE)
+
+	// Windows 7 SP1: no shcore.dll, none of the Windows 10 DPI exports.
+	simulateMissing(t,
+		"SetProcessDpiAwarenessContext", "GetDpiForWindow",
+		"GetThreadDpiAwarenessContext", "AreDpiAwarenessContextsEqual",
+		"SetProcessDpiAwareness", "GetProcessDpiAwareness", "GetDpiForMonitor",
+		"GetWindowLongPtrW")
+
+	c := OSCapabilities()
+	if c.PerMonitorV2 || c.PerMonitor || c.GetDpiForWindow || c.ThreadDPIAwareness {
+		t.Errorf("capabilities = %+v, want the DPI APIs reported missing", c)
+	}
+	for _, name := range []string{"SetProcessDpiAwarenessContext", "GetDpiForWindow", "SetProcessDpiAwareness"} {
+		if !slices.Contains(c.Missing, name) {
+			t.Errorf("Missing = %v, want it to name %s", c.Missing, name)
+		}
+	}
+
+	// Fallbacks must work without touching the missing exports.
+	if dpi, _, err := GetDPI(0); err != nil || dpi == 0 {
+		t.Errorf("GetDPI = %d, %v; want the GetDeviceCaps fallback", dpi, err)
+	}
+	if _, err := DPIAwareness(); err != nil {
+		t.Errorf("DPIAwareness = %v; want the IsProcessDPIAware fallback", err)
+	}
+	if got := GetWindowLong(hwnd, GWL_EXSTYLE); got != exStyle {
+		t.Errorf("GetWindowLong fallback = %#x, want %#x", got, exStyle)
+	}
+}
+
+func TestMissingExportsReturnUnsupportedOS(t *testing.T) {
+	simulateMissing(t,
+		"SetProcessDpiAwarenessContext", "SetProcessDpiAwareness", "SetProcessDPIAware",
+		"GetThreadDpiAwarenessContext", "GetProcessDpiAwareness", "IsProcessDPIAware",
+		"DwmSetWindowAttribute", "DwmFlush", "NormalizeString")
+
+	wantUnsupported(t, enablePerMonitorDPI(), "SetProcessDPIAware")
+	_, err := DPIAwareness()
+	wantUnsupported(t, err, "IsProcessDPIAware")
+	wantUnsupported(t, SetTransitionsDisabled(0, true), "DwmSetWindowAttribute")
+	_, err = NormalizeNFC("é")
+	wantUnsupported(t, err, "NormalizeString")
+	DwmFlush() // must not panic
+
+	if c := OSCapabilities(); c.DWM || c.NormalizeString {
+		t.Errorf("capabilities = %+v, want DWM and NormalizeString missing", c)
+	}
+}
@@ -1,3 +1,5 @@
+//go:build windows
+
 package window
 
 import (
@@ -23,6 +25,19 @@ func IsIconic(hwnd uintptr) bool {
 	return r != 0
 }
 
+// IsZoomed checks if the specified window is maximized.
+func IsZoomed(hwnd uintptr) bool {
+	r, _, _ := ProcIsZoomed.Call(hwnd)
+	return r != 0
+}
+
+// IsHungAppWindow reports whether Windows considers the window's thread hung: it has not
+// retrieved messages for 5 seconds while not waiting for input.
+func IsHungAppWindow(hwnd uintptr) bool {
+	r, _, _ := ProcIsHungAppWindow.Call(hwnd)
+	return r != 0
+}
+
 // IsValid checks if the specified window handle identifies an existing window.
 func IsValid(hwnd uintptr) bool {
 	r, _, _ := ProcIsWindow.Call(hwnd)
@@ -51,6 +66,17 @@ func GetClientRect(hwnd uintptr) (width, height int32, err error) {
 	return rc.Right - rc.Left, rc.Bottom - rc.Top, nil
 }
 
+// GetWindowRect retrieves the bounding rectangle of the window in screen coordinates,
+// including the non-client area (title bar, borders).
+func GetWindowRect(hwnd uintptr) (RECT, error) {
+	var rc RECT
+	r, _, _ := ProcGetWindowRect.Call(hwnd, uintptr(unsafe.Pointer(&rc)))
+	if r == 0 {
+		return RECT{}, fmt.Errorf("GetWindowRect failed")
+	}
+	return rc, nil
+}
+
 // ScreenToClient converts the screen coordinates of a specified point on the screen
 // to client-area coordinates.
 func ScreenToClient(hwnd uintptr, x, y int32) (cx, cy int32, err error) {
@@ -99,3 +125,58 @@ func SetCursorPos(x, y int32) error {
 	}
 	return nil
 }
+
+// GetAncestor flags
+const (
+	GA_PARENT    = 1
+	GA_ROOT      = 2
+	GA_ROOTOWNER = 3
+)
+
+// WindowFromPoint returns the deepest visible window containing the specified screen point.
+// Returns 0 if no window exists at the point.
+func WindowFromPoint(x, y int32) uintptr {
+	var r uintptr
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		// POINT is passed by value and fits in a single 64-bit register.
+		r, _, _ = ProcWindowFromPoint.Call(uintptr(uint32(x)) | uintptr(uint32(y))<<32)
+	} else {
+		r, _, _ = ProcWindowFromPoint.Call(uintptr(x), uintptr(y))
+	}
+	return r
+}
+
+// DeepestChildFromPoint walks RealChildWindowFromPoint from root down to the deepest
+// visible, enabled descendant containing the screen point. It returns root if no child qualifies.
+func DeepestChildFromPoint(root uintptr, x, y int32) uintptr {
+	cur := root
+	for depth := 0; depth < 32; depth++ {
+		cx, cy, err := ScreenToClient(cur, x, y)
+		if err != nil {
+			break
+		}
+		var child uintptr
+		if unsafe.Sizeof(uintptr(0)) == 8 {
+			child, _, _ = ProcRealChildWindowFromPoint.Call(cur, uintptr(uint32(cx))|uintptr(uint32(cy))<<32)
+		} else {
+			child, _, _ = ProcRealChildWindowFromPoint.Call(cur, uintptr(cx), uintptr(cy))
+		}
+		if child == 0 || child == cur || !IsVisible(child) || !IsEnabled(child) {
+			break
+		}
+		cur = child
+	}
+	return cur
+}
+
+// IsEnabled reports whether the window accepts mouse and keyboard input.
+func IsEnabled(hwnd uintptr) bool {
+	r, _, _ := ProcIsWindowEnabled.Call(hwnd)
+	return r != 0
+}
+
+// GetAncestor retrieves the ancestor of the specified window (GA_PARENT, GA_ROOT, GA_ROOTOWNER).
+func GetAncestor(hwnd uintptr, flags uint32) uintptr {
+	r, _, _ := ProcGetAncestor.Call(hwnd, uintptr(flags))
+	return r
+}
@@ -1,7 +1,11 @@
+//go:build windows
+
 package window
 
 import (
 	"fmt"
+	"sync"
+	"syscall"
 	"unsafe"
 )
 
@@ -16,19 +20,77 @@ const (
 	DPI_AWARENESS_CONTEXT_UNAWARE_GDISCALED    = ^uintptr(4) // -5
 )
 
+// DPIAlreadySetError reports the awareness level a process was already locked to when
+// EnablePerMonitorDPI was called. It matches ErrDPIAlreadySet with errors.Is.
+type DPIAlreadySetError struct {
+	Level DPIAwarenessLevel
+}
+
+func (e *DPIAlreadySetError) Error() string {
+	return fmt.Sprintf("%v (current level: %s)", ErrDPIAlreadySet, e.Level)
+}
+
+func (e *DPIAlreadySetError) Unwrap() error {
+	return ErrDPIAlreadySet
+}
+
+const (
+	errorAccessDenied = 5          // ERROR_ACCESS_DENIED
+	eAccessDenied     = 0x80070005 // E_ACCESSDENIED
+)
+
+var (
+	dpiMu  sync.Mutex
+	dpiSet bool // EnablePerMonitorDPI succeeded earlier in this process
+)
+
 // EnablePerMonitorDPI attempts to set the process to Per-Monitor DPI Aware (V2).
 // It falls back to V1 or System Aware on older systems if V2 is unavailable.
+//
+// The OS only lets the awareness be set once per process. EnablePerMonitorDPI is safe to
+// call repeatedly and from several goroutines: it returns nil if it already succeeded or
+// the process is already Per-Monitor V2 aware, and a *DPIAlreadySetError carrying the
+// current level if a manifest or the host application already chose a different one.
 func EnablePerMonitorDPI() error {
+	dpiMu.Lock()
+	defer dpiMu.Unlock()
+
+	if dpiSet {
+		return nil
+	}
+	switch level, err := DPIAwareness(); {
+	case err != nil:
+		// Unknown; try to set it below.
+	case level == DPIPerMonitorV2:
+		dpiSet = true
+		return nil
+	case level != DPIUnaware:
+		return &DPIAlreadySetError{Level: level}
+	}
+
+	if err := enablePerMonitorDPI(); err != nil {
+		return err
+	}
+	dpiSet = true
+	return nil
+}
+
+func enablePerMonitorDPI() error {
+	alreadySet := func() error {
+		level, _ := DPIAwareness()
+		return &DPIAlreadySetError{Level: level}
+	}
+
 	// Try SetProcessDpiAwarenessContext (Win10 1607+)
-	if err := ProcSetProcessDpiAwarenessCtx.Find(); err == nil {
-		// Prefer V2
-		r, _, _ := ProcSetProcessDpiAwarenessCtx.Call(DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2)
-		if r != 0 { // S_OK is not 0 for this API? Wait, check MSDN.
-			// MSDN: "If the function succeeds, the return value is TRUE." -> Non-zero.
-			// Wait, SetProcessDpiAwarenessContext returns BOOL.
-			// If it succeeds, it returns non-zero.
+	if findProc(ProcSetProcessDpiAwarenessCtx) == nil {
+		// Prefer V2. The function returns a BOOL: non-zero on success.
+		r, _, e := ProcSetProcessDpiAwarenessCtx.Call(DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2)
+		if r != 0 {
 			return nil
 		}
+		if e == syscall.Errno(errorAccessDenied) {
+			return alreadySet()
+		}
 		// Fallback to V1
 		r, _, _ = ProcSetProcessDpiAwarenessCtx.Call(DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE)
 		if r != 0 {
@@ -38,32 +100,33 @@ func EnablePerMonitorDPI() error {
 
 	// Fallback for older Windows (8.1/10 early) - Shcore.dll
 	// SetProcessDpiAwareness(PROCESS_PER_MONITOR_DPI_AWARE = 2)
-	procSetProcessDpiAwareness := shcore.NewProc("SetProcessDpiAwareness")
-	if procSetProcessDpiAwareness.Find() == nil {
-		r, _, _ := procSetProcessDpiAwareness.Call(2)
+	if findProc(ProcSetProcessDpiAwareness) == nil {
+		r, _, _ := ProcSetProcessDpiAwareness.Call(2)
 		if r == 0 { // S_OK = 0
 			return nil
 		}
+		if uint32(r) == eAccessDenied {
+			return alreadySet()
+		}
 	}
 
 	// Fallback for Vista/7/8 - User32.dll
 	// SetProcessDPIAware()
-	procSetProcessDPIAware := user32.NewProc("SetProcessDPIAware")
-	if procSetProcessDPIAware.Find() == nil {
-		r, _, _ := procSetProcessDPIAware.Call()
-		if r != 0 { // BOOL, non-zero is success
-			return nil
-		}
+	if err := findProc(ProcSetProcessDPIAware); err != nil {
+		return &UnsupportedOSError{API: "SetProcessDPIAware"}
 	}
-
-	return fmt.Errorf("failed to set DPI awareness on this Windows version")
+	r, _, e := ProcSetProcessDPIAware.Call()
+	if r == 0 { // BOOL, non-zero is success
+		return fmt.Errorf("SetProcessDPIAware failed: %v", e)
+	}
+	return nil
 }
 
 // GetDPI returns the DPI for the specified window.
 // It tries to use GetDpiForWindow (Win10 1607+), falling back to System DPI.
 func GetDPI(hwnd uintptr) (uint32, uint32, error) {
 	// Try GetDpiForWindow (Win10 1607+)
-	if err := ProcGetDpiForWindow.Find(); err == nil {
+	if findProc(ProcGetDpiForWindow) == nil {
 		dpi, _, _ := ProcGetDpiForWindow.Call(hwnd)
 		if dpi != 0 {
 			return uint32(dpi), uint32(dpi), nil
@@ -96,47 +159,136 @@ func GetDPI(hwnd uintptr) (uint32, uint32, error) {
 	return uint32(dpiX), uint32(dpiY), nil
 }
 
-// IsPerMonitorDPIAware checks if the current process is Per-Monitor DPI Aware (V1 or V2).
-// This is critical for ensuring that screen coordinates (GetSystemMetrics, BitBlt) are exact
-// pixels and not virtualized/scaled by the OS.
-func IsPerMonitorDPIAware() bool {
+// DPIAwarenessLevel describes how the process is scaled by the OS.
+// Levels are ordered: a higher value is always at least as accurate as a lower one.
+type DPIAwarenessLevel int
+
+const (
+	// DPIUnaware means the process is bitmap-stretched by the OS on every non-96 DPI monitor.
+	DPIUnaware DPIAwarenessLevel = iota
+	// DPISystemAware means coordinates are exact on the primary monitor only.
+	DPISystemAware
+	// DPIPerMonitor means coordinates are exact on every monitor (Windows 8.1+).
+	DPIPerMonitor
+	// DPIPerMonitorV2 is DPIPerMonitor plus non-client area and dialog scaling (Win10 1703+).
+	DPIPerMonitorV2
+)
+
+// String returns a readable name for the level.
+func (l DPIAwarenessLevel) String() string {
+	switch l {
+	case DPIUnaware:
+		return "Unaware"
+	case DPISystemAware:
+		return "SystemAware"
+	case DPIPerMonitor:
+		return "PerMonitor"
+	case DPIPerMonitorV2:
+		return "PerMonitorV2"
+	default:
+		return fmt.Sprintf("DPIAwarenessLevel(%d)", int(l))
+	}
+}
+
+// GetDPIAwareness queries the DPI awareness level in effect for the calling thread (see
+// DPIAwareness).
+// It returns DPIUnaware if the level cannot be determined; use DPIAwareness to tell the two apart.
+func GetDPIAwareness() DPIAwarenessLevel {
+	level, _ := DPIAwareness()
+	return level
+}
+
+// DPIAwareness queries the DPI awareness level in effect for the calling thread, which is the
+// process level unless the thread overrode it. It walks the same API generations as
+// EnablePerMonitorDPI, newest first, and returns an error only if none of them is available.
+func DPIAwareness() (DPIAwarenessLevel, error) {
 	// 1. Try Modern Win10 API (1607+)
-	if err := ProcGetProcessDpiAwarenessCtx.Find(); err == nil {
-		if err := ProcAreDpiAwarenessContextsEqual.Find(); err == nil {
-			ctx, _, _ := ProcGetProcessDpiAwarenessCtx.Call(0)
-			if ctx != 0 {
-				// Check for V2
-				r1, _, _ := ProcAreDpiAwarenessContextsEqual.Call(ctx, DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2)
-				if r1 != 0 {
-					return true
-				}
-				// Check for V1
-				r2, _, _ := ProcAreDpiAwarenessContextsEqual.Call(ctx, DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE)
-				if r2 != 0 {
-					return true
+	if findProc(ProcGetThreadDpiAwarenessCtx) == nil && findProc(ProcAreDpiAwarenessContextsEqual) == nil {
+		ctx, _, _ := ProcGetThreadDpiAwarenessCtx.Call()
+		if ctx != 0 {
+			for _, c := range []struct {
+				ctx   uintptr
+				level DPIAwarenessLevel
+			}{
+				{DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2, DPIPerMonitorV2},
+				{DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE, DPIPerMonitor},
+				{DPI_AWARENESS_CONTEXT_SYSTEM_AWARE, DPISystemAware},
+			} {
+				r, _, _ := ProcAreDpiAwarenessContextsEqual.Call(ctx, c.ctx)
+				if r != 0 {
+					return c.level, nil
 				}
 			}
+			return DPIUnaware, nil
 		}
 	}
 
 	// 2. Try Windows 8.1 API (Shcore.dll)
-	if err := ProcGetProcessDpiAwareness.Find(); err == nil {
+	if findProc(ProcGetProcessDpiAwareness) == nil {
 		var awareness int32
 		// 0 = current process (NULL handle)
 		r, _, _ := ProcGetProcessDpiAwareness.Call(0, uintptr(unsafe.Pointer(&awareness)))
 		if r == 0 { // S_OK
-			// PROCESS_PER_MONITOR_DPI_AWARE = 2
-			return awareness == 2
+			// PROCESS_DPI_UNAWARE = 0, PROCESS_SYSTEM_DPI_AWARE = 1, PROCESS_PER_MONITOR_DPI_AWARE = 2
+			switch awareness {
+			case 2:
+				return DPIPerMonitor, nil
+			case 1:
+				return DPISystemAware, nil
+			default:
+				return DPIUnaware, nil
+			}
 		}
 	}
 
 	// 3. Try Vista/Win7 API (User32.dll)
-	// Note: This only returns if the process is "Aware" (System Aware),
-	// it doesn't distinguish Per-Monitor. But for older systems, this is the best we can check.
-	if err := ProcIsProcessDPIAware.Find(); err == nil {
+	// Note: This only reports "Aware" (System Aware); Per-Monitor does not exist on these systems.
+	if findProc(ProcIsProcessDPIAware) == nil {
 		r, _, _ := ProcIsProcessDPIAware.Call()
-		return r != 0
+		if r != 0 {
+			return DPISystemAware, nil
+		}
+		return DPIUnaware, nil
 	}
 
-	return false
+	return DPIUnaware, &UnsupportedOSError{API: "IsProcessDPIAware"}
+}
+
+// IsPerMonitorDPIAware checks if the calling thread is Per-Monitor DPI Aware (V1 or V2), which
+// is the process's awareness unless the thread overrode it.
+// This is critical for ensuring that screen coordinates (GetSystemMetrics, BitBlt) are exact
+// pixels and not virtualized/scaled by the OS.
+func IsPerMonitorDPIAware() bool {
+	return GetDPIAwareness() >= DPIPerMonitor
+}
+
+// MONITOR_DEFAULTTONULL makes MonitorFromPoint return 0 for a point on no monitor;
+// MONITOR_DEFAULTTONEAREST makes MonitorFromWindow/MonitorFromPoint return the closest
+// monitor when the window or point is on none.
+const (
+	MONITOR_DEFAULTTONULL    = 0
+	MONITOR_DEFAULTTONEAREST = 2
+)
+
+// MonitorFromWindow returns the handle of the monitor with the largest overlap with the
+// window, or of the nearest monitor if it overlaps none. It returns 0 for an invalid handle.
+func MonitorFromWindow(hwnd uintptr) uintptr {
+	if !IsValid(hwnd) {
+		return 0
+	}
+	r, _, _ := ProcMonitorFromWindow.Call(hwnd, MONITOR_DEFAULTTONEAREST)
+	return r
+}
+
+// MonitorFromPoint returns the handle of the monitor containing the screen point, or 0 if
+// the point is on no monitor.
+func MonitorFromPoint(x, y int32) uintptr {
+	var r uintptr
+	if unsafe.Sizeof(uintptr(0)) == 8 {
+		// POINT is passed by value and fits in a single 64-bit register.
+		r, _, _ = ProcMonitorFromPoint.Call(uintptr(uint32(x))|uintptr(uint32(y))<<32, MONITOR_DEFAULTTONULL)
+	} else {
+		r, _, _ = ProcMonitorFromPoint.Call(uintptr(x), uintptr(y), MONITOR_DEFAULTTONULL)
+	}
+	return r
 }
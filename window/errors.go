@@ -1,5 +1,56 @@
 package window
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var ErrPostMessageFailed = errors.New("PostMessageW failed")
+
+// ErrAccessDenied is returned when a call fails with ERROR_ACCESS_DENIED (typically UIPI).
+var ErrAccessDenied = errors.New("access denied")
+
+// ErrProcessNotFound is returned when no running process matches a name or path.
+var ErrProcessNotFound = errors.New("process not found")
+
+// ErrUnsupportedPlatform is returned by every API when built for a platform other than Windows.
+var ErrUnsupportedPlatform = errors.New("winput: unsupported platform (Windows only)")
+
+// ErrDPIAlreadySet is returned by EnablePerMonitorDPI when the process DPI awareness was
+// already set to a different level (by a manifest or the host application) and can no longer be changed.
+var ErrDPIAlreadySet = errors.New("DPI awareness already set")
+
+// ErrUnsupportedOS is returned when a feature needs an API that this version of Windows
+// does not export. The error is an *UnsupportedOSError naming the API.
+var ErrUnsupportedOS = errors.New("not supported on this version of Windows")
+
+// UnsupportedOSError names the API missing for a feature.
+type UnsupportedOSError struct {
+	API string // e.g. "DwmSetWindowAttribute"
+}
+
+func (e *UnsupportedOSError) Error() string {
+	return fmt.Sprintf("%v: %s is unavailable", ErrUnsupportedOS, e.API)
+}
+
+func (e *UnsupportedOSError) Unwrap() error {
+	return ErrUnsupportedOS
+}
+
+// ErrChildIndexOutOfRange is returned by FindChildByClassNth when fewer children of the class
+// exist than requested. The error is a *ChildIndexError carrying the count.
+var ErrChildIndexOutOfRange = errors.New("child index out of range")
+
+// ChildIndexError reports how many children of Class exist when Index is out of range.
+type ChildIndexError struct {
+	Class        string
+	Index, Count int
+}
+
+func (e *ChildIndexError) Error() string {
+	return fmt.Sprintf("%v: %q index %d, only %d exist", ErrChildIndexOutOfRange, e.Class, e.Index, e.Count)
+}
+
+func (e *ChildIndexError) Unwrap() error {
+	return ErrChildIndexOutOfRange
+}
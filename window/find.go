@@ -1,8 +1,12 @@
+//go:build windows
+
 package window
 
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"unsafe"
 )
@@ -50,21 +54,79 @@ func FindChildByClass(parent uintptr, class string) (uintptr, error) {
 	return ret, nil
 }
 
-// FindByPID returns all top-level windows belonging to the specified Process ID.
-func FindByPID(targetPid uint32) ([]uintptr, error) {
+var (
+	enumOnce     sync.Once
+	enumCallback uintptr
+	enumLists    sync.Map // enumeration ID -> *[]uintptr
+	enumNextID   atomic.Uintptr
+)
+
+// syscall.NewCallback slots are never freed, so every enumeration shares one callback
+// and collects into the list registered under the ID passed as its lparam.
+func enumProc(hwnd uintptr, id uintptr) uintptr {
+	if v, ok := enumLists.Load(id); ok {
+		l := v.(*[]uintptr)
+		*l = append(*l, hwnd)
+	}
+	return 1 // Continue enumeration
+}
+
+// enumWith calls enum with the shared callback and a fresh list ID, and returns the
+// windows collected meanwhile.
+func enumWith(enum func(cb, id uintptr)) []uintptr {
+	enumOnce.Do(func() { enumCallback = syscall.NewCallback(enumProc) })
+	id := enumNextID.Add(1)
 	var hwnds []uintptr
+	enumLists.Store(id, &hwnds)
+	defer enumLists.Delete(id)
+	enum(enumCallback, id)
+	return hwnds
+}
 
-	cb := syscall.NewCallback(func(hwnd uintptr, lparam uintptr) uintptr {
-		var pid uint32
-		ProcGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+// FindChildByID returns the direct child with the specified control ID (GetDlgItem).
+func FindChildByID(parent uintptr, id int) (uintptr, error) {
+	ret, _, _ := ProcGetDlgItem.Call(parent, uintptr(id))
+	if ret == 0 {
+		return 0, fmt.Errorf("child window not found with ID: %d", id)
+	}
+	return ret, nil
+}
 
-		if pid == targetPid {
-			hwnds = append(hwnds, hwnd)
+// FindChildByClassNth returns the n-th (zero-based) direct child with the specified class
+// name, walking siblings with FindWindowExW. If there are children of the class but n is
+// out of range, the error is a *ChildIndexError.
+func FindChildByClassNth(parent uintptr, class string, n int) (uintptr, error) {
+	var child uintptr
+	count := 0
+	for {
+		next, _, _ := ProcFindWindowExW.Call(
+			parent,
+			child,
+			uintptr(unsafe.Pointer(utf16Ptr(class))),
+			0,
+		)
+		if next == 0 {
+			break
 		}
-		return 1 // Continue enumeration
-	})
+		if count == n {
+			return next, nil
+		}
+		child = next
+		count++
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("child window not found with class: %s", class)
+	}
+	return 0, &ChildIndexError{Class: class, Index: n, Count: count}
+}
 
-	r, _, e := ProcEnumWindows.Call(cb, 0)
+// EnumTopLevel returns every top-level window in EnumWindows (z-) order.
+func EnumTopLevel() ([]uintptr, error) {
+	var r uintptr
+	var e error
+	hwnds := enumWith(func(cb, id uintptr) {
+		r, _, e = ProcEnumWindows.Call(cb, id)
+	})
 	if r == 0 {
 		// EnumWindows returns 0 if it fails OR if the callback stops it.
 		// Since our callback always returns 1, r==0 implies failure or no windows (unlikely).
@@ -73,6 +135,32 @@ func FindByPID(targetPid uint32) ([]uintptr, error) {
 			return nil, fmt.Errorf("EnumWindows failed: %w", errno)
 		}
 	}
+	return hwnds, nil
+}
+
+// EnumDescendants returns every descendant of parent (children, their children, ...) in
+// EnumChildWindows order: depth-first, each child before its own children.
+func EnumDescendants(parent uintptr) []uintptr {
+	return enumWith(func(cb, id uintptr) {
+		// The return value is unused and carries no error information.
+		ProcEnumChildWindows.Call(parent, cb, id)
+	})
+}
+
+// FindByPID returns all top-level windows belonging to the specified Process ID,
+// in EnumWindows (z-) order. Use OrderWindows for a stable order.
+func FindByPID(targetPid uint32) ([]uintptr, error) {
+	all, err := EnumTopLevel()
+	if err != nil {
+		return nil, err
+	}
+
+	var hwnds []uintptr
+	for _, hwnd := range all {
+		if GetWindowPID(hwnd) == targetPid {
+			hwnds = append(hwnds, hwnd)
+		}
+	}
 
 	if len(hwnds) == 0 {
 		return nil, fmt.Errorf("no windows found for PID: %d", targetPid)
@@ -100,14 +188,13 @@ type PROCESSENTRY32 struct {
 	ExeFile         [260]uint16
 }
 
-// FindPIDByName searches for a process ID by its executable name (e.g., "notepad.exe").
-// The comparison is case-insensitive.
-func FindPIDByName(name string) (uint32, error) {
+// walkProcesses calls fn for every entry of a Toolhelp process snapshot until fn returns false.
+func walkProcesses(fn func(pe *PROCESSENTRY32) bool) error {
 	const INVALID_HANDLE_VALUE = ^uintptr(0)
 
 	snap, _, err := ProcCreateToolhelp32Snapshot.Call(TH32CS_SNAPPROCESS, 0)
 	if snap == INVALID_HANDLE_VALUE {
-		return 0, fmt.Errorf("CreateToolhelp32Snapshot failed: %v", err)
+		return fmt.Errorf("CreateToolhelp32Snapshot failed: %v", err)
 	}
 	defer ProcCloseHandle.Call(snap)
 
@@ -116,25 +203,216 @@ func FindPIDByName(name string) (uint32, error) {
 
 	r, _, err := ProcProcess32First.Call(snap, uintptr(unsafe.Pointer(&pe32)))
 	if r == 0 {
-		return 0, fmt.Errorf("Process32First failed: %v", err)
-	}
-
-	target := strings.ToLower(name)
-	if !strings.HasSuffix(target, ".exe") {
-		target += ".exe"
+		return fmt.Errorf("Process32First failed: %v", err)
 	}
 
 	for {
-		exeName := syscall.UTF16ToString(pe32.ExeFile[:])
-		if strings.EqualFold(exeName, target) {
-			return pe32.ProcessID, nil
+		if !fn(&pe32) {
+			return nil
 		}
 
 		r, _, _ = ProcProcess32Next.Call(snap, uintptr(unsafe.Pointer(&pe32)))
 		if r == 0 {
-			break
+			return nil
+		}
+	}
+}
+
+// exeNameKey folds an executable name for comparison, adding ".exe" if it is missing.
+func exeNameKey(name string) string {
+	key := Fold(name)
+	if !strings.HasSuffix(key, ".exe") {
+		key += ".exe"
+	}
+	return key
+}
+
+// FindPIDByName searches for a process ID by its executable name (e.g., "notepad.exe").
+// The comparison is case-insensitive using full Unicode case folding.
+func FindPIDByName(name string) (uint32, error) {
+	target := exeNameKey(name)
+
+	var pid uint32
+	found := false
+	err := walkProcesses(func(pe *PROCESSENTRY32) bool {
+		if Fold(syscall.UTF16ToString(pe.ExeFile[:])) == target {
+			pid = pe.ProcessID
+			found = true
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: %s", ErrProcessNotFound, name)
+	}
+	return pid, nil
+}
+
+// FindPIDsByName returns the IDs of every process with the executable name (e.g.
+// "notepad.exe" or "notepad"), in process snapshot order. The comparison is the same as
+// FindPIDByName. When nothing matches it returns an empty slice and ErrProcessNotFound.
+func FindPIDsByName(name string) ([]uint32, error) {
+	target := exeNameKey(name)
+
+	pids := []uint32{}
+	err := walkProcesses(func(pe *PROCESSENTRY32) bool {
+		if Fold(syscall.UTF16ToString(pe.ExeFile[:])) == target {
+			pids = append(pids, pe.ProcessID)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(pids) == 0 {
+		return pids, fmt.Errorf("%w: %s", ErrProcessNotFound, name)
+	}
+	return pids, nil
+}
+
+// ProcessNames returns the executable name (e.g. "notepad.exe") of every running process, keyed by PID.
+// Unlike GetProcessImagePath it also covers protected processes.
+func ProcessNames() (map[uint32]string, error) {
+	names := make(map[uint32]string)
+	err := walkProcesses(func(pe *PROCESSENTRY32) bool {
+		names[pe.ProcessID] = syscall.UTF16ToString(pe.ExeFile[:])
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// ProcessName returns the executable name (e.g. "notepad.exe") of the process pid, or
+// ErrProcessNotFound if it is not running.
+func ProcessName(pid uint32) (string, error) {
+	var name string
+	err := walkProcesses(func(pe *PROCESSENTRY32) bool {
+		if pe.ProcessID == pid {
+			name = syscall.UTF16ToString(pe.ExeFile[:])
+			return false
 		}
+		return true
+	})
+	if err != nil {
+		return "", err
 	}
+	if name == "" {
+		return "", fmt.Errorf("%w: pid %d", ErrProcessNotFound, pid)
+	}
+	return name, nil
+}
+
+const PROCESS_QUERY_LIMITED_INFORMATION = 0x1000
+
+// GetProcessImagePath returns the full executable path of the process.
+// It requires only PROCESS_QUERY_LIMITED_INFORMATION, so it works for most non-protected processes.
+func GetProcessImagePath(pid uint32) (string, error) {
+	h, _, err := ProcOpenProcess.Call(PROCESS_QUERY_LIMITED_INFORMATION, 0, uintptr(pid))
+	if h == 0 {
+		return "", fmt.Errorf("OpenProcess(%d) failed: %v", pid, err)
+	}
+	defer ProcCloseHandle.Call(h)
+
+	buf := make([]uint16, 1024)
+	size := uint32(len(buf))
+	r, _, err := ProcQueryFullProcessImageW.Call(h, 0, uintptr(unsafe.Pointer(&buf[0])), uintptr(unsafe.Pointer(&size)))
+	if r == 0 {
+		return "", fmt.Errorf("QueryFullProcessImageNameW(%d) failed: %v", pid, err)
+	}
+	return syscall.UTF16ToString(buf[:size]), nil
+}
 
-	return 0, fmt.Errorf("process not found: %s", name)
+const PROCESS_TERMINATE = 0x0001
+
+// TerminateProcess ends the process immediately, without giving it a chance to save or clean up.
+func TerminateProcess(pid uint32) error {
+	h, _, e := ProcOpenProcess.Call(PROCESS_TERMINATE, 0, uintptr(pid))
+	if h == 0 {
+		if errno, ok := e.(syscall.Errno); ok && errno == ERROR_ACCESS_DENIED {
+			return fmt.Errorf("OpenProcess(%d): %w", pid, ErrAccessDenied)
+		}
+		return fmt.Errorf("OpenProcess(%d) failed: %v", pid, e)
+	}
+	defer ProcCloseHandle.Call(h)
+
+	if r, _, e := ProcTerminateProcess.Call(h, 1); r == 0 {
+		return fmt.Errorf("TerminateProcess(%d) failed: %v", pid, e)
+	}
+	return nil
+}
+
+const (
+	SYNCHRONIZE  = 0x00100000
+	WAIT_TIMEOUT = 258
+	WAIT_FAILED  = 0xFFFFFFFF
+)
+
+// WaitForInputIdle waits up to timeoutMs until the process has finished its initialization
+// and is waiting for user input with no input pending. It reports false if the timeout
+// elapsed first. A process without a message queue (e.g. a console program) is an error.
+func WaitForInputIdle(pid uint32, timeoutMs uint32) (bool, error) {
+	h, _, e := ProcOpenProcess.Call(SYNCHRONIZE|PROCESS_QUERY_LIMITED_INFORMATION, 0, uintptr(pid))
+	if h == 0 {
+		if errno, ok := e.(syscall.Errno); ok && errno == ERROR_ACCESS_DENIED {
+			return false, fmt.Errorf("OpenProcess(%d): %w", pid, ErrAccessDenied)
+		}
+		return false, fmt.Errorf("OpenProcess(%d) failed: %v", pid, e)
+	}
+	defer ProcCloseHandle.Call(h)
+
+	r, _, e := ProcWaitForInputIdle.Call(h, uintptr(timeoutMs))
+	switch uint32(r) {
+	case 0:
+		return true, nil
+	case WAIT_TIMEOUT:
+		return false, nil
+	default:
+		return false, fmt.Errorf("WaitForInputIdle(%d) failed (no message queue, or the process exited): %v", pid, e)
+	}
+}
+
+// FindPIDsByPath returns the IDs of all processes whose full executable path contains
+// pathSubstring. The comparison is case-insensitive using full Unicode case folding,
+// and '/' is treated as '\'.
+// When nothing matches, the error lists near-miss paths (same executable base name)
+// to aid debugging.
+func FindPIDsByPath(pathSubstring string) ([]uint32, error) {
+	target := Fold(strings.ReplaceAll(pathSubstring, "/", `\`))
+	base := target
+	if i := strings.LastIndex(base, `\`); i >= 0 {
+		base = base[i+1:]
+	}
+
+	var pids []uint32
+	var nearMisses []string
+	err := walkProcesses(func(pe *PROCESSENTRY32) bool {
+		path, err := GetProcessImagePath(pe.ProcessID)
+		if err != nil {
+			return true // Protected or exited process
+		}
+		folded := Fold(path)
+		if strings.Contains(folded, target) {
+			pids = append(pids, pe.ProcessID)
+		} else if base != "" && strings.Contains(Fold(syscall.UTF16ToString(pe.ExeFile[:])), base) && len(nearMisses) < 10 {
+			nearMisses = append(nearMisses, path)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pids) == 0 {
+		if len(nearMisses) > 0 {
+			return nil, fmt.Errorf("%w: no executable path contains %q (near misses: %s)",
+				ErrProcessNotFound, pathSubstring, strings.Join(nearMisses, "; "))
+		}
+		return nil, fmt.Errorf("%w: no executable path contains %q", ErrProcessNotFound, pathSubstring)
+	}
+	return pids, nil
 }
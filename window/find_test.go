@@ -0,0 +1,33 @@
+//go:build windows
+
+package window
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFindPIDsByName(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Base(exe)
+	self := uint32(os.Getpid())
+
+	for _, n := range []string{name, strings.ToUpper(strings.TrimSuffix(name, ".exe"))} {
+		pids, err := FindPIDsByName(n)
+		if err != nil || !slices.Contains(pids, self) {
+			t.Errorf("FindPIDsByName(%q) = %v, %v; want a list containing %d", n, pids, err, self)
+		}
+	}
+
+	pids, err := FindPIDsByName("winput_no_such_process.exe")
+	if !errors.Is(err, ErrProcessNotFound) || pids == nil || len(pids) != 0 {
+		t.Errorf("FindPIDsByName of a missing process = %#v, %v; want an empty slice and ErrProcessNotFound", pids, err)
+	}
+}
@@ -0,0 +1,127 @@
+//go:build windows
+
+package window
+
+import "unsafe"
+
+const (
+	WM_ACTIVATE    = 0x0006
+	WM_SETFOCUS    = 0x0007
+	WM_KILLFOCUS   = 0x0008
+	WM_ACTIVATEAPP = 0x001C
+	WM_NCACTIVATE  = 0x0086
+
+	WA_INACTIVE = 0
+	WA_ACTIVE   = 1
+)
+
+// FlashWindowEx flags
+const (
+	FLASHW_STOP      = 0
+	FLASHW_ALL       = 0x3 // caption and taskbar button
+	FLASHW_TIMERNOFG = 0xC // until the window comes to the foreground
+)
+
+type flashWInfo struct {
+	Size    uint32
+	HWND    uintptr
+	Flags   uint32
+	Count   uint32
+	Timeout uint32
+}
+
+type guiThreadInfo struct {
+	Size      uint32
+	Flags     uint32
+	Active    uintptr
+	Focus     uintptr
+	Capture   uintptr
+	MenuOwner uintptr
+	MoveSize  uintptr
+	Caret     uintptr
+	CaretRect RECT
+}
+
+// GetForegroundWindow returns the window the user is currently working with, or 0
+// (e.g. on the secure desktop or in a non-interactive session).
+func GetForegroundWindow() uintptr {
+	r, _, _ := ProcGetForegroundWindow.Call()
+	return r
+}
+
+// SetForegroundWindow asks the system to bring hwnd to the foreground. It reports false if the
+// request was refused (foreground lock) or the handle is invalid.
+func SetForegroundWindow(hwnd uintptr) bool {
+	r, _, _ := ProcSetForegroundWindow.Call(hwnd)
+	return r != 0
+}
+
+// BringWindowToTop moves hwnd to the top of the z-order, activating it if it is a top-level
+// window of a thread that may set the foreground.
+func BringWindowToTop(hwnd uintptr) bool {
+	r, _, _ := ProcBringWindowToTop.Call(hwnd)
+	return r != 0
+}
+
+// FlashWindow flashes the window's caption and taskbar button count times with the given
+// FLASHW_ flags, at the default cursor blink rate.
+func FlashWindow(hwnd uintptr, flags, count uint32) {
+	fi := flashWInfo{HWND: hwnd, Flags: flags, Count: count}
+	fi.Size = uint32(unsafe.Sizeof(fi))
+	// The return value is the previous caption state, not an error.
+	ProcFlashWindowEx.Call(uintptr(unsafe.Pointer(&fi)))
+}
+
+// AttachThreadInput shares (or, with attach false, stops sharing) the input state of the
+// threads from and to, so that from may change the focus and foreground of to.
+func AttachThreadInput(from, to uint32, attach bool) bool {
+	var a uintptr
+	if attach {
+		a = 1
+	}
+	r, _, _ := ProcAttachThreadInput.Call(uintptr(from), uintptr(to), a)
+	return r != 0
+}
+
+// SetFocus gives hwnd the keyboard focus of the calling thread's input state, which covers
+// another thread only while attached to it with AttachThreadInput. It returns the window that
+// had focus before, or 0 if none did or the call failed.
+func SetFocus(hwnd uintptr) uintptr {
+	r, _, _ := ProcSetFocus.Call(hwnd)
+	return r
+}
+
+// FocusedWindow returns the window with keyboard focus on the foreground window's thread,
+// falling back to the foreground window itself. It returns 0 if there is no foreground window.
+func FocusedWindow() uintptr {
+	fg := GetForegroundWindow()
+	if fg == 0 {
+		return 0
+	}
+	tid, _ := GetThreadProcessID(fg)
+	if focus := ThreadFocus(tid); focus != 0 {
+		return focus
+	}
+	return fg
+}
+
+// ThreadFocus returns the window with keyboard focus on the thread tid, or 0 if it has none.
+func ThreadFocus(tid uint32) uintptr {
+	var gti guiThreadInfo
+	gti.Size = uint32(unsafe.Sizeof(gti))
+	if r, _, _ := ProcGetGUIThreadInfo.Call(uintptr(tid), uintptr(unsafe.Pointer(&gti))); r == 0 {
+		return 0
+	}
+	return gti.Focus
+}
+
+// ThreadCaret returns the window that owns the caret on the thread tid and the caret
+// rectangle in that window's client coordinates. caret is 0 if the thread has no caret.
+func ThreadCaret(tid uint32) (caret uintptr, rc RECT) {
+	var gti guiThreadInfo
+	gti.Size = uint32(unsafe.Sizeof(gti))
+	if r, _, _ := ProcGetGUIThreadInfo.Call(uintptr(tid), uintptr(unsafe.Pointer(&gti))); r == 0 {
+		return 0, RECT{}
+	}
+	return gti.Caret, gti.CaretRect
+}
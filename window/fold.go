@@ -0,0 +1,23 @@
+//go:build windows
+
+package window
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fold returns a case-folded form of s suitable for case-insensitive comparison.
+//
+// Unlike strings.ToLower/EqualFold it maps every case variant of a letter to the same
+// rune, including the Turkish dotted/dotless i (İ, ı), so "İNSTALLER.EXE" and
+// "installer.exe" compare equal regardless of the system locale.
+func Fold(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case 'İ', 'ı':
+			return 'i'
+		}
+		return unicode.ToLower(unicode.ToUpper(r))
+	}, s)
+}
@@ -0,0 +1,24 @@
+//go:build windows
+
+package window
+
+import "testing"
+
+func TestFold(t *testing.T) {
+	cases := []struct{ a, b string }{
+		{"notepad.exe", "NOTEPAD.EXE"},
+		{"İNSTALLER.exe", "installer.exe"},
+		{"ınstaller.exe", "INSTALLER.EXE"},
+		{`C:\Program Files\Ärger\app.exe`, `c:\program files\ärger\APP.EXE`},
+		{"Kelvin K", "kelvin k"},
+	}
+	for _, c := range cases {
+		if Fold(c.a) != Fold(c.b) {
+			t.Errorf("Fold(%q) = %q, Fold(%q) = %q; want equal", c.a, Fold(c.a), c.b, Fold(c.b))
+		}
+	}
+
+	if Fold("a.exe") == Fold("b.exe") {
+		t.Error("Fold must not equate different names")
+	}
+}
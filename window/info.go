@@ -0,0 +1,47 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// ClassName returns the window class name. Class names are limited to 256 characters.
+func ClassName(hwnd uintptr) (string, error) {
+	var buf [256]uint16
+	n, _, e := ProcGetClassNameW.Call(hwnd, uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)))
+	if n == 0 {
+		if errno, ok := e.(syscall.Errno); ok && errno != 0 {
+			return "", fmt.Errorf("GetClassNameW failed: %w", errno)
+		}
+		return "", fmt.Errorf("GetClassNameW failed")
+	}
+	return syscall.UTF16ToString(buf[:n]), nil
+}
+
+// GetClassName returns the window class name, or "" if the handle is invalid.
+func GetClassName(hwnd uintptr) string {
+	name, _ := ClassName(hwnd)
+	return name
+}
+
+// GetWindowPID returns the ID of the process that created the window, or 0 if the handle is invalid.
+func GetWindowPID(hwnd uintptr) uint32 {
+	_, pid := GetThreadProcessID(hwnd)
+	return pid
+}
+
+// CurrentThreadID returns the ID of the calling OS thread.
+func CurrentThreadID() uint32 {
+	r, _, _ := ProcGetCurrentThreadId.Call()
+	return uint32(r)
+}
+
+// GetThreadProcessID returns the IDs of the thread and process that created the window,
+// or zeros if the handle is invalid.
+func GetThreadProcessID(hwnd uintptr) (tid, pid uint32) {
+	r, _, _ := ProcGetWindowThreadProcessId.Call(hwnd, uintptr(unsafe.Pointer(&pid)))
+	return uint32(r), pid
+}
@@ -0,0 +1,52 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"runtime"
+	"syscall"
+)
+
+// SetLayeredWindowAttributes flags
+const (
+	LWA_COLORKEY = 0x1
+	LWA_ALPHA    = 0x2
+)
+
+// SetWindowLong sets a window attribute (e.g. GWL_EXSTYLE), falling back to SetWindowLongW
+// like GetWindowLong. ERROR_ACCESS_DENIED (a higher-integrity target) is reported as ErrAccessDenied.
+func SetWindowLong(hwnd uintptr, index int32, value uintptr) error {
+	proc := ProcSetWindowLongPtrW
+	if findProc(proc) != nil {
+		proc = ProcSetWindowLongW
+	}
+	// The previous value may legitimately be 0, so failure is only told apart by the
+	// thread's last error, which must be cleared on the same OS thread first.
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	ProcSetLastError.Call(0)
+	r, _, e := proc.Call(hwnd, uintptr(index), value)
+	if r == 0 {
+		if errno, ok := e.(syscall.Errno); ok && errno != 0 {
+			if errno == ERROR_ACCESS_DENIED {
+				return fmt.Errorf("SetWindowLong: %w", ErrAccessDenied)
+			}
+			return fmt.Errorf("SetWindowLong failed: %v", e)
+		}
+	}
+	return nil
+}
+
+// SetLayeredWindowAttributes sets the opacity (LWA_ALPHA) or transparent color (LWA_COLORKEY)
+// of a window with the WS_EX_LAYERED style.
+func SetLayeredWindowAttributes(hwnd uintptr, colorKey uint32, alpha byte, flags uint32) error {
+	r, _, e := ProcSetLayeredWindowAttributes.Call(hwnd, uintptr(colorKey), uintptr(alpha), uintptr(flags))
+	if r == 0 {
+		if errno, ok := e.(syscall.Errno); ok && errno == ERROR_ACCESS_DENIED {
+			return fmt.Errorf("SetLayeredWindowAttributes: %w", ErrAccessDenied)
+		}
+		return fmt.Errorf("SetLayeredWindowAttributes failed: %v", e)
+	}
+	return nil
+}
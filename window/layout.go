@@ -0,0 +1,128 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// WM_INPUTLANGCHANGEREQUEST asks a window's thread to switch its input language.
+	WM_INPUTLANGCHANGEREQUEST = 0x0050
+
+	keyboardLayoutsKey = `SYSTEM\CurrentControlSet\Control\Keyboard Layouts`
+)
+
+// KeyboardLayout describes an installed input locale (HKL).
+type KeyboardLayout struct {
+	HKL    uintptr
+	KLID   string // keyboard layout identifier, e.g. "00000409" (US) or "00010409" (Dvorak)
+	LangID uint16
+	Locale string // e.g. "en-US"
+	Name   string // layout display name from the registry, e.g. "US"
+}
+
+// KeyboardLayouts returns the input locales loaded in the current session, in the order
+// of the language bar.
+func KeyboardLayouts() ([]KeyboardLayout, error) {
+	n, _, _ := ProcGetKeyboardLayoutList.Call(0, 0)
+	if n == 0 {
+		return nil, fmt.Errorf("GetKeyboardLayoutList failed")
+	}
+	hkls := make([]uintptr, n)
+	n, _, _ = ProcGetKeyboardLayoutList.Call(n, uintptr(unsafe.Pointer(&hkls[0])))
+	hkls = hkls[:n]
+
+	out := make([]KeyboardLayout, 0, len(hkls))
+	for _, h := range hkls {
+		out = append(out, describeLayout(h))
+	}
+	return out, nil
+}
+
+// CurrentKeyboardLayout returns the HKL active on the given thread (0 = calling thread).
+func CurrentKeyboardLayout(tid uint32) uintptr {
+	r, _, _ := ProcGetKeyboardLayout.Call(uintptr(tid))
+	return r
+}
+
+// ActivateKeyboardLayout makes hkl the active layout of the calling thread and returns
+// the previously active one.
+func ActivateKeyboardLayout(hkl uintptr) (uintptr, error) {
+	prev, _, e := ProcActivateKeyboardLayout.Call(hkl, 0)
+	if prev == 0 {
+		return 0, fmt.Errorf("ActivateKeyboardLayout failed: %v", e)
+	}
+	return prev, nil
+}
+
+func describeLayout(hkl uintptr) KeyboardLayout {
+	lang := uint16(hkl)
+	device := uint16(hkl >> 16)
+	l := KeyboardLayout{HKL: hkl, LangID: lang, Locale: localeName(lang)}
+
+	if device&0xF000 == 0xF000 {
+		// A variant layout: the low 12 bits are the registry "Layout Id".
+		l.KLID = findLayoutByID(device & 0x0FFF)
+	} else {
+		l.KLID = fmt.Sprintf("%08X", device)
+	}
+	if l.KLID != "" {
+		l.Name, _ = layoutRegString(l.KLID, "Layout Text")
+	}
+	return l
+}
+
+func localeName(lang uint16) string {
+	var buf [85]uint16 // LOCALE_NAME_MAX_LENGTH
+	n, _, _ := ProcLCIDToLocaleName.Call(uintptr(lang), uintptr(unsafe.Pointer(&buf[0])), uintptr(len(buf)), 0)
+	if n == 0 {
+		return ""
+	}
+	return syscall.UTF16ToString(buf[:])
+}
+
+// findLayoutByID scans the installed layouts for the one whose "Layout Id" matches id.
+func findLayoutByID(id uint16) string {
+	var root syscall.Handle
+	if syscall.RegOpenKeyEx(syscall.HKEY_LOCAL_MACHINE, utf16Ptr(keyboardLayoutsKey), 0, syscall.KEY_READ, &root) != nil {
+		return ""
+	}
+	defer syscall.RegCloseKey(root)
+
+	for i := uint32(0); ; i++ {
+		var name [16]uint16
+		n := uint32(len(name))
+		if syscall.RegEnumKeyEx(root, i, &name[0], &n, nil, nil, nil, nil) != nil {
+			return ""
+		}
+		klid := syscall.UTF16ToString(name[:n])
+		v, err := layoutRegString(klid, "Layout Id")
+		if err != nil {
+			continue
+		}
+		if got, err := strconv.ParseUint(v, 16, 16); err == nil && uint16(got) == id {
+			return strings.ToUpper(klid)
+		}
+	}
+}
+
+func layoutRegString(klid, value string) (string, error) {
+	var k syscall.Handle
+	if err := syscall.RegOpenKeyEx(syscall.HKEY_LOCAL_MACHINE, utf16Ptr(keyboardLayoutsKey+`\`+klid), 0, syscall.KEY_READ, &k); err != nil {
+		return "", err
+	}
+	defer syscall.RegCloseKey(k)
+
+	var buf [256]uint16
+	size := uint32(len(buf) * 2)
+	var typ uint32
+	if err := syscall.RegQueryValueEx(k, utf16Ptr(value), nil, &typ, (*byte)(unsafe.Pointer(&buf[0])), &size); err != nil {
+		return "", err
+	}
+	return syscall.UTF16ToString(buf[:size/2]), nil
+}
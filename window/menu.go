@@ -0,0 +1,125 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	WM_CLOSE      = 0x0010
+	WM_COMMAND    = 0x0111
+	WM_SYSCOMMAND = 0x0112
+
+	SC_MINIMIZE = 0xF020
+	SC_MAXIMIZE = 0xF030
+	SC_CLOSE    = 0xF060
+	SC_RESTORE  = 0xF120
+
+	MIIM_ID      = 0x0002
+	MIIM_SUBMENU = 0x0004
+	MIIM_STRING  = 0x0040
+	MIIM_FTYPE   = 0x0100
+
+	MFT_SEPARATOR = 0x0800
+)
+
+type menuItemInfoW struct {
+	Size         uint32
+	Mask         uint32
+	Type         uint32
+	State        uint32
+	ID           uint32
+	SubMenu      uintptr
+	BmpChecked   uintptr
+	BmpUnchecked uintptr
+	ItemData     uintptr
+	TypeData     *uint16
+	Cch          uint32
+	BmpItem      uintptr
+}
+
+// MenuItem is a command-bearing entry of a window's menu bar.
+type MenuItem struct {
+	ID   uint16
+	Text string   // label without '&' markers or accelerator text
+	Path []string // labels of the enclosing submenus, outermost first
+}
+
+// PostMessage posts a message to the window's queue.
+func PostMessage(hwnd uintptr, msg uint32, wparam, lparam uintptr) error {
+	r, _, e := ProcPostMessageW.Call(hwnd, uintptr(msg), wparam, lparam)
+	if r == 0 {
+		if errno, ok := e.(syscall.Errno); ok && errno != 0 {
+			return fmt.Errorf("%w: %v", ErrPostMessageFailed, errno)
+		}
+		return ErrPostMessageFailed
+	}
+	return nil
+}
+
+// MenuItems walks the window's menu bar recursively and returns every item that carries a command ID.
+// Separators and submenu headers are skipped. Windows without a classic menu bar return an empty slice.
+func MenuItems(hwnd uintptr) ([]MenuItem, error) {
+	menu, _, _ := ProcGetMenu.Call(hwnd)
+	if menu == 0 {
+		return nil, nil
+	}
+	var items []MenuItem
+	if err := walkMenu(menu, nil, &items, 0); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func walkMenu(menu uintptr, path []string, items *[]MenuItem, depth int) error {
+	if depth > 16 {
+		return fmt.Errorf("menu nesting too deep")
+	}
+	n, _, _ := ProcGetMenuItemCount.Call(menu)
+	if int32(n) < 0 {
+		return fmt.Errorf("GetMenuItemCount failed")
+	}
+	for i := uintptr(0); i < n; i++ {
+		var buf [256]uint16
+		mii := menuItemInfoW{
+			Mask:     MIIM_ID | MIIM_SUBMENU | MIIM_STRING | MIIM_FTYPE,
+			TypeData: &buf[0],
+			Cch:      uint32(len(buf)),
+		}
+		mii.Size = uint32(unsafe.Sizeof(mii))
+		if r, _, _ := ProcGetMenuItemInfoW.Call(menu, i, 1, uintptr(unsafe.Pointer(&mii))); r == 0 {
+			continue
+		}
+		if mii.Type&MFT_SEPARATOR != 0 {
+			continue
+		}
+		label := cleanMenuText(syscall.UTF16ToString(buf[:]))
+		if mii.SubMenu != 0 {
+			sub := append(append([]string(nil), path...), label)
+			if err := walkMenu(mii.SubMenu, sub, items, depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		*items = append(*items, MenuItem{
+			ID:   uint16(mii.ID),
+			Text: label,
+			Path: append([]string(nil), path...),
+		})
+	}
+	return nil
+}
+
+// cleanMenuText strips the accelerator text after a tab and '&' mnemonic markers ("&&" is a literal '&').
+func cleanMenuText(s string) string {
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.ReplaceAll(s, "&&", "\x00")
+	s = strings.ReplaceAll(s, "&", "")
+	return strings.ReplaceAll(s, "\x00", "&")
+}
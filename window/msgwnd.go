@@ -0,0 +1,101 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// HWND_MESSAGE is the parent handle for message-only windows.
+	HWND_MESSAGE = ^uintptr(2) // -3
+
+	COLOR_WINDOW = 5
+)
+
+type wndClassExW struct {
+	Size       uint32
+	Style      uint32
+	WndProc    uintptr
+	ClsExtra   int32
+	WndExtra   int32
+	Instance   uintptr
+	Icon       uintptr
+	Cursor     uintptr
+	Background uintptr
+	MenuName   *uint16
+	ClassName  *uint16
+	IconSm     uintptr
+}
+
+var (
+	msgClassOnce sync.Once
+	msgClassName *uint16
+	msgClassErr  error
+)
+
+// CS_DBLCLKS makes a window class receive WM_LBUTTONDBLCLK and the other double-click messages.
+const CS_DBLCLKS = 0x0008
+
+// RegisterWindowClass registers a window class with the given procedure (a syscall.NewCallback
+// or DefWindowProcW address) and returns the class name for CreateWindowExW.
+func RegisterWindowClass(name string, wndProc uintptr) (*uint16, error) {
+	return RegisterWindowClassEx(name, 0, wndProc)
+}
+
+// RegisterWindowClassEx is RegisterWindowClass with class styles (e.g. CS_DBLCLKS).
+func RegisterWindowClassEx(name string, style uint32, wndProc uintptr) (*uint16, error) {
+	className, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	inst, _, _ := ProcGetModuleHandleW.Call(0)
+	wc := wndClassExW{
+		Style:      style,
+		WndProc:    wndProc,
+		Instance:   inst,
+		Background: COLOR_WINDOW + 1,
+		ClassName:  className,
+	}
+	wc.Size = uint32(unsafe.Sizeof(wc))
+	if r, _, e := ProcRegisterClassExW.Call(uintptr(unsafe.Pointer(&wc))); r == 0 {
+		return nil, fmt.Errorf("RegisterClassExW failed: %v", e)
+	}
+	return className, nil
+}
+
+// CreateMessageWindow creates a message-only window owned by the calling thread.
+// Messages sent to it from the same thread are handled synchronously by DefWindowProcW,
+// so no message pump is required. The caller must lock the OS thread and destroy the window.
+func CreateMessageWindow() (uintptr, error) {
+	msgClassOnce.Do(func() {
+		msgClassName, msgClassErr = RegisterWindowClass("winput_message_window", ProcDefWindowProcW.Addr())
+	})
+	if msgClassErr != nil {
+		return 0, msgClassErr
+	}
+	inst, _, _ := ProcGetModuleHandleW.Call(0)
+	hwnd, _, e := ProcCreateWindowExW.Call(
+		0,
+		uintptr(unsafe.Pointer(msgClassName)),
+		0,
+		0,
+		0, 0, 0, 0,
+		HWND_MESSAGE,
+		0,
+		inst,
+		0,
+	)
+	if hwnd == 0 {
+		return 0, fmt.Errorf("CreateWindowExW failed: %v", e)
+	}
+	return hwnd, nil
+}
+
+// DestroyWindow destroys a window created by the calling thread.
+func DestroyWindow(hwnd uintptr) {
+	ProcDestroyWindow.Call(hwnd)
+}
@@ -0,0 +1,49 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const normalizationC = 1
+
+// NormalizeNFC returns s in Unicode Normalization Form C using the OS NormalizeString API.
+func NormalizeNFC(s string) (string, error) {
+	if s == "" {
+		return s, nil
+	}
+	if err := findProc(ProcNormalizeString); err != nil {
+		return "", &UnsupportedOSError{API: "NormalizeString"}
+	}
+	src, err := syscall.UTF16FromString(s)
+	if err != nil {
+		return "", err
+	}
+	src = src[:len(src)-1] // drop NUL; length is passed explicitly
+
+	// The API returns an estimate; retry with the suggested size if it was too small.
+	size := len(src) + 16
+	for i := 0; i < 4; i++ {
+		dst := make([]uint16, size)
+		n, _, e := ProcNormalizeString.Call(
+			normalizationC,
+			uintptr(unsafe.Pointer(&src[0])), uintptr(len(src)),
+			uintptr(unsafe.Pointer(&dst[0])), uintptr(len(dst)),
+		)
+		if int32(n) > 0 {
+			return syscall.UTF16ToString(dst[:n]), nil
+		}
+		if errno, ok := e.(syscall.Errno); ok && errno == syscall.ERROR_INSUFFICIENT_BUFFER {
+			size = int(-int32(n)) + 16
+			if size <= len(dst) {
+				size = len(dst) * 2
+			}
+			continue
+		}
+		return "", fmt.Errorf("NormalizeString failed: %v", e)
+	}
+	return "", fmt.Errorf("NormalizeString: buffer estimate did not converge")
+}
@@ -0,0 +1,142 @@
+//go:build windows
+
+package window
+
+import "sort"
+
+// GetWindow / GetWindowLong constants
+const (
+	GW_OWNER = 4
+
+	GWL_STYLE   = -16
+	GWL_EXSTYLE = -20
+
+	WS_CHILD    = 0x40000000
+	WS_DISABLED = 0x08000000
+
+	WS_EX_TOPMOST     = 0x00000008
+	WS_EX_TRANSPARENT = 0x00000020
+	WS_EX_TOOLWINDOW  = 0x00000080
+	WS_EX_APPWINDOW   = 0x00040000
+	WS_EX_LAYERED     = 0x00080000
+	WS_EX_NOACTIVATE  = 0x08000000
+)
+
+// SetWindowPos insertAfter values
+const (
+	HWND_TOP       = 0
+	HWND_BOTTOM    = 1
+	HWND_TOPMOST   = ^uintptr(0) // -1
+	HWND_NOTOPMOST = ^uintptr(1) // -2
+)
+
+// IsTopMost reports whether the window has the WS_EX_TOPMOST extended style.
+func IsTopMost(hwnd uintptr) bool {
+	return GetWindowLong(hwnd, GWL_EXSTYLE)&WS_EX_TOPMOST != 0
+}
+
+// IsToolWindow reports whether the window is a tool window without a taskbar button
+// (WS_EX_TOOLWINDOW and not WS_EX_APPWINDOW).
+func IsToolWindow(hwnd uintptr) bool {
+	ex := GetWindowLong(hwnd, GWL_EXSTYLE)
+	return ex&WS_EX_TOOLWINDOW != 0 && ex&WS_EX_APPWINDOW == 0
+}
+
+// GetOwner returns the owner window of hwnd, or 0 if it is unowned.
+func GetOwner(hwnd uintptr) uintptr {
+	r, _, _ := ProcGetWindow.Call(hwnd, GW_OWNER)
+	return r
+}
+
+// GetWindowLong retrieves a window attribute (e.g. GWL_STYLE, GWL_EXSTYLE).
+// GetWindowLongPtrW is only exported by 64-bit user32, so 32-bit builds fall back to GetWindowLongW.
+func GetWindowLong(hwnd uintptr, index int32) uintptr {
+	proc := ProcGetWindowLongPtrW
+	if findProc(proc) != nil {
+		proc = ProcGetWindowLongW
+	}
+	r, _, _ := proc.Call(hwnd, uintptr(index))
+	return r
+}
+
+func clientArea(hwnd uintptr) int64 {
+	w, h, err := GetClientRect(hwnd)
+	if err != nil {
+		return 0
+	}
+	return int64(w) * int64(h)
+}
+
+// OrderWindows returns hwnds in a deterministic preference order:
+// visible windows first, then larger client area first, then ascending HWND.
+// Owned popups whose owner is also in the list are dropped, so each application
+// window appears once.
+func OrderWindows(hwnds []uintptr) []uintptr {
+	present := make(map[uintptr]bool, len(hwnds))
+	for _, h := range hwnds {
+		present[h] = true
+	}
+
+	type entry struct {
+		hwnd    uintptr
+		visible bool
+		area    int64
+	}
+	entries := make([]entry, 0, len(hwnds))
+	for _, h := range hwnds {
+		if owner := GetOwner(h); owner != 0 && present[owner] {
+			continue
+		}
+		entries = append(entries, entry{
+			hwnd:    h,
+			visible: IsVisible(h),
+			area:    clientArea(h),
+		})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.visible != b.visible {
+			return a.visible
+		}
+		if a.area != b.area {
+			return a.area > b.area
+		}
+		return a.hwnd < b.hwnd
+	})
+
+	out := make([]uintptr, len(entries))
+	for i, e := range entries {
+		out[i] = e.hwnd
+	}
+	return out
+}
+
+// MainWindow picks the window most likely to be the application's main window:
+// visible, unowned, not a tool window, preferring WS_EX_APPWINDOW, then the OrderWindows order.
+// Returns false if no candidate qualifies.
+func MainWindow(hwnds []uintptr) (uintptr, bool) {
+	var best uintptr
+	bestScore := -1
+	for _, h := range OrderWindows(hwnds) {
+		if !IsVisible(h) || GetOwner(h) != 0 {
+			continue
+		}
+		if IsToolWindow(h) {
+			continue
+		}
+		ex := GetWindowLong(h, GWL_EXSTYLE)
+		score := 0
+		if ex&WS_EX_APPWINDOW != 0 {
+			score++
+		}
+		if GetAncestor(h, GA_ROOTOWNER) == h {
+			score++
+		}
+		// Strictly greater keeps the OrderWindows tie-break.
+		if score > bestScore {
+			best, bestScore = h, score
+		}
+	}
+	return best, best != 0
+}
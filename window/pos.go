@@ -0,0 +1,111 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// SetWindowPos flags
+const (
+	SWP_NOSIZE         = 0x0001
+	SWP_NOMOVE         = 0x0002
+	SWP_NOZORDER       = 0x0004
+	SWP_NOACTIVATE     = 0x0010
+	SWP_FRAMECHANGED   = 0x0020
+	SWP_NOOWNERZORDER  = 0x0200
+	SWP_ASYNCWINDOWPOS = 0x4000
+)
+
+const (
+	WM_NULL = 0x0000
+
+	DWMWA_TRANSITIONS_FORCEDISABLED = 3
+
+	ERROR_ACCESS_DENIED = 5
+)
+
+// SetWindowPos changes the size, position and z-order of a window.
+// ERROR_ACCESS_DENIED is reported as ErrAccessDenied so callers can map it.
+func SetWindowPos(hwnd, insertAfter uintptr, x, y, cx, cy int32, flags uint32) error {
+	r, _, e := ProcSetWindowPos.Call(
+		hwnd,
+		insertAfter,
+		uintptr(x), uintptr(y),
+		uintptr(cx), uintptr(cy),
+		uintptr(flags),
+	)
+	if r == 0 {
+		if errno, ok := e.(syscall.Errno); ok && errno == ERROR_ACCESS_DENIED {
+			return fmt.Errorf("SetWindowPos: %w", ErrAccessDenied)
+		}
+		return fmt.Errorf("SetWindowPos failed: %v", e)
+	}
+	return nil
+}
+
+// WindowSizeForClient returns the outer size hwnd needs for a client area of width x height,
+// given its current styles, menu bar and DPI. It uses AdjustWindowRectExForDpi (Windows 10
+// 1607+) and falls back to AdjustWindowRectEx, which assumes the system DPI.
+// A menu bar that wraps onto several lines is counted as one.
+func WindowSizeForClient(hwnd uintptr, width, height int32) (int32, int32, error) {
+	style := GetWindowLong(hwnd, GWL_STYLE)
+	exStyle := GetWindowLong(hwnd, GWL_EXSTYLE)
+	var menu uintptr
+	if m, _, _ := ProcGetMenu.Call(hwnd); m != 0 && style&WS_CHILD == 0 {
+		menu = 1
+	}
+	rc := RECT{Right: width, Bottom: height}
+	var r uintptr
+	var e error
+	if findProc(ProcAdjustWindowRectExForDpi) == nil {
+		dpi, _, _ := GetDPI(hwnd)
+		r, _, e = ProcAdjustWindowRectExForDpi.Call(uintptr(unsafe.Pointer(&rc)), uintptr(uint32(style)), menu, uintptr(uint32(exStyle)), uintptr(dpi))
+	} else {
+		r, _, e = ProcAdjustWindowRectEx.Call(uintptr(unsafe.Pointer(&rc)), uintptr(uint32(style)), menu, uintptr(uint32(exStyle)))
+	}
+	if r == 0 {
+		return 0, 0, fmt.Errorf("AdjustWindowRectEx failed: %v", e)
+	}
+	return rc.Right - rc.Left, rc.Bottom - rc.Top, nil
+}
+
+// SetTransitionsDisabled toggles DWM transition animations (minimize/restore/move) for the window.
+// It returns an error if DWM is unavailable (e.g. Windows 7 with composition disabled),
+// or an *UnsupportedOSError where dwmapi.dll does not export DwmSetWindowAttribute.
+func SetTransitionsDisabled(hwnd uintptr, disabled bool) error {
+	if err := findProc(ProcDwmSetWindowAttribute); err != nil {
+		return &UnsupportedOSError{API: "DwmSetWindowAttribute"}
+	}
+	var v int32
+	if disabled {
+		v = 1
+	}
+	hr, _, _ := ProcDwmSetWindowAttribute.Call(
+		hwnd,
+		DWMWA_TRANSITIONS_FORCEDISABLED,
+		uintptr(unsafe.Pointer(&v)),
+		unsafe.Sizeof(v),
+	)
+	if hr != 0 {
+		return fmt.Errorf("DwmSetWindowAttribute failed: 0x%X", uint32(hr))
+	}
+	return nil
+}
+
+// DwmFlush blocks until the next DWM composition pass, so changes made before the call are on screen.
+// It is a no-op when dwmapi.dll is unavailable.
+func DwmFlush() {
+	if findProc(ProcDwmFlush) == nil {
+		ProcDwmFlush.Call()
+	}
+}
+
+// Ping sends WM_NULL and waits (up to timeoutMs) until the window's thread has processed it,
+// which implies every message queued before it has been handled too.
+func Ping(hwnd uintptr, timeoutMs uint32) error {
+	_, err := sendMessageTimeout(hwnd, WM_NULL, 0, 0, timeoutMs)
+	return err
+}
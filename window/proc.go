@@ -1,3 +1,5 @@
+//go:build windows
+
 package window
 
 import (
@@ -11,8 +13,10 @@ var (
 
 	ProcFindWindowW              = user32.NewProc("FindWindowW")
 	ProcFindWindowExW            = user32.NewProc("FindWindowExW")
+	ProcGetDlgItem               = user32.NewProc("GetDlgItem")
 	ProcGetWindowThreadProcessId = user32.NewProc("GetWindowThreadProcessId")
 	ProcEnumWindows              = user32.NewProc("EnumWindows")
+	ProcEnumChildWindows         = user32.NewProc("EnumChildWindows")
 	ProcSendMessageW             = user32.NewProc("SendMessageW")
 	ProcSendMessageTimeoutW      = user32.NewProc("SendMessageTimeoutW")
 	ProcGetWindowTextW           = user32.NewProc("GetWindowTextW")
@@ -20,32 +24,86 @@ var (
 	ProcIsWindow                 = user32.NewProc("IsWindow")
 	ProcIsWindowVisible          = user32.NewProc("IsWindowVisible")
 	ProcIsIconic                 = user32.NewProc("IsIconic")
+	ProcIsZoomed                 = user32.NewProc("IsZoomed")
+	ProcIsHungAppWindow          = user32.NewProc("IsHungAppWindow")
 	ProcGetClassNameW            = user32.NewProc("GetClassNameW")
+	ProcGetWindow                = user32.NewProc("GetWindow")
+	ProcGetWindowLongW           = user32.NewProc("GetWindowLongW")
+	ProcGetWindowLongPtrW        = user32.NewProc("GetWindowLongPtrW")
+	ProcSetWindowLongW           = user32.NewProc("SetWindowLongW")
+	ProcSetWindowLongPtrW        = user32.NewProc("SetWindowLongPtrW")
+
+	ProcSetLayeredWindowAttributes = user32.NewProc("SetLayeredWindowAttributes")
+
+	ProcScreenToClient  = user32.NewProc("ScreenToClient")
+	ProcClientToScreen  = user32.NewProc("ClientToScreen")
+	ProcGetClientRect   = user32.NewProc("GetClientRect")
+	ProcGetWindowRect   = user32.NewProc("GetWindowRect")
+	ProcWindowFromPoint = user32.NewProc("WindowFromPoint")
+	ProcGetAncestor     = user32.NewProc("GetAncestor")
+	ProcIsWindowEnabled = user32.NewProc("IsWindowEnabled")
+
+	ProcRealChildWindowFromPoint = user32.NewProc("RealChildWindowFromPoint")
+	ProcSetWindowPos             = user32.NewProc("SetWindowPos")
+	ProcAdjustWindowRectEx       = user32.NewProc("AdjustWindowRectEx")
+	ProcGetCursorPos             = user32.NewProc("GetCursorPos")
+	ProcSetCursorPos             = user32.NewProc("SetCursorPos")
+	ProcMouseEvent               = user32.NewProc("mouse_event")
+	ProcKeybdEvent               = user32.NewProc("keybd_event")
+	ProcSendInput                = user32.NewProc("SendInput")
+	ProcMonitorFromPoint         = user32.NewProc("MonitorFromPoint")
+	ProcMonitorFromWindow        = user32.NewProc("MonitorFromWindow")
+	ProcEnumDisplayMonitors      = user32.NewProc("EnumDisplayMonitors")
+	ProcGetMonitorInfoW          = user32.NewProc("GetMonitorInfoW")
+	ProcGetSystemMetrics         = user32.NewProc("GetSystemMetrics")
+	ProcGetDoubleClickTime       = user32.NewProc("GetDoubleClickTime")
 
-	ProcScreenToClient      = user32.NewProc("ScreenToClient")
-	ProcClientToScreen      = user32.NewProc("ClientToScreen")
-	ProcGetClientRect       = user32.NewProc("GetClientRect")
-	ProcGetCursorPos        = user32.NewProc("GetCursorPos")
-	ProcSetCursorPos        = user32.NewProc("SetCursorPos")
-	ProcMouseEvent          = user32.NewProc("mouse_event")
-	ProcKeybdEvent          = user32.NewProc("keybd_event")
-	ProcSendInput           = user32.NewProc("SendInput")
-	ProcMonitorFromPoint    = user32.NewProc("MonitorFromPoint")
-	ProcMonitorFromWindow   = user32.NewProc("MonitorFromWindow")
-	ProcEnumDisplayMonitors = user32.NewProc("EnumDisplayMonitors")
-	ProcGetMonitorInfoW     = user32.NewProc("GetMonitorInfoW")
-	ProcGetSystemMetrics    = user32.NewProc("GetSystemMetrics")
-	ProcGetDoubleClickTime  = user32.NewProc("GetDoubleClickTime")
+	ProcRegisterClassExW = user32.NewProc("RegisterClassExW")
+	ProcCreateWindowExW  = user32.NewProc("CreateWindowExW")
+	ProcDestroyWindow    = user32.NewProc("DestroyWindow")
+	ProcDefWindowProcW   = user32.NewProc("DefWindowProcW")
+	ProcGetMessageW      = user32.NewProc("GetMessageW")
+	ProcTranslateMessage = user32.NewProc("TranslateMessage")
+	ProcDispatchMessageW = user32.NewProc("DispatchMessageW")
+	ProcPostQuitMessage  = user32.NewProc("PostQuitMessage")
+	ProcShowWindow       = user32.NewProc("ShowWindow")
+
+	ProcSetWinEventHook    = user32.NewProc("SetWinEventHook")
+	ProcUnhookWinEvent     = user32.NewProc("UnhookWinEvent")
+	ProcPostThreadMessageW = user32.NewProc("PostThreadMessageW")
+
+	ProcGetKeyboardLayoutList  = user32.NewProc("GetKeyboardLayoutList")
+	ProcGetKeyboardLayout      = user32.NewProc("GetKeyboardLayout")
+	ProcActivateKeyboardLayout = user32.NewProc("ActivateKeyboardLayout")
+
+	ProcGetMenu             = user32.NewProc("GetMenu")
+	ProcGetMenuItemCount    = user32.NewProc("GetMenuItemCount")
+	ProcGetMenuItemInfoW    = user32.NewProc("GetMenuItemInfoW")
+	ProcGetForegroundWindow = user32.NewProc("GetForegroundWindow")
+	ProcSetForegroundWindow = user32.NewProc("SetForegroundWindow")
+	ProcBringWindowToTop    = user32.NewProc("BringWindowToTop")
+	ProcAttachThreadInput   = user32.NewProc("AttachThreadInput")
+	ProcSetFocus            = user32.NewProc("SetFocus")
+	ProcFlashWindowEx       = user32.NewProc("FlashWindowEx")
+	ProcWaitForInputIdle    = user32.NewProc("WaitForInputIdle")
+	ProcGetGUIThreadInfo    = user32.NewProc("GetGUIThreadInfo")
+	ProcOpenClipboard       = user32.NewProc("OpenClipboard")
+	ProcCloseClipboard      = user32.NewProc("CloseClipboard")
+	ProcEmptyClipboard      = user32.NewProc("EmptyClipboard")
+	ProcSetClipboardData    = user32.NewProc("SetClipboardData")
 
 	// DPI Awareness (Win10 1607+)
 	ProcGetDpiForWindow              = user32.NewProc("GetDpiForWindow")
+	ProcAdjustWindowRectExForDpi     = user32.NewProc("AdjustWindowRectExForDpi")
 	ProcSetProcessDpiAwarenessCtx    = user32.NewProc("SetProcessDpiAwarenessContext")
-	ProcGetProcessDpiAwarenessCtx    = user32.NewProc("GetProcessDpiAwarenessContext")
+	ProcGetThreadDpiAwarenessCtx     = user32.NewProc("GetThreadDpiAwarenessContext")
 	ProcAreDpiAwarenessContextsEqual = user32.NewProc("AreDpiAwarenessContextsEqual")
 	ProcIsProcessDPIAware            = user32.NewProc("IsProcessDPIAware")
+	ProcSetProcessDPIAware           = user32.NewProc("SetProcessDPIAware")
 
 	ProcGetDpiForMonitor       = shcore.NewProc("GetDpiForMonitor")
 	ProcGetProcessDpiAwareness = shcore.NewProc("GetProcessDpiAwareness")
+	ProcSetProcessDpiAwareness = shcore.NewProc("SetProcessDpiAwareness")
 
 	ProcGetDC     = user32.NewProc("GetDC")
 	ProcReleaseDC = user32.NewProc("ReleaseDC")
@@ -58,14 +116,50 @@ var (
 	ProcDeleteObject       = gdi32.NewProc("DeleteObject")
 	ProcDeleteDC           = gdi32.NewProc("DeleteDC")
 	ProcBitBlt             = gdi32.NewProc("BitBlt")
+	ProcPrintWindow        = user32.NewProc("PrintWindow")
 
 	ProcPostMessageW   = user32.NewProc("PostMessageW")
 	ProcMapVirtualKeyW = user32.NewProc("MapVirtualKeyW")
 
+	dwmapi = syscall.NewLazyDLL("dwmapi.dll")
+
+	ProcDwmSetWindowAttribute = dwmapi.NewProc("DwmSetWindowAttribute")
+	ProcDwmFlush              = dwmapi.NewProc("DwmFlush")
+
 	kernel32 = syscall.NewLazyDLL("kernel32.dll")
 
 	ProcCreateToolhelp32Snapshot = kernel32.NewProc("CreateToolhelp32Snapshot")
 	ProcProcess32First           = kernel32.NewProc("Process32FirstW")
 	ProcProcess32Next            = kernel32.NewProc("Process32NextW")
 	ProcCloseHandle              = kernel32.NewProc("CloseHandle")
+	ProcOpenProcess              = kernel32.NewProc("OpenProcess")
+	ProcTerminateProcess         = kernel32.NewProc("TerminateProcess")
+	ProcQueryFullProcessImageW   = kernel32.NewProc("QueryFullProcessImageNameW")
+	ProcGetModuleHandleW         = kernel32.NewProc("GetModuleHandleW")
+	ProcProcessIdToSessionId     = kernel32.NewProc("ProcessIdToSessionId")
+	ProcGlobalAlloc              = kernel32.NewProc("GlobalAlloc")
+	ProcGlobalFree               = kernel32.NewProc("GlobalFree")
+	ProcGlobalLock               = kernel32.NewProc("GlobalLock")
+	ProcGlobalUnlock             = kernel32.NewProc("GlobalUnlock")
+	ProcRtlMoveMemory            = kernel32.NewProc("RtlMoveMemory")
+	ProcVirtualAllocEx           = kernel32.NewProc("VirtualAllocEx")
+	ProcVirtualFreeEx            = kernel32.NewProc("VirtualFreeEx")
+	ProcWriteProcessMemory       = kernel32.NewProc("WriteProcessMemory")
+	ProcCreateMutexW             = kernel32.NewProc("CreateMutexW")
+	ProcReleaseMutex             = kernel32.NewProc("ReleaseMutex")
+	ProcGetCurrentThreadId       = kernel32.NewProc("GetCurrentThreadId")
+	ProcSetLastError             = kernel32.NewProc("SetLastError")
+	ProcLCIDToLocaleName         = kernel32.NewProc("LCIDToLocaleName")
+
+	normaliz = syscall.NewLazyDLL("normaliz.dll")
+
+	ProcNormalizeString = normaliz.NewProc("NormalizeString")
+
+	shell32 = syscall.NewLazyDLL("shell32.dll")
+
+	ProcSHAppBarMessage = shell32.NewProc("SHAppBarMessage")
+
+	ntdll = syscall.NewLazyDLL("ntdll.dll")
+
+	ProcRtlGetVersion = ntdll.NewProc("RtlGetVersion")
 )
@@ -0,0 +1,81 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	PROCESS_VM_OPERATION = 0x0008
+	PROCESS_VM_READ      = 0x0010
+	PROCESS_VM_WRITE     = 0x0020
+
+	MEM_COMMIT     = 0x1000
+	MEM_RESERVE    = 0x2000
+	MEM_RELEASE    = 0x8000
+	PAGE_READWRITE = 0x04
+)
+
+// remoteBuffer is memory in the window's owning process, for messages whose pointer
+// parameters Windows does not marshal across processes (most EM_* messages).
+// For windows of the current process it is plain local memory.
+type remoteBuffer struct {
+	proc  syscall.Handle
+	addr  uintptr
+	local []byte
+}
+
+func newRemoteBuffer(hwnd uintptr, size int) (*remoteBuffer, error) {
+	pid := GetWindowPID(hwnd)
+	if pid == 0 {
+		return nil, fmt.Errorf("window has no owning process")
+	}
+	if pid == uint32(syscall.Getpid()) {
+		b := &remoteBuffer{local: make([]byte, size)}
+		b.addr = uintptr(unsafe.Pointer(&b.local[0]))
+		return b, nil
+	}
+
+	h, _, e := ProcOpenProcess.Call(PROCESS_VM_OPERATION|PROCESS_VM_READ|PROCESS_VM_WRITE, 0, uintptr(pid))
+	if h == 0 {
+		if errno, ok := e.(syscall.Errno); ok && errno == ERROR_ACCESS_DENIED {
+			return nil, fmt.Errorf("OpenProcess: %w", ErrAccessDenied)
+		}
+		return nil, fmt.Errorf("OpenProcess failed: %v", e)
+	}
+	addr, _, e := ProcVirtualAllocEx.Call(h, 0, uintptr(size), MEM_COMMIT|MEM_RESERVE, PAGE_READWRITE)
+	if addr == 0 {
+		ProcCloseHandle.Call(h)
+		return nil, fmt.Errorf("VirtualAllocEx failed: %v", e)
+	}
+	return &remoteBuffer{proc: syscall.Handle(h), addr: addr}, nil
+}
+
+// write copies data to offset off of the buffer.
+func (b *remoteBuffer) write(off int, data []byte) error {
+	if b.local != nil {
+		copy(b.local[off:], data)
+		return nil
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	var n uintptr
+	r, _, e := ProcWriteProcessMemory.Call(uintptr(b.proc), b.addr+uintptr(off), uintptr(unsafe.Pointer(&data[0])), uintptr(len(data)), uintptr(unsafe.Pointer(&n)))
+	if r == 0 {
+		return fmt.Errorf("WriteProcessMemory failed: %v", e)
+	}
+	return nil
+}
+
+func (b *remoteBuffer) free() {
+	if b.local != nil {
+		b.local = nil
+		return
+	}
+	ProcVirtualFreeEx.Call(uintptr(b.proc), b.addr, 0, MEM_RELEASE)
+	ProcCloseHandle.Call(uintptr(b.proc))
+}
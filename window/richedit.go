@@ -0,0 +1,103 @@
+//go:build windows
+
+package window
+
+import (
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	WM_SETTEXT   = 0x000C
+	EM_GETSEL    = 0x00B0
+	EM_SETSEL    = 0x00B1
+	EM_SETTEXTEX = 0x0461 // WM_USER + 97
+
+	ST_DEFAULT   = 0x0
+	ST_KEEPUNDO  = 0x1
+	ST_SELECTION = 0x2
+	ST_UNICODE   = 0x8
+
+	CP_UNICODE = 1200
+)
+
+// IsEditControl reports whether the window is an Edit or RichEdit-family control,
+// i.e. one that supports the EM_* selection messages.
+func IsEditControl(hwnd uintptr) bool {
+	return strings.EqualFold(GetClassName(hwnd), "Edit") || IsRichEdit(hwnd)
+}
+
+// IsRichEdit reports whether the window is a RichEdit-family control
+// (RichEdit20W, RICHEDIT50W, RichEditD2DPT used by Windows 11 Notepad, ...).
+func IsRichEdit(hwnd uintptr) bool {
+	return strings.Contains(strings.ToLower(GetClassName(hwnd)), "richedit")
+}
+
+// SetTextEx sends EM_SETTEXTEX to a RichEdit control. With selection set, text replaces
+// the current selection (inserting at the caret); otherwise it replaces the whole content.
+// The change is kept on the undo stack where the control supports ST_KEEPUNDO.
+func SetTextEx(hwnd uintptr, text string, selection bool, timeoutMs uint32) error {
+	utf16, err := syscall.UTF16FromString(text)
+	if err != nil {
+		return err
+	}
+
+	flags := uint32(ST_KEEPUNDO | ST_UNICODE)
+	if selection {
+		flags |= ST_SELECTION
+	}
+	// Layout: SETTEXTEX {flags, codepage} followed by the NUL-terminated UTF-16 text.
+	header := make([]byte, 8)
+	*(*uint32)(unsafe.Pointer(&header[0])) = flags
+	*(*uint32)(unsafe.Pointer(&header[4])) = CP_UNICODE
+	body := unsafe.Slice((*byte)(unsafe.Pointer(&utf16[0])), len(utf16)*2)
+
+	buf, err := newRemoteBuffer(hwnd, len(header)+len(body))
+	if err != nil {
+		return err
+	}
+	defer buf.free()
+	if err := buf.write(0, header); err != nil {
+		return err
+	}
+	if err := buf.write(len(header), body); err != nil {
+		return err
+	}
+
+	_, err = sendMessageTimeout(hwnd, EM_SETTEXTEX, buf.addr, buf.addr+uintptr(len(header)), timeoutMs)
+	return err
+}
+
+// SetText replaces the window text with WM_SETTEXT, which Windows marshals across processes.
+func SetText(hwnd uintptr, text string, timeoutMs uint32) error {
+	p, err := syscall.UTF16PtrFromString(text)
+	if err != nil {
+		return err
+	}
+	_, err = sendMessageTimeout(hwnd, WM_SETTEXT, 0, uintptr(unsafe.Pointer(p)), timeoutMs)
+	return err
+}
+
+// GetSel returns the selection of an Edit or RichEdit control in UTF-16 code units.
+// A collapsed selection (start == end) is the caret position. Positions above 65535 are truncated.
+func GetSel(hwnd uintptr, timeoutMs uint32) (start, end int, err error) {
+	r, err := sendMessageTimeout(hwnd, EM_GETSEL, 0, 0, timeoutMs)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(r & 0xFFFF), int((r >> 16) & 0xFFFF), nil
+}
+
+// SetSel selects the range [start, end) of an Edit or RichEdit control in UTF-16 code units;
+// start == end places the caret. Positions past the end of the text are clamped by the control.
+func SetSel(hwnd uintptr, start, end int, timeoutMs uint32) error {
+	_, err := sendMessageTimeout(hwnd, EM_SETSEL, uintptr(start), uintptr(end), timeoutMs)
+	return err
+}
+
+// GetTextLength returns the length of the window text in UTF-16 code units (WM_GETTEXTLENGTH).
+func GetTextLength(hwnd uintptr, timeoutMs uint32) (int, error) {
+	n, err := sendMessageTimeout(hwnd, WM_GETTEXTLENGTH, 0, 0, timeoutMs)
+	return int(n), err
+}
@@ -0,0 +1,18 @@
+//go:build windows
+
+package window
+
+// ShowWindow commands.
+const (
+	SW_HIDE     = 0
+	SW_MAXIMIZE = 3
+	SW_SHOW     = 5
+	SW_MINIMIZE = 6
+	SW_RESTORE  = 9
+)
+
+// ShowWindow sets the show state of hwnd and reports whether it was visible before.
+func ShowWindow(hwnd uintptr, cmd int) bool {
+	r, _, _ := ProcShowWindow.Call(hwnd, uintptr(cmd))
+	return r != 0
+}
@@ -0,0 +1,879 @@
+// Code generated by internal/stubgen; DO NOT EDIT.
+
+//go:build !windows
+
+package window
+
+import (
+	"errors"
+)
+
+const (
+	CF_UNICODETEXT = 13
+	GMEM_MOVEABLE  = 0x0002
+)
+
+// Capabilities reports which version-dependent APIs the running Windows provides.
+// Features whose API is missing either fall back (documented on the feature) or return
+// an *UnsupportedOSError naming it.
+type Capabilities struct {
+	PerMonitorV2 bool
+
+	PerMonitor bool
+
+	GetDpiForWindow bool
+
+	ThreadDPIAwareness bool
+
+	DWM bool
+
+	NormalizeString bool
+
+	AutoHideBars bool
+
+	Missing []string
+}
+
+// POINT represents a point in 2D space (x, y).
+// It corresponds to the Win32 POINT structure.
+type POINT struct {
+	X, Y int32
+}
+
+// RECT represents a rectangle in 2D space.
+// It corresponds to the Win32 RECT structure.
+type RECT struct {
+	Left, Top, Right, Bottom int32
+}
+
+// GetAncestor flags
+const (
+	GA_PARENT    = 1
+	GA_ROOT      = 2
+	GA_ROOTOWNER = 3
+)
+
+// DPI Awareness Contexts (Pseudo-Handles)
+// Win10 1607+
+// Using ^uintptr(0) to correctly represent -1 on both 32-bit and 64-bit systems.
+const (
+	DPI_AWARENESS_CONTEXT_UNAWARE              = ^uintptr(0) // -1
+	DPI_AWARENESS_CONTEXT_SYSTEM_AWARE         = ^uintptr(1) // -2
+	DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE    = ^uintptr(2) // -3
+	DPI_AWARENESS_CONTEXT_PER_MONITOR_AWARE_V2 = ^uintptr(3) // -4
+	DPI_AWARENESS_CONTEXT_UNAWARE_GDISCALED    = ^uintptr(4) // -5
+)
+
+// DPIAlreadySetError reports the awareness level a process was already locked to when
+// EnablePerMonitorDPI was called. It matches ErrDPIAlreadySet with errors.Is.
+type DPIAlreadySetError struct {
+	Level DPIAwarenessLevel
+}
+
+// DPIAwarenessLevel describes how the process is scaled by the OS.
+// Levels are ordered: a higher value is always at least as accurate as a lower one.
+type DPIAwarenessLevel int
+
+const (
+	// DPIUnaware means the process is bitmap-stretched by the OS on every non-96 DPI monitor.
+	DPIUnaware DPIAwarenessLevel = iota
+	// DPISystemAware means coordinates are exact on the primary monitor only.
+	DPISystemAware
+	// DPIPerMonitor means coordinates are exact on every monitor (Windows 8.1+).
+	DPIPerMonitor
+	// DPIPerMonitorV2 is DPIPerMonitor plus non-client area and dialog scaling (Win10 1703+).
+	DPIPerMonitorV2
+)
+
+// MONITOR_DEFAULTTONULL makes MonitorFromPoint return 0 for a point on no monitor;
+// MONITOR_DEFAULTTONEAREST makes MonitorFromWindow/MonitorFromPoint return the closest
+// monitor when the window or point is on none.
+const (
+	MONITOR_DEFAULTTONULL    = 0
+	MONITOR_DEFAULTTONEAREST = 2
+)
+
+const (
+	TH32CS_SNAPPROCESS = 0x00000002
+)
+
+type PROCESSENTRY32 struct {
+	Size            uint32
+	CntUsage        uint32
+	ProcessID       uint32
+	DefaultHeapID   uintptr
+	ModuleID        uint32
+	CntThreads      uint32
+	ParentProcessID uint32
+	PriClassBase    int32
+	Flags           uint32
+	ExeFile         [260]uint16
+}
+
+const PROCESS_QUERY_LIMITED_INFORMATION = 0x1000
+
+const PROCESS_TERMINATE = 0x0001
+
+const (
+	SYNCHRONIZE  = 0x00100000
+	WAIT_TIMEOUT = 258
+	WAIT_FAILED  = 0xFFFFFFFF
+)
+
+const (
+	WM_ACTIVATE    = 0x0006
+	WM_SETFOCUS    = 0x0007
+	WM_KILLFOCUS   = 0x0008
+	WM_ACTIVATEAPP = 0x001C
+	WM_NCACTIVATE  = 0x0086
+
+	WA_INACTIVE = 0
+	WA_ACTIVE   = 1
+)
+
+// FlashWindowEx flags
+const (
+	FLASHW_STOP      = 0
+	FLASHW_ALL       = 0x3 // caption and taskbar button
+	FLASHW_TIMERNOFG = 0xC // until the window comes to the foreground
+)
+
+// SetLayeredWindowAttributes flags
+const (
+	LWA_COLORKEY = 0x1
+	LWA_ALPHA    = 0x2
+)
+
+const (
+	// WM_INPUTLANGCHANGEREQUEST asks a window's thread to switch its input language.
+	WM_INPUTLANGCHANGEREQUEST = 0x0050
+
+	keyboardLayoutsKey = `SYSTEM\CurrentControlSet\Control\Keyboard Layouts`
+)
+
+// KeyboardLayout describes an installed input locale (HKL).
+type KeyboardLayout struct {
+	HKL    uintptr
+	KLID   string
+	LangID uint16
+	Locale string
+	Name   string
+}
+
+const (
+	WM_CLOSE      = 0x0010
+	WM_COMMAND    = 0x0111
+	WM_SYSCOMMAND = 0x0112
+
+	SC_MINIMIZE = 0xF020
+	SC_MAXIMIZE = 0xF030
+	SC_CLOSE    = 0xF060
+	SC_RESTORE  = 0xF120
+
+	MIIM_ID      = 0x0002
+	MIIM_SUBMENU = 0x0004
+	MIIM_STRING  = 0x0040
+	MIIM_FTYPE   = 0x0100
+
+	MFT_SEPARATOR = 0x0800
+)
+
+// MenuItem is a command-bearing entry of a window's menu bar.
+type MenuItem struct {
+	ID   uint16
+	Text string
+	Path []string
+}
+
+const (
+	// HWND_MESSAGE is the parent handle for message-only windows.
+	HWND_MESSAGE = ^uintptr(2) // -3
+
+	COLOR_WINDOW = 5
+)
+
+// CS_DBLCLKS makes a window class receive WM_LBUTTONDBLCLK and the other double-click messages.
+const CS_DBLCLKS = 0x0008
+
+// GetWindow / GetWindowLong constants
+const (
+	GW_OWNER = 4
+
+	GWL_STYLE   = -16
+	GWL_EXSTYLE = -20
+
+	WS_CHILD    = 0x40000000
+	WS_DISABLED = 0x08000000
+
+	WS_EX_TOPMOST     = 0x00000008
+	WS_EX_TRANSPARENT = 0x00000020
+	WS_EX_TOOLWINDOW  = 0x00000080
+	WS_EX_APPWINDOW   = 0x00040000
+	WS_EX_LAYERED     = 0x00080000
+	WS_EX_NOACTIVATE  = 0x08000000
+)
+
+// SetWindowPos insertAfter values
+const (
+	HWND_TOP       = 0
+	HWND_BOTTOM    = 1
+	HWND_TOPMOST   = ^uintptr(0) // -1
+	HWND_NOTOPMOST = ^uintptr(1) // -2
+)
+
+// SetWindowPos flags
+const (
+	SWP_NOSIZE         = 0x0001
+	SWP_NOMOVE         = 0x0002
+	SWP_NOZORDER       = 0x0004
+	SWP_NOACTIVATE     = 0x0010
+	SWP_FRAMECHANGED   = 0x0020
+	SWP_NOOWNERZORDER  = 0x0200
+	SWP_ASYNCWINDOWPOS = 0x4000
+)
+
+const (
+	WM_NULL = 0x0000
+
+	DWMWA_TRANSITIONS_FORCEDISABLED = 3
+
+	ERROR_ACCESS_DENIED = 5
+)
+
+const (
+	PROCESS_VM_OPERATION = 0x0008
+	PROCESS_VM_READ      = 0x0010
+	PROCESS_VM_WRITE     = 0x0020
+
+	MEM_COMMIT     = 0x1000
+	MEM_RESERVE    = 0x2000
+	MEM_RELEASE    = 0x8000
+	PAGE_READWRITE = 0x04
+)
+
+const (
+	WM_SETTEXT   = 0x000C
+	EM_GETSEL    = 0x00B0
+	EM_SETSEL    = 0x00B1
+	EM_SETTEXTEX = 0x0461 // WM_USER + 97
+
+	ST_DEFAULT   = 0x0
+	ST_KEEPUNDO  = 0x1
+	ST_SELECTION = 0x2
+	ST_UNICODE   = 0x8
+
+	CP_UNICODE = 1200
+)
+
+// ShowWindow commands.
+const (
+	SW_HIDE     = 0
+	SW_MAXIMIZE = 3
+	SW_SHOW     = 5
+	SW_MINIMIZE = 6
+	SW_RESTORE  = 9
+)
+
+const (
+	SM_REMOTESESSION = 0x1000
+
+	tokenElevation = 20
+)
+
+const (
+	WM_GETTEXT       = 0x000D
+	WM_GETTEXTLENGTH = 0x000E
+
+	SMTO_ABORTIFHUNG = 0x0002
+)
+
+var ErrReadTextFailed = errors.New("failed to read window text")
+
+// WinEvent constants
+const (
+	EVENT_OBJECT_CREATE         = 0x8000
+	EVENT_OBJECT_DESTROY        = 0x8001
+	EVENT_OBJECT_SHOW           = 0x8002
+	EVENT_OBJECT_HIDE           = 0x8003
+	EVENT_OBJECT_LOCATIONCHANGE = 0x800B
+	EVENT_OBJECT_NAMECHANGE     = 0x800C
+
+	WINEVENT_OUTOFCONTEXT = 0x0000
+
+	OBJID_WINDOW = 0
+)
+
+// WinEventFunc receives a WinEvent on the hook's thread.
+type WinEventFunc func(event uint32, hwnd uintptr, idObject, idChild int32)
+
+// WinEventHook is an out-of-context WinEvent hook serviced by its own message-pumping thread.
+type WinEventHook struct {
+}
+
+// SetClipboardText replaces the clipboard contents with text.
+// OpenClipboard is retried briefly because other processes may hold the clipboard.
+func SetClipboardText(text string) error {
+	return ErrUnsupportedPlatform
+}
+
+// OSCapabilities probes the version-dependent APIs. It never calls them.
+func OSCapabilities() Capabilities {
+	return *new(Capabilities)
+}
+
+// IsIconic checks if the specified window is minimized (iconic).
+func IsIconic(hwnd uintptr) bool {
+	return false
+}
+
+// IsZoomed checks if the specified window is maximized.
+func IsZoomed(hwnd uintptr) bool {
+	return false
+}
+
+// IsHungAppWindow reports whether Windows considers the window's thread hung: it has not
+// retrieved messages for 5 seconds while not waiting for input.
+func IsHungAppWindow(hwnd uintptr) bool {
+	return false
+}
+
+// IsValid checks if the specified window handle identifies an existing window.
+func IsValid(hwnd uintptr) bool {
+	return false
+}
+
+// IsVisible checks if the specified window has the WS_VISIBLE style.
+func IsVisible(hwnd uintptr) bool {
+	return false
+}
+
+// GetClientRect retrieves the coordinates of a window's client area.
+// The client coordinates specify the upper-left and lower-right corners of the
+// client area. Because client coordinates are relative to the upper-left corner
+// of a window's client area, the coordinates of the upper-left corner are (0,0).
+func GetClientRect(hwnd uintptr) (width, height int32, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// GetWindowRect retrieves the bounding rectangle of the window in screen coordinates,
+// including the non-client area (title bar, borders).
+func GetWindowRect(hwnd uintptr) (RECT, error) {
+	return *new(RECT), ErrUnsupportedPlatform
+}
+
+// ScreenToClient converts the screen coordinates of a specified point on the screen
+// to client-area coordinates.
+func ScreenToClient(hwnd uintptr, x, y int32) (cx, cy int32, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// ClientToScreen converts the client-area coordinates of a specified point to
+// screen coordinates.
+func ClientToScreen(hwnd uintptr, x, y int32) (sx, sy int32, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// GetCursorPos retrieves the cursor's position, in screen coordinates.
+// The coordinates are relative to the primary monitor (0,0).
+// Returns negative values if the cursor is on a monitor to the left or above the primary monitor.
+func GetCursorPos() (x, y int32, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// SetCursorPos moves the cursor to the specified screen coordinates.
+func SetCursorPos(x, y int32) error {
+	return ErrUnsupportedPlatform
+}
+
+// WindowFromPoint returns the deepest visible window containing the specified screen point.
+// Returns 0 if no window exists at the point.
+func WindowFromPoint(x, y int32) uintptr {
+	return 0
+}
+
+// DeepestChildFromPoint walks RealChildWindowFromPoint from root down to the deepest
+// visible, enabled descendant containing the screen point. It returns root if no child qualifies.
+func DeepestChildFromPoint(root uintptr, x, y int32) uintptr {
+	return 0
+}
+
+// IsEnabled reports whether the window accepts mouse and keyboard input.
+func IsEnabled(hwnd uintptr) bool {
+	return false
+}
+
+// GetAncestor retrieves the ancestor of the specified window (GA_PARENT, GA_ROOT, GA_ROOTOWNER).
+func GetAncestor(hwnd uintptr, flags uint32) uintptr {
+	return 0
+}
+
+func (e *DPIAlreadySetError) Error() string {
+	return ""
+}
+
+func (e *DPIAlreadySetError) Unwrap() error {
+	return ErrUnsupportedPlatform
+}
+
+// EnablePerMonitorDPI attempts to set the process to Per-Monitor DPI Aware (V2).
+// It falls back to V1 or System Aware on older systems if V2 is unavailable.
+//
+// The OS only lets the awareness be set once per process. EnablePerMonitorDPI is safe to
+// call repeatedly and from several goroutines: it returns nil if it already succeeded or
+// the process is already Per-Monitor V2 aware, and a *DPIAlreadySetError carrying the
+// current level if a manifest or the host application already chose a different one.
+func EnablePerMonitorDPI() error {
+	return ErrUnsupportedPlatform
+}
+
+// GetDPI returns the DPI for the specified window.
+// It tries to use GetDpiForWindow (Win10 1607+), falling back to System DPI.
+func GetDPI(hwnd uintptr) (uint32, uint32, error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// String returns a readable name for the level.
+func (l DPIAwarenessLevel) String() string {
+	return ""
+}
+
+// GetDPIAwareness queries the DPI awareness level in effect for the calling thread (see
+// DPIAwareness).
+// It returns DPIUnaware if the level cannot be determined; use DPIAwareness to tell the two apart.
+func GetDPIAwareness() DPIAwarenessLevel {
+	return *new(DPIAwarenessLevel)
+}
+
+// DPIAwareness queries the DPI awareness level in effect for the calling thread, which is the
+// process level unless the thread overrode it. It walks the same API generations as
+// EnablePerMonitorDPI, newest first, and returns an error only if none of them is available.
+func DPIAwareness() (DPIAwarenessLevel, error) {
+	return *new(DPIAwarenessLevel), ErrUnsupportedPlatform
+}
+
+// IsPerMonitorDPIAware checks if the calling thread is Per-Monitor DPI Aware (V1 or V2), which
+// is the process's awareness unless the thread overrode it.
+// This is critical for ensuring that screen coordinates (GetSystemMetrics, BitBlt) are exact
+// pixels and not virtualized/scaled by the OS.
+func IsPerMonitorDPIAware() bool {
+	return false
+}
+
+// MonitorFromWindow returns the handle of the monitor with the largest overlap with the
+// window, or of the nearest monitor if it overlaps none. It returns 0 for an invalid handle.
+func MonitorFromWindow(hwnd uintptr) uintptr {
+	return 0
+}
+
+// MonitorFromPoint returns the handle of the monitor containing the screen point, or 0 if
+// the point is on no monitor.
+func MonitorFromPoint(x, y int32) uintptr {
+	return 0
+}
+
+// FindByTitle searches for a top-level window matching the exact title.
+func FindByTitle(title string) (uintptr, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// FindByClass searches for a top-level window matching the specified class name.
+func FindByClass(class string) (uintptr, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// FindChildByClass searches for a child window with the specified class name.
+func FindChildByClass(parent uintptr, class string) (uintptr, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// FindChildByID returns the direct child with the specified control ID (GetDlgItem).
+func FindChildByID(parent uintptr, id int) (uintptr, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// FindChildByClassNth returns the n-th (zero-based) direct child with the specified class
+// name, walking siblings with FindWindowExW. If there are children of the class but n is
+// out of range, the error is a *ChildIndexError.
+func FindChildByClassNth(parent uintptr, class string, n int) (uintptr, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// EnumTopLevel returns every top-level window in EnumWindows (z-) order.
+func EnumTopLevel() ([]uintptr, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// EnumDescendants returns every descendant of parent (children, their children, ...) in
+// EnumChildWindows order: depth-first, each child before its own children.
+func EnumDescendants(parent uintptr) []uintptr {
+	return nil
+}
+
+// FindByPID returns all top-level windows belonging to the specified Process ID,
+// in EnumWindows (z-) order. Use OrderWindows for a stable order.
+func FindByPID(targetPid uint32) ([]uintptr, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// FindPIDByName searches for a process ID by its executable name (e.g., "notepad.exe").
+// The comparison is case-insensitive using full Unicode case folding.
+func FindPIDByName(name string) (uint32, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// FindPIDsByName returns the IDs of every process with the executable name (e.g.
+// "notepad.exe" or "notepad"), in process snapshot order. The comparison is the same as
+// FindPIDByName. When nothing matches it returns an empty slice and ErrProcessNotFound.
+func FindPIDsByName(name string) ([]uint32, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// ProcessNames returns the executable name (e.g. "notepad.exe") of every running process, keyed by PID.
+// Unlike GetProcessImagePath it also covers protected processes.
+func ProcessNames() (map[uint32]string, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// ProcessName returns the executable name (e.g. "notepad.exe") of the process pid, or
+// ErrProcessNotFound if it is not running.
+func ProcessName(pid uint32) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// GetProcessImagePath returns the full executable path of the process.
+// It requires only PROCESS_QUERY_LIMITED_INFORMATION, so it works for most non-protected processes.
+func GetProcessImagePath(pid uint32) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// TerminateProcess ends the process immediately, without giving it a chance to save or clean up.
+func TerminateProcess(pid uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+// WaitForInputIdle waits up to timeoutMs until the process has finished its initialization
+// and is waiting for user input with no input pending. It reports false if the timeout
+// elapsed first. A process without a message queue (e.g. a console program) is an error.
+func WaitForInputIdle(pid uint32, timeoutMs uint32) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// FindPIDsByPath returns the IDs of all processes whose full executable path contains
+// pathSubstring. The comparison is case-insensitive using full Unicode case folding,
+// and '/' is treated as '\'.
+// When nothing matches, the error lists near-miss paths (same executable base name)
+// to aid debugging.
+func FindPIDsByPath(pathSubstring string) ([]uint32, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// GetForegroundWindow returns the window the user is currently working with, or 0
+// (e.g. on the secure desktop or in a non-interactive session).
+func GetForegroundWindow() uintptr {
+	return 0
+}
+
+// SetForegroundWindow asks the system to bring hwnd to the foreground. It reports false if the
+// request was refused (foreground lock) or the handle is invalid.
+func SetForegroundWindow(hwnd uintptr) bool {
+	return false
+}
+
+// BringWindowToTop moves hwnd to the top of the z-order, activating it if it is a top-level
+// window of a thread that may set the foreground.
+func BringWindowToTop(hwnd uintptr) bool {
+	return false
+}
+
+// FlashWindow flashes the window's caption and taskbar button count times with the given
+// FLASHW_ flags, at the default cursor blink rate.
+func FlashWindow(hwnd uintptr, flags, count uint32) {}
+
+// AttachThreadInput shares (or, with attach false, stops sharing) the input state of the
+// threads from and to, so that from may change the focus and foreground of to.
+func AttachThreadInput(from, to uint32, attach bool) bool {
+	return false
+}
+
+// SetFocus gives hwnd the keyboard focus of the calling thread's input state, which covers
+// another thread only while attached to it with AttachThreadInput. It returns the window that
+// had focus before, or 0 if none did or the call failed.
+func SetFocus(hwnd uintptr) uintptr {
+	return 0
+}
+
+// FocusedWindow returns the window with keyboard focus on the foreground window's thread,
+// falling back to the foreground window itself. It returns 0 if there is no foreground window.
+func FocusedWindow() uintptr {
+	return 0
+}
+
+// ThreadFocus returns the window with keyboard focus on the thread tid, or 0 if it has none.
+func ThreadFocus(tid uint32) uintptr {
+	return 0
+}
+
+// ThreadCaret returns the window that owns the caret on the thread tid and the caret
+// rectangle in that window's client coordinates. caret is 0 if the thread has no caret.
+func ThreadCaret(tid uint32) (caret uintptr, rc RECT) {
+	return 0, *new(RECT)
+}
+
+// Fold returns a case-folded form of s suitable for case-insensitive comparison.
+//
+// Unlike strings.ToLower/EqualFold it maps every case variant of a letter to the same
+// rune, including the Turkish dotted/dotless i (İ, ı), so "İNSTALLER.EXE" and
+// "installer.exe" compare equal regardless of the system locale.
+func Fold(s string) string {
+	return ""
+}
+
+// ClassName returns the window class name. Class names are limited to 256 characters.
+func ClassName(hwnd uintptr) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// GetClassName returns the window class name, or "" if the handle is invalid.
+func GetClassName(hwnd uintptr) string {
+	return ""
+}
+
+// GetWindowPID returns the ID of the process that created the window, or 0 if the handle is invalid.
+func GetWindowPID(hwnd uintptr) uint32 {
+	return 0
+}
+
+// CurrentThreadID returns the ID of the calling OS thread.
+func CurrentThreadID() uint32 {
+	return 0
+}
+
+// GetThreadProcessID returns the IDs of the thread and process that created the window,
+// or zeros if the handle is invalid.
+func GetThreadProcessID(hwnd uintptr) (tid, pid uint32) {
+	return 0, 0
+}
+
+// SetWindowLong sets a window attribute (e.g. GWL_EXSTYLE), falling back to SetWindowLongW
+// like GetWindowLong. ERROR_ACCESS_DENIED (a higher-integrity target) is reported as ErrAccessDenied.
+func SetWindowLong(hwnd uintptr, index int32, value uintptr) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetLayeredWindowAttributes sets the opacity (LWA_ALPHA) or transparent color (LWA_COLORKEY)
+// of a window with the WS_EX_LAYERED style.
+func SetLayeredWindowAttributes(hwnd uintptr, colorKey uint32, alpha byte, flags uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+// KeyboardLayouts returns the input locales loaded in the current session, in the order
+// of the language bar.
+func KeyboardLayouts() ([]KeyboardLayout, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// CurrentKeyboardLayout returns the HKL active on the given thread (0 = calling thread).
+func CurrentKeyboardLayout(tid uint32) uintptr {
+	return 0
+}
+
+// ActivateKeyboardLayout makes hkl the active layout of the calling thread and returns
+// the previously active one.
+func ActivateKeyboardLayout(hkl uintptr) (uintptr, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// PostMessage posts a message to the window's queue.
+func PostMessage(hwnd uintptr, msg uint32, wparam, lparam uintptr) error {
+	return ErrUnsupportedPlatform
+}
+
+// MenuItems walks the window's menu bar recursively and returns every item that carries a command ID.
+// Separators and submenu headers are skipped. Windows without a classic menu bar return an empty slice.
+func MenuItems(hwnd uintptr) ([]MenuItem, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// RegisterWindowClass registers a window class with the given procedure (a syscall.NewCallback
+// or DefWindowProcW address) and returns the class name for CreateWindowExW.
+func RegisterWindowClass(name string, wndProc uintptr) (*uint16, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// RegisterWindowClassEx is RegisterWindowClass with class styles (e.g. CS_DBLCLKS).
+func RegisterWindowClassEx(name string, style uint32, wndProc uintptr) (*uint16, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// CreateMessageWindow creates a message-only window owned by the calling thread.
+// Messages sent to it from the same thread are handled synchronously by DefWindowProcW,
+// so no message pump is required. The caller must lock the OS thread and destroy the window.
+func CreateMessageWindow() (uintptr, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// DestroyWindow destroys a window created by the calling thread.
+func DestroyWindow(hwnd uintptr) {}
+
+// NormalizeNFC returns s in Unicode Normalization Form C using the OS NormalizeString API.
+func NormalizeNFC(s string) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// IsTopMost reports whether the window has the WS_EX_TOPMOST extended style.
+func IsTopMost(hwnd uintptr) bool {
+	return false
+}
+
+// IsToolWindow reports whether the window is a tool window without a taskbar button
+// (WS_EX_TOOLWINDOW and not WS_EX_APPWINDOW).
+func IsToolWindow(hwnd uintptr) bool {
+	return false
+}
+
+// GetOwner returns the owner window of hwnd, or 0 if it is unowned.
+func GetOwner(hwnd uintptr) uintptr {
+	return 0
+}
+
+// GetWindowLong retrieves a window attribute (e.g. GWL_STYLE, GWL_EXSTYLE).
+// GetWindowLongPtrW is only exported by 64-bit user32, so 32-bit builds fall back to GetWindowLongW.
+func GetWindowLong(hwnd uintptr, index int32) uintptr {
+	return 0
+}
+
+// OrderWindows returns hwnds in a deterministic preference order:
+// visible windows first, then larger client area first, then ascending HWND.
+// Owned popups whose owner is also in the list are dropped, so each application
+// window appears once.
+func OrderWindows(hwnds []uintptr) []uintptr {
+	return nil
+}
+
+// MainWindow picks the window most likely to be the application's main window:
+// visible, unowned, not a tool window, preferring WS_EX_APPWINDOW, then the OrderWindows order.
+// Returns false if no candidate qualifies.
+func MainWindow(hwnds []uintptr) (uintptr, bool) {
+	return 0, false
+}
+
+// SetWindowPos changes the size, position and z-order of a window.
+// ERROR_ACCESS_DENIED is reported as ErrAccessDenied so callers can map it.
+func SetWindowPos(hwnd, insertAfter uintptr, x, y, cx, cy int32, flags uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+// WindowSizeForClient returns the outer size hwnd needs for a client area of width x height,
+// given its current styles, menu bar and DPI. It uses AdjustWindowRectExForDpi (Windows 10
+// 1607+) and falls back to AdjustWindowRectEx, which assumes the system DPI.
+// A menu bar that wraps onto several lines is counted as one.
+func WindowSizeForClient(hwnd uintptr, width, height int32) (int32, int32, error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// SetTransitionsDisabled toggles DWM transition animations (minimize/restore/move) for the window.
+// It returns an error if DWM is unavailable (e.g. Windows 7 with composition disabled),
+// or an *UnsupportedOSError where dwmapi.dll does not export DwmSetWindowAttribute.
+func SetTransitionsDisabled(hwnd uintptr, disabled bool) error {
+	return ErrUnsupportedPlatform
+}
+
+// DwmFlush blocks until the next DWM composition pass, so changes made before the call are on screen.
+// It is a no-op when dwmapi.dll is unavailable.
+func DwmFlush() {}
+
+// Ping sends WM_NULL and waits (up to timeoutMs) until the window's thread has processed it,
+// which implies every message queued before it has been handled too.
+func Ping(hwnd uintptr, timeoutMs uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+// IsEditControl reports whether the window is an Edit or RichEdit-family control,
+// i.e. one that supports the EM_* selection messages.
+func IsEditControl(hwnd uintptr) bool {
+	return false
+}
+
+// IsRichEdit reports whether the window is a RichEdit-family control
+// (RichEdit20W, RICHEDIT50W, RichEditD2DPT used by Windows 11 Notepad, ...).
+func IsRichEdit(hwnd uintptr) bool {
+	return false
+}
+
+// SetTextEx sends EM_SETTEXTEX to a RichEdit control. With selection set, text replaces
+// the current selection (inserting at the caret); otherwise it replaces the whole content.
+// The change is kept on the undo stack where the control supports ST_KEEPUNDO.
+func SetTextEx(hwnd uintptr, text string, selection bool, timeoutMs uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+// SetText replaces the window text with WM_SETTEXT, which Windows marshals across processes.
+func SetText(hwnd uintptr, text string, timeoutMs uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+// GetSel returns the selection of an Edit or RichEdit control in UTF-16 code units.
+// A collapsed selection (start == end) is the caret position. Positions above 65535 are truncated.
+func GetSel(hwnd uintptr, timeoutMs uint32) (start, end int, err error) {
+	return 0, 0, ErrUnsupportedPlatform
+}
+
+// SetSel selects the range [start, end) of an Edit or RichEdit control in UTF-16 code units;
+// start == end places the caret. Positions past the end of the text are clamped by the control.
+func SetSel(hwnd uintptr, start, end int, timeoutMs uint32) error {
+	return ErrUnsupportedPlatform
+}
+
+// GetTextLength returns the length of the window text in UTF-16 code units (WM_GETTEXTLENGTH).
+func GetTextLength(hwnd uintptr, timeoutMs uint32) (int, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// ShowWindow sets the show state of hwnd and reports whether it was visible before.
+func ShowWindow(hwnd uintptr, cmd int) bool {
+	return false
+}
+
+// OSVersion returns the real Windows version via RtlGetVersion, which,
+// unlike GetVersionEx, is not subject to manifest-based version lies.
+func OSVersion() (major, minor, build uint32, err error) {
+	return 0, 0, 0, ErrUnsupportedPlatform
+}
+
+// IsElevated reports whether the current process token is elevated (UAC "Run as administrator").
+func IsElevated() (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// SessionID returns the Terminal Services session of the current process.
+// Session 0 is the non-interactive services session.
+func SessionID() (uint32, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// IsRemoteSession reports whether the process runs in a Remote Desktop session.
+func IsRemoteSession() bool {
+	return false
+}
+
+// GetText returns the current text for a window/control handle.
+// It prefers WM_GETTEXT to support standard text controls, then falls back to GetWindowTextW.
+func GetText(hwnd uintptr) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// GetTitle returns the caption of a top-level window via GetWindowTextW, which for windows
+// of other processes reads the system's copy and therefore cannot block on a hung target.
+func GetTitle(hwnd uintptr) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// HookWinEvents installs a WinEvent hook for events in [eventMin, eventMax] raised by
+// the process that owns hwnd (or every process if hwnd is 0). fn runs on a dedicated
+// thread and must not block. Close removes the hook.
+func HookWinEvents(hwnd uintptr, eventMin, eventMax uint32, fn WinEventFunc) (*WinEventHook, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Close removes the hook and stops its thread. It is safe to call more than once.
+func (h *WinEventHook) Close() {}
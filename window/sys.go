@@ -0,0 +1,71 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	SM_REMOTESESSION = 0x1000
+
+	tokenElevation = 20
+)
+
+type osVersionInfoW struct {
+	Size         uint32
+	MajorVersion uint32
+	MinorVersion uint32
+	BuildNumber  uint32
+	PlatformID   uint32
+	CSDVersion   [128]uint16
+}
+
+// OSVersion returns the real Windows version via RtlGetVersion, which,
+// unlike GetVersionEx, is not subject to manifest-based version lies.
+func OSVersion() (major, minor, build uint32, err error) {
+	if err := findProc(ProcRtlGetVersion); err != nil {
+		return 0, 0, 0, &UnsupportedOSError{API: "RtlGetVersion"}
+	}
+	var vi osVersionInfoW
+	vi.Size = uint32(unsafe.Sizeof(vi))
+	status, _, _ := ProcRtlGetVersion.Call(uintptr(unsafe.Pointer(&vi)))
+	if status != 0 {
+		return 0, 0, 0, fmt.Errorf("RtlGetVersion failed: 0x%X", uint32(status))
+	}
+	return vi.MajorVersion, vi.MinorVersion, vi.BuildNumber, nil
+}
+
+// IsElevated reports whether the current process token is elevated (UAC "Run as administrator").
+func IsElevated() (bool, error) {
+	token, err := syscall.OpenCurrentProcessToken()
+	if err != nil {
+		return false, err
+	}
+	defer token.Close()
+
+	var elevated, n uint32
+	if err := syscall.GetTokenInformation(token, tokenElevation, (*byte)(unsafe.Pointer(&elevated)), uint32(unsafe.Sizeof(elevated)), &n); err != nil {
+		return false, err
+	}
+	return elevated != 0, nil
+}
+
+// SessionID returns the Terminal Services session of the current process.
+// Session 0 is the non-interactive services session.
+func SessionID() (uint32, error) {
+	var id uint32
+	r, _, e := ProcProcessIdToSessionId.Call(uintptr(syscall.Getpid()), uintptr(unsafe.Pointer(&id)))
+	if r == 0 {
+		return 0, fmt.Errorf("ProcessIdToSessionId failed: %v", e)
+	}
+	return id, nil
+}
+
+// IsRemoteSession reports whether the process runs in a Remote Desktop session.
+func IsRemoteSession() bool {
+	r, _, _ := ProcGetSystemMetrics.Call(SM_REMOTESESSION)
+	return r != 0
+}
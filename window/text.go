@@ -1,3 +1,5 @@
+//go:build windows
+
 package window
 
 import (
@@ -29,9 +31,9 @@ func sendMessageTimeout(hwnd uintptr, msg uint32, wparam uintptr, lparam uintptr
 	)
 	if r == 0 {
 		if errno, ok := e.(syscall.Errno); ok && errno != 0 {
-			return 0, fmt.Errorf("%w: %v", ErrReadTextFailed, errno)
+			return 0, fmt.Errorf("SendMessageTimeout failed: %w", errno)
 		}
-		return 0, ErrReadTextFailed
+		return 0, fmt.Errorf("SendMessageTimeout failed")
 	}
 	return result, nil
 }
@@ -81,3 +83,13 @@ func GetText(hwnd uintptr) (string, error) {
 
 	return getWindowText(hwnd, int(n))
 }
+
+// GetTitle returns the caption of a top-level window via GetWindowTextW, which for windows
+// of other processes reads the system's copy and therefore cannot block on a hung target.
+func GetTitle(hwnd uintptr) (string, error) {
+	n, _, _ := ProcGetWindowTextLengthW.Call(hwnd)
+	if n == 0 {
+		return "", nil
+	}
+	return getWindowText(hwnd, int(n))
+}
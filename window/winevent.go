@@ -0,0 +1,125 @@
+//go:build windows
+
+package window
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// WinEvent constants
+const (
+	EVENT_OBJECT_CREATE         = 0x8000
+	EVENT_OBJECT_DESTROY        = 0x8001
+	EVENT_OBJECT_SHOW           = 0x8002
+	EVENT_OBJECT_HIDE           = 0x8003
+	EVENT_OBJECT_LOCATIONCHANGE = 0x800B
+	EVENT_OBJECT_NAMECHANGE     = 0x800C
+
+	WINEVENT_OUTOFCONTEXT = 0x0000
+
+	OBJID_WINDOW = 0
+)
+
+const wmQuit = 0x0012
+
+// WinEventFunc receives a WinEvent on the hook's thread.
+type WinEventFunc func(event uint32, hwnd uintptr, idObject, idChild int32)
+
+// WinEventHook is an out-of-context WinEvent hook serviced by its own message-pumping thread.
+type WinEventHook struct {
+	tid  uint32
+	done chan struct{}
+}
+
+var (
+	winEventOnce     sync.Once
+	winEventCallback uintptr
+	winEventFuncs    sync.Map // hook handle -> WinEventFunc
+)
+
+// syscall.NewCallback slots are never freed, so every hook shares one callback and
+// dispatches on the hook handle.
+func winEventProc(hook, event, hwnd, idObject, idChild, thread, ms uintptr) uintptr {
+	if fn, ok := winEventFuncs.Load(hook); ok {
+		fn.(WinEventFunc)(uint32(event), hwnd, int32(idObject), int32(idChild))
+	}
+	return 0
+}
+
+// HookWinEvents installs a WinEvent hook for events in [eventMin, eventMax] raised by
+// the process that owns hwnd (or every process if hwnd is 0). fn runs on a dedicated
+// thread and must not block. Close removes the hook.
+func HookWinEvents(hwnd uintptr, eventMin, eventMax uint32, fn WinEventFunc) (*WinEventHook, error) {
+	winEventOnce.Do(func() { winEventCallback = syscall.NewCallback(winEventProc) })
+
+	var pid uint32
+	if hwnd != 0 {
+		if pid = GetWindowPID(hwnd); pid == 0 {
+			return nil, fmt.Errorf("GetWindowThreadProcessId failed for %#x", hwnd)
+		}
+	}
+
+	h := &WinEventHook{done: make(chan struct{})}
+	started := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer close(h.done)
+
+		tid, _, _ := ProcGetCurrentThreadId.Call()
+		h.tid = uint32(tid)
+		// SetWinEventHook returns before any event can be delivered on this thread,
+		// so the function is registered in time.
+		hook, _, e := ProcSetWinEventHook.Call(
+			uintptr(eventMin), uintptr(eventMax), 0, winEventCallback,
+			uintptr(pid), 0, WINEVENT_OUTOFCONTEXT,
+		)
+		if hook == 0 {
+			started <- fmt.Errorf("SetWinEventHook failed: %v", e)
+			return
+		}
+		winEventFuncs.Store(hook, fn)
+		defer func() {
+			ProcUnhookWinEvent.Call(hook)
+			winEventFuncs.Delete(hook)
+		}()
+		started <- nil
+
+		var m struct {
+			HWND    uintptr
+			Message uint32
+			WParam  uintptr
+			LParam  uintptr
+			Time    uint32
+			Pt      POINT
+			Private uint32
+		}
+		for {
+			r, _, _ := ProcGetMessageW.Call(uintptr(unsafe.Pointer(&m)), 0, 0, 0)
+			if int32(r) <= 0 {
+				return
+			}
+			ProcDispatchMessageW.Call(uintptr(unsafe.Pointer(&m)))
+		}
+	}()
+
+	if err := <-started; err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Close removes the hook and stops its thread. It is safe to call more than once.
+func (h *WinEventHook) Close() {
+	select {
+	case <-h.done:
+		return
+	default:
+	}
+	ProcPostThreadMessageW.Call(uintptr(h.tid), wmQuit, 0, 0)
+	<-h.done
+}
@@ -1,3 +1,5 @@
+//go:build windows
+
 package winput
 
 import (
@@ -17,6 +19,8 @@ import (
 // Window represents a handle to a window.
 type Window struct {
 	HWND uintptr
+
+	resolvedBy *[]ResolveStep // the Resolve chain that found the window, for Refind
 }
 
 // -----------------------------------------------------------------------------
@@ -24,12 +28,32 @@ type Window struct {
 // -----------------------------------------------------------------------------
 
 // FindByTitle searches for a top-level window matching the exact title.
-func FindByTitle(title string) (*Window, error) {
-	hwnd, err := window.FindByTitle(title)
+//
+// With MatchCaseInsensitive or MatchTrimSpace the titles are compared in that normalized
+// form instead, returning the topmost match; as with FindByTitleContains, invisible and
+// tool windows are then skipped unless IncludeHidden is also given.
+func FindByTitle(title string, opts ...FindOption) (*Window, error) {
+	if len(opts) == 0 {
+		hwnd, err := window.FindByTitle(title)
+		if err != nil {
+			return nil, ErrWindowNotFound
+		}
+		return &Window{HWND: hwnd}, nil
+	}
+
+	var o findOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	want := o.normalize(title)
+	windows, err := findTopLevel(func(t string) bool { return o.normalize(t) == want }, opts)
 	if err != nil {
+		return nil, err
+	}
+	if len(windows) == 0 {
 		return nil, ErrWindowNotFound
 	}
-	return &Window{HWND: hwnd}, nil
+	return windows[0], nil
 }
 
 // FindByClass searches for a top-level window matching the specified class name.
@@ -42,11 +66,17 @@ func FindByClass(class string) (*Window, error) {
 }
 
 // FindByPID returns all top-level windows belonging to the specified Process ID.
+//
+// Results are ordered deterministically: visible windows first, then by descending
+// client area, then by ascending HWND. Owned popups (dialogs, tool palettes) whose
+// owner is also in the result are omitted, so each application window appears once.
+// Use MainWindowOfPID to pick the single most likely main window.
 func FindByPID(pid uint32) ([]*Window, error) {
 	hwnds, err := window.FindByPID(pid)
 	if err != nil {
 		return nil, ErrWindowNotFound
 	}
+	hwnds = window.OrderWindows(hwnds)
 	windows := make([]*Window, len(hwnds))
 	for i, h := range hwnds {
 		windows[i] = &Window{HWND: h}
@@ -54,7 +84,22 @@ func FindByPID(pid uint32) ([]*Window, error) {
 	return windows, nil
 }
 
+// MainWindowOfPID returns the window most likely to be the main window of the process:
+// visible, unowned, not a tool window, preferring WS_EX_APPWINDOW and larger windows.
+func MainWindowOfPID(pid uint32) (*Window, error) {
+	hwnds, err := window.FindByPID(pid)
+	if err != nil {
+		return nil, ErrWindowNotFound
+	}
+	hwnd, ok := window.MainWindow(hwnds)
+	if !ok {
+		return nil, ErrWindowNotFound
+	}
+	return &Window{HWND: hwnd}, nil
+}
+
 // FindByProcessName searches for all top-level windows belonging to a process with the given executable name.
+// Results use the same ordering as FindByPID.
 func FindByProcessName(name string) ([]*Window, error) {
 	pid, err := window.FindPIDByName(name)
 	if err != nil {
@@ -63,6 +108,33 @@ func FindByProcessName(name string) ([]*Window, error) {
 	return FindByPID(pid)
 }
 
+// FindByProcessPath returns the top-level windows of every process whose full executable path
+// contains substr (case-insensitive, Unicode-aware). Use it to tell apart processes that share
+// an executable name but are installed in different directories.
+// Results use the same ordering as FindByPID.
+func FindByProcessPath(substr string) ([]*Window, error) {
+	pids, err := window.FindPIDsByPath(substr)
+	if err != nil {
+		return nil, err
+	}
+	var hwnds []uintptr
+	for _, pid := range pids {
+		h, err := window.FindByPID(pid)
+		if err == nil {
+			hwnds = append(hwnds, h...)
+		}
+	}
+	if len(hwnds) == 0 {
+		return nil, ErrWindowNotFound
+	}
+	hwnds = window.OrderWindows(hwnds)
+	windows := make([]*Window, len(hwnds))
+	for i, h := range hwnds {
+		windows[i] = &Window{HWND: h}
+	}
+	return windows, nil
+}
+
 // FindChildByClass searches for a child window with the specified class name.
 func (w *Window) FindChildByClass(class string) (*Window, error) {
 	hwnd, err := window.FindChildByClass(w.HWND, class)
@@ -72,12 +144,45 @@ func (w *Window) FindChildByClass(class string) (*Window, error) {
 	return &Window{HWND: hwnd}, nil
 }
 
+// FindChildByClassNth returns the n-th (zero-based) direct child with the specified class
+// name, in z-order, e.g. the second of a dialog's "Edit" controls. It returns
+// ErrWindowNotFound if there is no such child at all, and a *ChildIndexError
+// (ErrChildIndexOutOfRange) carrying the count if n is out of range.
+func (w *Window) FindChildByClassNth(class string, n int) (*Window, error) {
+	hwnd, err := window.FindChildByClassNth(w.HWND, class, n)
+	if err != nil {
+		if errors.Is(err, ErrChildIndexOutOfRange) {
+			return nil, err
+		}
+		return nil, ErrWindowNotFound
+	}
+	return &Window{HWND: hwnd}, nil
+}
+
+// Children returns every descendant window (children, their children, ...) in
+// EnumChildWindows order: depth-first, each child before its own children. A window
+// without children yields an empty slice.
+func (w *Window) Children() ([]*Window, error) {
+	if !w.IsValid() {
+		return nil, ErrWindowGone
+	}
+	hwnds := window.EnumDescendants(w.HWND)
+	children := make([]*Window, 0, len(hwnds))
+	for _, h := range hwnds {
+		children = append(children, &Window{HWND: h})
+	}
+	return children, nil
+}
+
 // Text returns the current text/value of the target window or control.
 // It is most reliable for standard Win32 text controls such as Edit and RichEdit.
 func (w *Window) Text() (string, error) {
 	if !w.IsValid() {
 		return "", ErrWindowGone
 	}
+	if err := w.checkSelfTarget(); err != nil {
+		return "", err
+	}
 
 	text, err := window.GetText(w.HWND)
 	if err != nil {
@@ -92,6 +197,9 @@ func (w *Window) Value() (string, error) {
 	if !w.IsValid() {
 		return "", ErrWindowGone
 	}
+	if err := w.checkSelfTarget(); err != nil {
+		return "", err
+	}
 
 	text, err := window.GetText(w.HWND)
 	if err == nil && text != "" {
@@ -127,14 +235,70 @@ func (w *Window) IsVisible() bool {
 	return window.IsVisible(w.HWND) && !window.IsIconic(w.HWND)
 }
 
+// IsMinimized reports whether the window is minimized (iconic), i.e. Restore would bring it back.
+func (w *Window) IsMinimized() bool {
+	return window.IsIconic(w.HWND)
+}
+
+// IsMaximized reports whether the window is maximized.
+func (w *Window) IsMaximized() bool {
+	return window.IsZoomed(w.HWND)
+}
+
+// ClassName returns the window class name, e.g. "Notepad" or "Chrome_WidgetWin_1",
+// to pick the right window among several FindByPID results.
+func (w *Window) ClassName() (string, error) {
+	name, err := window.ClassName(w.HWND)
+	if err != nil && !w.IsValid() {
+		return "", ErrWindowGone
+	}
+	return name, err
+}
+
+// ProcessID returns the ID of the process that owns the window.
+func (w *Window) ProcessID() (uint32, error) {
+	pid := window.GetWindowPID(w.HWND)
+	if pid == 0 {
+		return 0, ErrWindowGone
+	}
+	return pid, nil
+}
+
+// ThreadID returns the ID of the thread that created the window and runs its message
+// loop, e.g. for AttachThreadInput.
+func (w *Window) ThreadID() (uint32, error) {
+	tid, _ := window.GetThreadProcessID(w.HWND)
+	if tid == 0 {
+		return 0, ErrWindowGone
+	}
+	return tid, nil
+}
+
+// ProcessName returns the executable name of the process that owns the window,
+// e.g. "notepad.exe". Unlike the full image path it is available for protected processes too.
+func (w *Window) ProcessName() (string, error) {
+	pid, err := w.ProcessID()
+	if err != nil {
+		return "", err
+	}
+	name, err := window.ProcessName(pid)
+	if err != nil && !w.IsValid() {
+		return "", ErrWindowGone
+	}
+	return name, err
+}
+
 func (w *Window) checkReady() error {
 	if !w.IsValid() {
 		return ErrWindowGone
 	}
-	if !w.IsVisible() {
-		return ErrWindowNotVisible
+	if !window.IsVisible(w.HWND) {
+		return ErrWindowHidden
 	}
-	return nil
+	if window.IsIconic(w.HWND) {
+		return ErrWindowMinimized
+	}
+	return w.checkPumping()
 }
 
 // -----------------------------------------------------------------------------
@@ -154,7 +318,6 @@ const (
 var (
 	currentBackend Backend = BackendMessage
 	backendMutex   sync.RWMutex
-	inputMutex     sync.Mutex
 )
 
 // SetBackend sets the input simulation backend.
@@ -170,7 +333,7 @@ func SetBackend(b Backend) error {
 			if errors.Is(err, hid.ErrDriverNotInstalled) {
 				return ErrDriverNotInstalled
 			}
-			return fmt.Errorf("%w: %v", ErrDLLLoadFailed, err)
+			return fmt.Errorf("%w: %w", ErrDLLLoadFailed, err)
 		}
 	}
 
@@ -178,9 +341,26 @@ func SetBackend(b Backend) error {
 	return nil
 }
 
-// SetHIDLibraryPath sets the path to the interception.dll library.
-func SetHIDLibraryPath(path string) {
-	hid.SetLibraryPath(path)
+// SetHIDLibraryPath sets the path to the interception.dll library. Once the HID backend
+// has loaded a library from another path it fails with ErrHIDLibraryLoaded instead of
+// silently keeping the old one; use ReloadHIDLibrary to switch then.
+func SetHIDLibraryPath(path string) error {
+	return hid.SetLibraryPath(path)
+}
+
+// HIDLibraryPath returns the path the HID backend loaded interception.dll from, as given
+// to SetHIDLibraryPath, or "" if it has not loaded it.
+func HIDLibraryPath() string {
+	return hid.LoadedPath()
+}
+
+// ReloadHIDLibrary switches the HID backend to the interception library at path without
+// restarting the process, e.g. between a test build and the real one. The old context is
+// closed and the new library loaded under the driver lock; if that fails, the previous
+// library is restored when possible. It fails with ErrHIDLibraryInUse instead of waiting
+// if input is in progress.
+func ReloadHIDLibrary(path string) error {
+	return hid.Reload(path)
 }
 
 func checkBackend() error {
@@ -193,7 +373,7 @@ func checkBackend() error {
 			if errors.Is(err, hid.ErrDriverNotInstalled) {
 				return ErrDriverNotInstalled
 			}
-			return fmt.Errorf("%w: %v", ErrDLLLoadFailed, err)
+			return fmt.Errorf("%w: %w", ErrDLLLoadFailed, err)
 		}
 	}
 	return nil
@@ -218,7 +398,7 @@ func moveImpl(cb Backend, hwnd uintptr, x, y int32, isRelative bool) error {
 			}
 			return hid.Move(cx+x, cy+y)
 		} else {
-			sx, sy, err := window.ClientToScreen(hwnd, x, y)
+			sx, sy, err := clientToScreen(hwnd, x, y)
 			if err != nil {
 				return err
 			}
@@ -246,8 +426,7 @@ func keyDownImpl(cb Backend, hwnd uintptr, k Key) error {
 		return hid.KeyDown(uint16(k))
 	}
 	if hwnd == 0 {
-		vk := keyboard.MapScanCodeToVK(k)
-		window.ProcKeybdEvent.Call(vk, 0, 0, 0)
+		keybdEvent(k, false)
 		return nil
 	}
 	return keyboard.KeyDown(hwnd, k)
@@ -258,34 +437,64 @@ func keyUpImpl(cb Backend, hwnd uintptr, k Key) error {
 		return hid.KeyUp(uint16(k))
 	}
 	if hwnd == 0 {
-		vk := keyboard.MapScanCodeToVK(k)
-		window.ProcKeybdEvent.Call(vk, 0, 0x0002, 0)
+		keybdEvent(k, true)
 		return nil
 	}
 	return keyboard.KeyUp(hwnd, k)
 }
 
+// keybdEvent injects a global key event for the Message backend. Extended keys need
+// KEYEVENTF_EXTENDEDKEY, or the navigation cluster arrives as the numeric keypad (typing
+// digits while NumLock is on). KeyCtrl and KeyAlt are sent as the left modifiers even though
+// their codes double as the right-hand ones: a right Alt is AltGr on many layouts. Likewise
+// KeySlash is sent as the main-keyboard slash rather than the numeric keypad's KeyDivide.
+func keybdEvent(k Key, up bool) {
+	var flags uintptr
+	if keyboard.IsExtended(k) && k != KeyCtrl && k != KeyAlt && k != KeySlash {
+		flags |= KEYEVENTF_EXTENDEDKEY
+	}
+	if up {
+		flags |= KEYEVENTF_KEYUP
+	}
+	window.ProcKeybdEvent.Call(uintptr(keyboard.VKFromKey(k)), uintptr(k&0xFF), flags, 0)
+}
+
 // -----------------------------------------------------------------------------
 // Input API (Mouse)
 // -----------------------------------------------------------------------------
 
 // Move simulates mouse movement to the specified client coordinates.
 func (w *Window) Move(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.move(x, y)
+}
+
+func (w *Window) move(x, y int32) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	w.recordAction("Move", x, y)
 	return moveImpl(getBackend(), w.HWND, x, y, false)
 }
 
 // MoveRel simulates relative mouse movement from the current cursor position.
 func (w *Window) MoveRel(dx, dy int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.moveRel(dx, dy)
+}
+
+func (w *Window) moveRel(dx, dy int32) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
@@ -297,17 +506,25 @@ func (w *Window) MoveRel(dx, dy int32) error {
 
 // Click simulates a left mouse button click at the specified client coordinates.
 func (w *Window) Click(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.click(x, y)
+}
+
+func (w *Window) click(x, y int32) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	w.recordAction("Click", x, y)
 
 	if getBackend() == BackendHID {
-		sx, sy, err := window.ClientToScreen(w.HWND, x, y)
+		sx, sy, err := clientToScreen(w.HWND, x, y)
 		if err != nil {
 			return err
 		}
@@ -318,17 +535,25 @@ func (w *Window) Click(x, y int32) error {
 
 // ClickRight simulates a right mouse button click at the specified client coordinates.
 func (w *Window) ClickRight(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.clickRight(x, y)
+}
+
+func (w *Window) clickRight(x, y int32) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	w.recordAction("ClickRight", x, y)
 
 	if getBackend() == BackendHID {
-		sx, sy, err := window.ClientToScreen(w.HWND, x, y)
+		sx, sy, err := clientToScreen(w.HWND, x, y)
 		if err != nil {
 			return err
 		}
@@ -339,17 +564,25 @@ func (w *Window) ClickRight(x, y int32) error {
 
 // ClickMiddle simulates a middle mouse button click at the specified client coordinates.
 func (w *Window) ClickMiddle(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.clickMiddle(x, y)
+}
+
+func (w *Window) clickMiddle(x, y int32) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	w.recordAction("ClickMiddle", x, y)
 
 	if getBackend() == BackendHID {
-		sx, sy, err := window.ClientToScreen(w.HWND, x, y)
+		sx, sy, err := clientToScreen(w.HWND, x, y)
 		if err != nil {
 			return err
 		}
@@ -360,35 +593,51 @@ func (w *Window) ClickMiddle(x, y int32) error {
 
 // DoubleClick simulates a left mouse button double-click at the specified client coordinates.
 func (w *Window) DoubleClick(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.doubleClick(x, y)
+}
+
+func (w *Window) doubleClick(x, y int32) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	w.recordAction("DoubleClick", x, y)
 
 	if getBackend() == BackendHID {
-		sx, sy, err := window.ClientToScreen(w.HWND, x, y)
+		sx, sy, err := clientToScreen(w.HWND, x, y)
 		if err != nil {
 			return err
 		}
-		return hid.DoubleClick(sx, sy)
+		return hid.DoubleClickWithTiming(sx, sy, w.timingFor(nil).doubleClick())
 	}
 	return mouse.DoubleClick(w.HWND, x, y)
 }
 
 // Scroll simulates a vertical mouse wheel scroll.
 func (w *Window) Scroll(x, y int32, delta int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.scroll(x, y, delta)
+}
+
+func (w *Window) scroll(x, y int32, delta int32) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	w.recordAction("Scroll", x, y)
 
 	if getBackend() == BackendHID {
 		return hid.Scroll(delta)
@@ -402,33 +651,55 @@ func (w *Window) Scroll(x, y int32, delta int32) error {
 
 // MoveMouseTo moves the mouse cursor to the specified absolute screen coordinates (Virtual Desktop).
 func MoveMouseTo(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return moveMouseTo(x, y)
+}
+
+func moveMouseTo(x, y int32) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	x, y, guard := guardWorkArea(x, y)
 
 	if getBackend() == BackendHID {
-		return hid.Move(x, y)
+		if err := hid.Move(x, y); err != nil {
+			return err
+		}
+		return guard
 	}
 
 	r, _, _ := window.ProcSetCursorPos.Call(uintptr(x), uintptr(y))
 	if r == 0 {
 		return fmt.Errorf("SetCursorPos failed")
 	}
-	return nil
+	return guard
 }
 
 // ClickMouseAt moves to the specified screen coordinates and performs a left click.
 func ClickMouseAt(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return clickMouseAt(x, y)
+}
+
+func clickMouseAt(x, y int32) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	x, y, guard := guardWorkArea(x, y)
 
 	if getBackend() == BackendHID {
-		return hid.Click(x, y)
+		if err := hid.Click(x, y); err != nil {
+			return err
+		}
+		return guard
 	}
 
 	// Message Backend Fallback (duplicated logic from MoveMouseTo to avoid calling locked func)
@@ -440,19 +711,30 @@ func ClickMouseAt(x, y int32) error {
 	time.Sleep(30 * time.Millisecond)
 	window.ProcMouseEvent.Call(0x0002, 0, 0, 0, 0)
 	window.ProcMouseEvent.Call(0x0004, 0, 0, 0, 0)
-	return nil
+	return guard
 }
 
 // DoubleClickMouseAt moves to the specified screen coordinates and performs a left double-click.
 func DoubleClickMouseAt(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return doubleClickMouseAt(x, y)
+}
+
+func doubleClickMouseAt(x, y int32) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	x, y, guard := guardWorkArea(x, y)
 
 	if getBackend() == BackendHID {
-		return hid.DoubleClick(x, y)
+		if err := hid.DoubleClickWithTiming(x, y, GetTiming().doubleClick()); err != nil {
+			return err
+		}
+		return guard
 	}
 
 	// Message Backend Fallback
@@ -473,49 +755,61 @@ func DoubleClickMouseAt(x, y int32) error {
 	window.ProcMouseEvent.Call(MOUSEEVENTF_LEFTDOWN, 0, 0, 0, 0)
 	window.ProcMouseEvent.Call(MOUSEEVENTF_LEFTUP, 0, 0, 0, 0)
 
-	// Interval (short enough for OS to register as double click)
-	time.Sleep(50 * time.Millisecond)
+	// Interval: short enough for the OS to register a double click even when the user has
+	// lowered the double-click time.
+	gap := 50 * time.Millisecond
+	if dct, _, _ := window.ProcGetDoubleClickTime.Call(); dct > 0 {
+		gap = min(gap, time.Duration(dct)*time.Millisecond/4)
+	}
+	time.Sleep(gap)
 
 	// Second Click
 	window.ProcMouseEvent.Call(MOUSEEVENTF_LEFTDOWN, 0, 0, 0, 0)
 	window.ProcMouseEvent.Call(MOUSEEVENTF_LEFTUP, 0, 0, 0, 0)
 
-	return nil
+	return guard
 }
 
 // ClickRightMouseAt moves to the specified screen coordinates and performs a right click.
 func ClickRightMouseAt(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
-	if err := checkBackend(); err != nil {
+	unlock, err := lockInput()
+	if err != nil {
 		return err
 	}
+	defer unlock()
+	return clickRightMouseAt(x, y)
+}
 
-	if getBackend() == BackendHID {
-		return hid.ClickRight(x, y)
-	}
+func clickRightMouseAt(x, y int32) error {
+	return clickButtonAt(x, y, MouseRight)
+}
 
-	r, _, _ := window.ProcSetCursorPos.Call(uintptr(x), uintptr(y))
-	if r == 0 {
-		return fmt.Errorf("SetCursorPos failed")
+// ClickMiddleMouseAt moves to the specified screen coordinates and performs a middle click.
+func ClickMiddleMouseAt(x, y int32) error {
+	unlock, err := lockInput()
+	if err != nil {
+		return err
 	}
+	defer unlock()
+	return clickMiddleMouseAt(x, y)
+}
 
-	time.Sleep(30 * time.Millisecond)
-	window.ProcMouseEvent.Call(0x0008, 0, 0, 0, 0) // RIGHTDOWN
-	window.ProcMouseEvent.Call(0x0010, 0, 0, 0, 0) // RIGHTUP
-	return nil
+func clickMiddleMouseAt(x, y int32) error {
+	return clickButtonAt(x, y, MouseMiddle)
 }
 
-// ClickMiddleMouseAt moves to the specified screen coordinates and performs a middle click.
-func ClickMiddleMouseAt(x, y int32) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+// clickButtonAt moves to the screen point and clicks button b there.
+func clickButtonAt(x, y int32, b MouseButton) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
+	x, y, guard := guardWorkArea(x, y)
 
 	if getBackend() == BackendHID {
-		return hid.ClickMiddle(x, y)
+		if err := hid.ClickButton(x, y, b.hid()); err != nil {
+			return err
+		}
+		return guard
 	}
 
 	r, _, _ := window.ProcSetCursorPos.Call(uintptr(x), uintptr(y))
@@ -524,9 +818,10 @@ func ClickMiddleMouseAt(x, y int32) error {
 	}
 
 	time.Sleep(30 * time.Millisecond)
-	window.ProcMouseEvent.Call(0x0020, 0, 0, 0, 0) // MIDDLEDOWN
-	window.ProcMouseEvent.Call(0x0040, 0, 0, 0, 0) // MIDDLEUP
-	return nil
+	down, up, data := b.events()
+	window.ProcMouseEvent.Call(down, 0, 0, data, 0)
+	window.ProcMouseEvent.Call(up, 0, 0, data, 0)
+	return guard
 }
 
 // -----------------------------------------------------------------------------
@@ -535,6 +830,9 @@ func ClickMiddleMouseAt(x, y int32) error {
 
 type Key = keyboard.Key
 
+// KeyDef maps a character to the key that types it, and whether Shift is held.
+type KeyDef = keyboard.KeyDef
+
 const (
 	KeyEsc       = keyboard.KeyEsc
 	Key1         = keyboard.Key1
@@ -625,12 +923,32 @@ func KeyFromRune(r rune) (Key, bool) {
 	return k, ok
 }
 
+// KeyFromVK maps a virtual-key code (as used by AutoHotkey, AutoIt and the Windows docs)
+// to a Key under the current keyboard layout. See keyboard.KeyFromVK for the keys it rejects.
+func KeyFromVK(vk uint16) (Key, bool) {
+	return keyboard.KeyFromVK(vk)
+}
+
+// VKFromKey maps a Key to its virtual-key code under the current keyboard layout.
+// For every Key constant k, KeyFromVK(VKFromKey(k)) returns k; the reverse does not hold
+// for VKs that collapse onto one scan code (e.g. VK_LSHIFT and VK_SHIFT).
+func VKFromKey(k Key) uint16 {
+	return keyboard.VKFromKey(k)
+}
+
 // Public Wrappers using Lock
 
 // KeyDown sends a key down event to the window.
 func (w *Window) KeyDown(key Key) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.keyDown(key)
+}
+
+func (w *Window) keyDown(key Key) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
@@ -642,8 +960,15 @@ func (w *Window) KeyDown(key Key) error {
 
 // KeyUp sends a key up event to the window.
 func (w *Window) KeyUp(key Key) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.keyUp(key)
+}
+
+func (w *Window) keyUp(key Key) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
@@ -655,8 +980,15 @@ func (w *Window) KeyUp(key Key) error {
 
 // Press simulates a key press (down then up).
 func (w *Window) Press(key Key) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.press(key)
+}
+
+func (w *Window) press(key Key) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
@@ -667,14 +999,21 @@ func (w *Window) Press(key Key) error {
 	if err := keyDownImpl(getBackend(), w.HWND, key); err != nil {
 		return err
 	}
-	time.Sleep(30 * time.Millisecond)
+	time.Sleep(w.Timing().KeyHold)
 	return keyUpImpl(getBackend(), w.HWND, key)
 }
 
 // PressHotkey presses a combination of keys (e.g., Ctrl+A).
 func (w *Window) PressHotkey(keys ...Key) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.pressHotkey(keys...)
+}
+
+func (w *Window) pressHotkey(keys ...Key) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
@@ -699,10 +1038,76 @@ func (w *Window) PressHotkey(keys ...Key) error {
 	return nil
 }
 
+// TypeStrategy selects how the Message backend delivers text to a window.
+type TypeStrategy int
+
+const (
+	// TypeStrategyAuto uses TypeStrategySetText for RichEdit-family controls and
+	// TypeStrategyChar for everything else (default).
+	TypeStrategyAuto TypeStrategy = iota
+	// TypeStrategyChar posts one WM_CHAR per character.
+	TypeStrategyChar
+	// TypeStrategySetText inserts the whole text at the selection with EM_SETTEXTEX in a single
+	// message, keeping it on the undo stack. It only applies to RichEdit controls; others get WM_CHAR,
+	// as does a RichEdit that rejects the message.
+	TypeStrategySetText
+	// TypeStrategyKeyEvents posts WM_KEYDOWN/WM_KEYUP pairs with explicit Shift transitions,
+	// for targets that ignore WM_CHAR, paced by the Timing's KeyDelay and KeyHold. Characters
+	// without a scan code fall back to WM_CHAR.
+	TypeStrategyKeyEvents
+	// TypeStrategyClipboard puts the text on the clipboard (overwriting it) and pastes it:
+	// WM_PASTE under the Message backend, Ctrl+V under the HID backend.
+	TypeStrategyClipboard
+)
+
+// CaretPlacement selects where TypeWithOptions moves the caret before typing.
+type CaretPlacement int
+
+const (
+	// CaretPreserve types at the current caret or selection (default).
+	CaretPreserve CaretPlacement = iota
+	// CaretStart moves the caret to the start of the text.
+	CaretStart
+	// CaretEnd moves the caret to the end of the text, to append.
+	CaretEnd
+)
+
+// Point is a pair of coordinates; the API taking it says whether they are client or screen coordinates.
+type Point struct {
+	X, Y int32
+}
+
+// TypeOptions configures TypeWithOptions.
+type TypeOptions struct {
+	// Strategy selects the Message backend delivery method. The HID backend always sends
+	// key strokes, except for TypeStrategyClipboard.
+	Strategy TypeStrategy
+	// Timing overrides the window's timing for this call when non-nil.
+	Timing *Timing
+	// ClickFirst, when non-nil, is clicked (client coordinates) before typing to give the
+	// control focus and a caret, within the same hold of the input lock.
+	ClickFirst *Point
+	// Caret moves the caret before typing, after ClickFirst. Edit and RichEdit controls get
+	// EM_SETSEL; other windows get Ctrl+Home / Ctrl+End.
+	Caret CaretPlacement
+}
+
 // Type simulates typing text.
 func (w *Window) Type(text string) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	return w.TypeWithOptions(text, TypeOptions{})
+}
+
+// TypeWithOptions simulates typing text with the given options.
+func (w *Window) TypeWithOptions(text string, opts TypeOptions) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.typeText(text, opts)
+}
+
+func (w *Window) typeText(text string, opts TypeOptions) error {
 	if err := w.checkReady(); err != nil {
 		return err
 	}
@@ -710,38 +1115,121 @@ func (w *Window) Type(text string) error {
 		return err
 	}
 
+	if p := opts.ClickFirst; p != nil {
+		if err := w.click(p.X, p.Y); err != nil {
+			return err
+		}
+	}
+	if err := w.placeCaret(opts.Caret); err != nil {
+		return err
+	}
+
 	cb := getBackend()
+	timing := w.timingFor(opts.Timing)
+	runes := settingsFor(w.HWND).runes
+	if opts.Strategy == TypeStrategyClipboard {
+		return pasteText(cb, w.HWND, text)
+	}
 	if cb == BackendMessage {
+		switch opts.Strategy {
+		case TypeStrategyKeyEvents:
+			return keyboard.TypeKeysMapped(w.HWND, text, timing.KeyDelay, timing.KeyHold, runes)
+		case TypeStrategyAuto, TypeStrategySetText:
+			// WM_CHAR is paced at 30ms per character (10KB takes ~5 minutes) and can reorder
+			// under load in RichEdit; EM_SETTEXTEX inserts everything in one message.
+			// Runes registered on the window must be sent as keys, so they rule this out, as
+			// does a window of the calling thread (see SetAllowSelfTarget); WM_CHAR is posted.
+			if !hasRune(text, runes) && w.checkSelfTarget() == nil && window.IsRichEdit(w.HWND) && window.SetTextEx(w.HWND, text, true, 2000) == nil {
+				return nil
+			}
+		}
 		// Use WM_CHAR for reliability in background
-		return keyboard.Type(w.HWND, text)
+		if len(runes) > 0 {
+			return keyboard.TypeWithKeys(w.HWND, text, timing.KeyDelay, runes)
+		}
+		return keyboard.TypeWithDelay(w.HWND, text, timing.KeyDelay)
 	}
 
 	// HID Backend simulation
-	for _, r := range text {
-		k, shifted, ok := keyboard.LookupKey(r)
-		if !ok {
-			return ErrUnsupportedKey
+	for i, r := range text {
+		if err := hidTypeRune(r, i, runes); err != nil {
+			return err
 		}
+		time.Sleep(timing.KeyDelay)
+	}
+	return nil
+}
 
-		if shifted {
-			hid.KeyDown(uint16(KeyShift))
-			time.Sleep(10 * time.Millisecond)
-			hid.Press(uint16(k))
-			hid.KeyUp(uint16(KeyShift))
-		} else {
-			hid.Press(uint16(k))
+func (w *Window) placeCaret(c CaretPlacement) error {
+	if c == CaretPreserve {
+		return nil
+	}
+	if window.IsEditControl(w.HWND) && w.checkSelfTarget() == nil {
+		pos := 0
+		if c == CaretEnd {
+			n, err := window.GetTextLength(w.HWND, 200)
+			if err != nil {
+				return mapAccessDenied(err)
+			}
+			pos = n
 		}
-		time.Sleep(30 * time.Millisecond)
+		return mapAccessDenied(window.SetSel(w.HWND, pos, pos, 200))
 	}
-	return nil
+	if c == CaretEnd {
+		return w.pressHotkey(KeyCtrl, KeyEnd)
+	}
+	return w.pressHotkey(KeyCtrl, KeyHome)
+}
+
+// ReplaceText replaces the entire content of an Edit or RichEdit control in one message
+// (EM_SETTEXTEX for RichEdit, keeping undo; WM_SETTEXT otherwise). It is message-based under both backends.
+func (w *Window) ReplaceText(text string) error {
+	unlock, err := w.lockReady()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return w.replaceText(text)
+}
+
+func (w *Window) replaceText(text string) error {
+	if !w.IsValid() {
+		return ErrWindowGone
+	}
+	if err := w.checkSelfTarget(); err != nil {
+		return err
+	}
+	if window.IsRichEdit(w.HWND) && window.SetTextEx(w.HWND, text, false, 2000) == nil {
+		return nil
+	}
+	return mapAccessDenied(window.SetText(w.HWND, text, 2000))
+}
+
+// Selection returns the selection of an Edit or RichEdit control in UTF-16 code units.
+// When start == end there is no selection and start is the caret position.
+func (w *Window) Selection() (start, end int, err error) {
+	if !w.IsValid() {
+		return 0, 0, ErrWindowGone
+	}
+	if err := w.checkSelfTarget(); err != nil {
+		return 0, 0, err
+	}
+	return window.GetSel(w.HWND, 200)
 }
 
 // Global Wrappers
 
 // KeyDown simulates a global key down event.
 func KeyDown(k Key) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return keyDown(k)
+}
+
+func keyDown(k Key) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
@@ -750,8 +1238,15 @@ func KeyDown(k Key) error {
 
 // KeyUp simulates a global key up event.
 func KeyUp(k Key) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return keyUp(k)
+}
+
+func keyUp(k Key) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
@@ -760,8 +1255,15 @@ func KeyUp(k Key) error {
 
 // Press simulates a global key press (down then up).
 func Press(k Key) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return press(k)
+}
+
+func press(k Key) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
@@ -769,14 +1271,21 @@ func Press(k Key) error {
 	if err := keyDownImpl(getBackend(), 0, k); err != nil {
 		return err
 	}
-	time.Sleep(30 * time.Millisecond)
+	time.Sleep(GetTiming().KeyHold)
 	return keyUpImpl(getBackend(), 0, k)
 }
 
 // PressHotkey simulates a global combination of keys.
 func PressHotkey(keys ...Key) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return pressHotkey(keys...)
+}
+
+func pressHotkey(keys ...Key) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
@@ -798,59 +1307,51 @@ func PressHotkey(keys ...Key) error {
 	return nil
 }
 
-var (
-	sendInputOnce sync.Once
-	sendInputErr  error
-)
-
 // Type simulates typing text globally.
 func Type(text string) error {
-	inputMutex.Lock()
-	defer inputMutex.Unlock()
+	unlock, err := lockInput()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return typeGlobal(text)
+}
+
+func typeGlobal(text string) error {
 	if err := checkBackend(); err != nil {
 		return err
 	}
 
 	cb := getBackend()
 	if cb == BackendHID {
-		for _, r := range text {
-			k, shifted, ok := keyboard.LookupKey(r)
-			if !ok {
-				return ErrUnsupportedKey
-			}
-			if shifted {
-				hid.KeyDown(uint16(KeyShift))
-				time.Sleep(10 * time.Millisecond)
-				hid.Press(uint16(k))
-				hid.KeyUp(uint16(KeyShift))
-			} else {
-				hid.Press(uint16(k))
+		delay := GetTiming().KeyDelay
+		for i, r := range text {
+			if err := hidTypeRune(r, i, nil); err != nil {
+				return err
 			}
-			time.Sleep(30 * time.Millisecond)
+			time.Sleep(delay)
 		}
 		return nil
 	}
 
-	// Message Backend Fallback: SendInput with Unicode
-	sendInputOnce.Do(func() {
-		// Self-test to check if SendInput is viable (permissions, etc.)
-		var inputs [1]input
-		inputs[0].Type = INPUT_KEYBOARD
-		inputs[0].Ki.WScan = 'A' // Dummy char
-		inputs[0].Ki.DwFlags = KEYEVENTF_UNICODE
+	return typeGlobalMessage(text)
+}
 
-		n, _, _ := window.ProcSendInput.Call(1, uintptr(unsafe.Pointer(&inputs[0])), uintptr(unsafe.Sizeof(inputs[0])))
-		if n == 0 {
-			sendInputErr = errors.New("SendInput self-test failed; unsupported in this context")
-		}
-	})
-	if sendInputErr != nil {
-		return sendInputErr
+// hidTypeRune types a single character with the HID backend, holding Shift if needed.
+// index is the byte offset of r in the text being typed, reported if r cannot be mapped.
+// runes, if any, are consulted before the global mappings.
+func hidTypeRune(r rune, index int, runes keyboard.RuneMap) error {
+	k, shifted, ok := runes.Lookup(r)
+	if !ok {
+		return newUnsupportedKeyError(r, index)
 	}
-
-	for _, r := range text {
-		sendUnicode(r)
-		time.Sleep(30 * time.Millisecond)
+	if shifted {
+		hid.KeyDown(uint16(KeyShift))
+		time.Sleep(10 * time.Millisecond)
+		hid.Press(uint16(k))
+		hid.KeyUp(uint16(KeyShift))
+	} else {
+		hid.Press(uint16(k))
 	}
 	return nil
 }
@@ -866,15 +1367,19 @@ type keyboardInput struct {
 type input struct {
 	Type uint32
 	Ki   keyboardInput
+	// INPUT is a union sized by MOUSEINPUT, which is 8 bytes larger than KEYBDINPUT.
+	// SendInput rejects calls whose cbSize does not match sizeof(INPUT).
+	_ [8]byte
 }
 
 const (
-	INPUT_KEYBOARD    = 1
-	KEYEVENTF_UNICODE = 0x0004
-	KEYEVENTF_KEYUP   = 0x0002
+	INPUT_KEYBOARD        = 1
+	KEYEVENTF_EXTENDEDKEY = 0x0001
+	KEYEVENTF_UNICODE     = 0x0004
+	KEYEVENTF_KEYUP       = 0x0002
 )
 
-func sendUnicode(r rune) {
+func sendUnicode(r rune) error {
 	var inputs [2]input
 	inputs[0].Type = INPUT_KEYBOARD
 	inputs[0].Ki.WScan = uint16(r)
@@ -883,7 +1388,11 @@ func sendUnicode(r rune) {
 	inputs[1] = inputs[0]
 	inputs[1].Ki.DwFlags = KEYEVENTF_UNICODE | KEYEVENTF_KEYUP
 
-	window.ProcSendInput.Call(2, uintptr(unsafe.Pointer(&inputs[0])), uintptr(unsafe.Sizeof(inputs[0])))
+	n, _, e := window.ProcSendInput.Call(2, uintptr(unsafe.Pointer(&inputs[0])), uintptr(unsafe.Sizeof(inputs[0])))
+	if n == 0 {
+		return fmt.Errorf("SendInput failed: %v", e)
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -896,10 +1405,78 @@ func GetCursorPos() (int32, int32, error) {
 }
 
 // EnablePerMonitorDPI sets the process to be Per-Monitor DPI aware.
+// On systems without Per-Monitor support it falls back to System Aware;
+// use DPIAwarenessLevel to find out which level was achieved.
+//
+// It is idempotent and safe for concurrent use. Because the OS lets the awareness be set
+// only once, it returns a *DPIAlreadySetError (matching ErrDPIAlreadySet) with the current
+// level if a manifest or host application already chose a level other than Per-Monitor V2.
 func EnablePerMonitorDPI() error {
 	return window.EnablePerMonitorDPI()
 }
 
+// Level describes the DPI awareness level of the process.
+type Level = window.DPIAwarenessLevel
+
+const (
+	// LevelUnaware means the OS bitmap-stretches the process on high-DPI monitors.
+	LevelUnaware = window.DPIUnaware
+	// LevelSystemAware means coordinates are exact on the primary monitor only.
+	LevelSystemAware = window.DPISystemAware
+	// LevelPerMonitor means coordinates are exact on every monitor.
+	LevelPerMonitor = window.DPIPerMonitor
+	// LevelPerMonitorV2 is LevelPerMonitor with improved non-client scaling.
+	LevelPerMonitorV2 = window.DPIPerMonitorV2
+)
+
+// MoveInaccurateError reports where the cursor ended up when an HID move gave up;
+// EdgeConstrained tells whether screen edges were involved.
+type MoveInaccurateError = hid.MoveInaccurateError
+
+// DPIAlreadySetError reports the level a process was already locked to; see EnablePerMonitorDPI.
+type DPIAlreadySetError = window.DPIAlreadySetError
+
+// UnsupportedOSError names the Windows API a feature needs but the running version lacks.
+type UnsupportedOSError = window.UnsupportedOSError
+
+// ChildIndexError reports how many children of a class exist when FindChildByClassNth's
+// index is out of range. It matches ErrChildIndexOutOfRange with errors.Is.
+type ChildIndexError = window.ChildIndexError
+
+// Capabilities reports which version-dependent Windows APIs are available; see OSCapabilities.
+type Capabilities = window.Capabilities
+
+// Rect is a rectangle in screen or client coordinates; Right and Bottom are exclusive.
+type Rect = window.RECT
+
+// OSCapabilities reports which version-dependent APIs the running Windows provides, e.g. to
+// find out up front how the package will degrade on Windows 7 or 8. Features whose API is
+// missing either use a documented fallback or return an *UnsupportedOSError naming it.
+func OSCapabilities() Capabilities {
+	return window.OSCapabilities()
+}
+
+// DPIAwareness reports the DPI awareness level in effect for the calling thread (the process
+// level unless the thread overrode it). Libraries embedded in a host application can use it
+// to adapt to the host's choice. The error is non-nil only if no query API is available.
+func DPIAwareness() (Level, error) {
+	return window.DPIAwareness()
+}
+
+// DPIAwarenessLevel reports the DPI awareness level currently in effect for the calling
+// thread, which is the process level unless the thread overrode it with
+// SetThreadDpiAwarenessContext. Call it after EnablePerMonitorDPI to find out which step of
+// the fallback chain succeeded.
+func DPIAwarenessLevel() Level {
+	return window.GetDPIAwareness()
+}
+
+// IsPerMonitorDPIAware reports whether the calling thread is Per-Monitor DPI Aware (V1 or V2),
+// which is the process's awareness unless the thread overrode it.
+func IsPerMonitorDPIAware() bool {
+	return window.IsPerMonitorDPIAware()
+}
+
 // DPI returns the DPI of the window.
 func (w *Window) DPI() (uint32, uint32, error) {
 	return window.GetDPI(w.HWND)
@@ -916,6 +1493,7 @@ func (w *Window) ScreenToClient(x, y int32) (cx, cy int32, err error) {
 }
 
 // ClientToScreen converts client coordinates to screen coordinates.
+// During a burst (see BeginBurst) the result is computed from the snapshot.
 func (w *Window) ClientToScreen(x, y int32) (sx, sy int32, err error) {
-	return window.ClientToScreen(w.HWND, x, y)
+	return clientToScreen(w.HWND, x, y)
 }
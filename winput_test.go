@@ -1,16 +1,27 @@
+//go:build windows
+
 package winput_test
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
+	"image"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"syscall"
 	"testing"
 	"time"
+	"unsafe"
 
 	"github.com/rpdg/winput"
 	"github.com/rpdg/winput/screen"
+	"github.com/rpdg/winput/window"
 )
 
 // Define command line flags
@@ -26,31 +37,19 @@ func TestMain(m *testing.M) {
 }
 
 // setupTestApp launches notepad and returns its Window object
-func setupTestApp(t *testing.T) (*winput.Window, *exec.Cmd) {
-	cmd := exec.Command("notepad.exe")
-	if err := cmd.Start(); err != nil {
-		t.Fatalf("Failed to start notepad: %v", err)
-	}
-
-	// Wait for window initialization
-	time.Sleep(1 * time.Second)
-
-	// Try finding window by process name
-	wins, err := winput.FindByProcessName("notepad.exe")
-	if err != nil || len(wins) == 0 {
-		// Clean up
-		cmd.Process.Kill()
+func setupTestApp(t *testing.T) (*winput.Window, *os.Process) {
+	// StartAndFind follows modern Notepad's launcher to the process that owns the window.
+	targetWin, proc, err := winput.StartAndFind("notepad.exe", nil, 10*time.Second)
+	if err != nil {
 		t.Fatalf("Could not find notepad window after launch: %v", err)
 	}
 
-	// Assume the first one is the target
-	targetWin := wins[0]
-
-	if !targetWin.IsVisible() {
-		t.Log("Warning: Notepad window is not visible")
+	// Wait for window initialization
+	if err := targetWin.WaitIdle(5 * time.Second); err != nil {
+		t.Logf("Warning: WaitIdle: %v", err)
 	}
 
-	return targetWin, cmd
+	return targetWin, proc
 }
 
 func findNotepadTextControl(w *winput.Window) (*winput.Window, error) {
@@ -71,9 +70,9 @@ func findNotepadTextControl(w *winput.Window) (*winput.Window, error) {
 	return nil, errors.New("notepad text control not found")
 }
 
-func cleanupTestApp(cmd *exec.Cmd) {
-	if cmd != nil && cmd.Process != nil {
-		cmd.Process.Kill()
+func cleanupTestApp(proc *os.Process) {
+	if proc != nil {
+		proc.Kill()
 	}
 }
 
@@ -89,8 +88,8 @@ func abs(x int32) int32 {
 // -----------------------------------------------------------------------------
 
 func TestWindowDiscovery(t *testing.T) {
-	w, cmd := setupTestApp(t)
-	defer cleanupTestApp(cmd)
+	w, proc := setupTestApp(t)
+	defer cleanupTestApp(proc)
 
 	t.Run("IsValid", func(t *testing.T) {
 		if !w.IsValid() {
@@ -99,7 +98,7 @@ func TestWindowDiscovery(t *testing.T) {
 	})
 
 	t.Run("FindByPID", func(t *testing.T) {
-		pid := uint32(cmd.Process.Pid)
+		pid := uint32(proc.Pid)
 		wins, err := winput.FindByPID(pid)
 		if err != nil {
 			t.Fatalf("Failed to find by PID %d: %v", pid, err)
@@ -107,6 +106,26 @@ func TestWindowDiscovery(t *testing.T) {
 		if len(wins) == 0 {
 			t.Error("FindByPID returned empty list")
 		}
+
+		again, err := winput.FindByPID(pid)
+		if err != nil || len(again) != len(wins) {
+			t.Fatalf("second FindByPID returned %d windows, err %v", len(again), err)
+		}
+		for i := range wins {
+			if wins[i].HWND != again[i].HWND {
+				t.Errorf("FindByPID order not stable at index %d", i)
+			}
+		}
+	})
+
+	t.Run("MainWindowOfPID", func(t *testing.T) {
+		main, err := winput.MainWindowOfPID(uint32(proc.Pid))
+		if err != nil {
+			t.Skipf("no main window for launched PID (launcher process?): %v", err)
+		}
+		if !main.IsVisible() {
+			t.Error("MainWindowOfPID returned an invisible window")
+		}
 	})
 
 	t.Run("Coordinates", func(t *testing.T) {
@@ -125,6 +144,1031 @@ func TestWindowDiscovery(t *testing.T) {
 // 2. Mouse Input Tests (Global & Relative)
 // -----------------------------------------------------------------------------
 
+func TestFindByTitleContains(t *testing.T) {
+	visible, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, Title: "Report.txt - winput Find Test"})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer visible.Close()
+	// Not Visible: shown off-screen as a tool window.
+	tool, err := winput.NewTestWindow(winput.TestWindowOptions{Title: "report.txt - WINPUT FIND TEST (tool)"})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer tool.Close()
+
+	w, err := winput.FindByTitleContains("winput find test")
+	if err != nil || w.HWND != visible.HWND {
+		t.Fatalf("FindByTitleContains = %v, %v; want the visible window", w, err)
+	}
+
+	all, err := winput.FindAllByTitleContains("WINPUT find TEST", winput.IncludeHidden())
+	if err != nil || len(all) != 2 {
+		t.Fatalf("FindAllByTitleContains(IncludeHidden) = %d windows, %v; want 2", len(all), err)
+	}
+
+	if _, err := winput.FindByTitleContains("no such window \u2603"); !errors.Is(err, winput.ErrWindowNotFound) {
+		t.Errorf("expected ErrWindowNotFound, got %v", err)
+	}
+
+	t.Run("Regex", func(t *testing.T) {
+		all, err := winput.FindByTitleRegex(`(?i)^report\.txt - winput find test`, winput.IncludeHidden())
+		if err != nil || len(all) != 2 {
+			t.Fatalf("FindByTitleRegex(IncludeHidden) = %d windows, %v; want 2", len(all), err)
+		}
+		all, err = winput.FindByTitleRegex(`^Report\.txt - winput Find Test$`)
+		if err != nil || len(all) != 1 || all[0].HWND != visible.HWND {
+			t.Fatalf("FindByTitleRegex = %d windows, %v; want the visible window", len(all), err)
+		}
+		if _, err := winput.FindByTitleRegex(`^$`, winput.IncludeHidden()); !errors.Is(err, winput.ErrWindowNotFound) {
+			t.Errorf("empty titles must be skipped, got %v", err)
+		}
+		if _, err := winput.FindByTitleRegex(`(`); err == nil || errors.Is(err, winput.ErrWindowNotFound) {
+			t.Errorf("expected a compile error, got %v", err)
+		}
+	})
+
+	t.Run("MatchOptions", func(t *testing.T) {
+		padded, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, Title: "Résumé - winput Match Test  "})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer padded.Close()
+
+		w, err := winput.FindByTitle("RÉSUMÉ - WINPUT MATCH TEST", winput.MatchCaseInsensitive(), winput.MatchTrimSpace())
+		if err != nil || w.HWND != padded.HWND {
+			t.Fatalf("FindByTitle(MatchCaseInsensitive, MatchTrimSpace) = %v, %v; want the padded window", w, err)
+		}
+		if _, err := winput.FindByTitle("Résumé - winput Match Test"); !errors.Is(err, winput.ErrWindowNotFound) {
+			t.Errorf("FindByTitle without options must match exactly, got %v", err)
+		}
+		if _, err := winput.FindByTitle("résumé - winput match test", winput.MatchTrimSpace()); !errors.Is(err, winput.ErrWindowNotFound) {
+			t.Errorf("FindByTitle(MatchTrimSpace) must keep case, got %v", err)
+		}
+		w, err = winput.FindByTitleContains("  match test  ", winput.MatchTrimSpace())
+		if err != nil || w.HWND != padded.HWND {
+			t.Errorf("FindByTitleContains(MatchTrimSpace) = %v, %v; want the padded window", w, err)
+		}
+	})
+}
+
+func TestWindowTitleAndClass(t *testing.T) {
+	long := strings.Repeat("winput Title Test ", 40) // longer than the usual 256-char buffers
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{Title: long})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	if title, err := ow.Title(); err != nil || title != long {
+		t.Errorf("Title() = %d chars, %v; want %d chars", len(title), err, len(long))
+	}
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	hwnd, err := window.CreateMessageWindow() // created without a title
+	if err != nil {
+		t.Fatalf("CreateMessageWindow failed: %v", err)
+	}
+	defer window.DestroyWindow(hwnd)
+	if title, err := (&winput.Window{HWND: hwnd}).Title(); err != nil || title != "" {
+		t.Errorf("untitled Title() = %q, %v; want empty", title, err)
+	}
+
+	if class, err := ow.ClassName(); err != nil || class != "winput_test_window" {
+		t.Errorf("ClassName() = %q, %v; want winput_test_window", class, err)
+	}
+
+	ow.Close()
+	if _, err := ow.Title(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("Title() after Close = %v, want ErrWindowGone", err)
+	}
+	if _, err := ow.ClassName(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("ClassName() after Close = %v, want ErrWindowGone", err)
+	}
+}
+
+func TestWindowProcess(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	if pid, err := ow.ProcessID(); err != nil || pid != uint32(os.Getpid()) {
+		t.Errorf("ProcessID() = %d, %v; want %d", pid, err, os.Getpid())
+	}
+	if tid, err := ow.ThreadID(); err != nil || tid == 0 {
+		t.Errorf("ThreadID() = %d, %v; want a thread ID", tid, err)
+	} else if wtid, wpid := window.GetThreadProcessID(ow.HWND); wtid != tid || wpid != uint32(os.Getpid()) {
+		t.Errorf("GetThreadProcessID() = %d, %d; want %d, %d", wtid, wpid, tid, os.Getpid())
+	}
+	exe, _ := os.Executable()
+	if name, err := ow.ProcessName(); err != nil || !strings.EqualFold(name, filepath.Base(exe)) {
+		t.Errorf("ProcessName() = %q, %v; want %q", name, err, filepath.Base(exe))
+	}
+	if pumping, err := ow.IsPumping(); err != nil || !pumping {
+		t.Errorf("IsPumping() = %v, %v; want true", pumping, err)
+	}
+
+	ow.Close()
+	if _, err := ow.IsPumping(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("IsPumping() after Close = %v, want ErrWindowGone despite the cached result", err)
+	}
+	if _, err := ow.ProcessID(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("ProcessID() after Close = %v, want ErrWindowGone", err)
+	}
+	if _, err := ow.ProcessName(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("ProcessName() after Close = %v, want ErrWindowGone", err)
+	}
+	if _, err := ow.ThreadID(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("ThreadID() after Close = %v, want ErrWindowGone", err)
+	}
+	if err := ow.WaitIdle(time.Second); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("WaitIdle() after Close = %v, want ErrWindowGone", err)
+	}
+}
+
+func TestWindowChildren(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{Children: []winput.TestChild{
+		{Class: "Edit"},
+		{Class: "Button", Text: "OK"},
+		{Class: "Static", Children: []winput.TestChild{{Class: "Edit"}}},
+	}})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	children, err := ow.Children()
+	if err != nil {
+		t.Fatalf("Children failed: %v", err)
+	}
+	var classes []string
+	for _, c := range children {
+		class, _ := c.ClassName()
+		classes = append(classes, class)
+	}
+	if got, want := strings.Join(classes, ","), "Edit,Button,Static,Edit"; got != want {
+		t.Errorf("Children classes = %s, want %s (depth-first)", got, want)
+	}
+
+	t.Run("FindChildByClassNth", func(t *testing.T) {
+		nested, err := winput.NewTestWindow(winput.TestWindowOptions{Children: []winput.TestChild{
+			{Class: "Button", Text: "One"}, {Class: "Edit"}, {Class: "Button", Text: "Two"},
+		}})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer nested.Close()
+
+		for n, want := range []string{"One", "Two"} {
+			b, err := nested.FindChildByClassNth("Button", n)
+			if err != nil {
+				t.Fatalf("FindChildByClassNth(Button, %d) failed: %v", n, err)
+			}
+			if text, _ := b.Text(); text != want {
+				t.Errorf("FindChildByClassNth(Button, %d) = %q, want %q", n, text, want)
+			}
+		}
+		_, err = nested.FindChildByClassNth("Button", 2)
+		var cie *winput.ChildIndexError
+		if !errors.As(err, &cie) || cie.Count != 2 || !errors.Is(err, winput.ErrChildIndexOutOfRange) {
+			t.Errorf("FindChildByClassNth(Button, 2) = %v, want a ChildIndexError with Count 2", err)
+		}
+		if _, err := nested.FindChildByClassNth("Static", 0); !errors.Is(err, winput.ErrWindowNotFound) {
+			t.Errorf("FindChildByClassNth(Static, 0) = %v, want ErrWindowNotFound", err)
+		}
+	})
+
+	t.Run("FindChildByTitle", func(t *testing.T) {
+		dlg, err := winput.NewTestWindow(winput.TestWindowOptions{Children: []winput.TestChild{
+			{Class: "Static", Text: "Save changes?"},
+			{Class: "Static", Children: []winput.TestChild{{Class: "Button", Text: "&OK"}}},
+			{Class: "Button", Text: "Cancel"},
+		}})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer dlg.Close()
+
+		for _, tc := range []struct {
+			find func(string) (*winput.Window, error)
+			arg  string
+			want string
+		}{
+			{dlg.FindChildByTitle, "ok", "&OK"}, // nested, mnemonic ignored
+			{dlg.FindChildByTitle, "CANCEL", "Cancel"},
+			{dlg.FindChildByTitleContains, "changes", "Save changes?"},
+		} {
+			c, err := tc.find(tc.arg)
+			if err != nil {
+				t.Errorf("find(%q) failed: %v", tc.arg, err)
+				continue
+			}
+			if text, _ := c.Text(); text != tc.want {
+				t.Errorf("find(%q) = %q, want %q", tc.arg, text, tc.want)
+			}
+		}
+		if _, err := dlg.FindChildByTitle("Save"); !errors.Is(err, winput.ErrWindowNotFound) {
+			t.Errorf("FindChildByTitle(Save) = %v, want ErrWindowNotFound (exact match)", err)
+		}
+	})
+
+	t.Run("FindDescendantByClass", func(t *testing.T) {
+		// The Edit is two levels down; a Button at depth 2 precedes the one at depth 1
+		// in EnumChildWindows order, so breadth-first must pick the shallower one.
+		nested, err := winput.NewTestWindow(winput.TestWindowOptions{Children: []winput.TestChild{
+			{Class: "Static", Children: []winput.TestChild{{Class: "Edit"}, {Class: "Button", Text: "deep"}}},
+			{Class: "Button", Text: "shallow"},
+		}})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer nested.Close()
+
+		if _, err := nested.FindDescendantByClass("edit", 0); err != nil {
+			t.Errorf("FindDescendantByClass(edit) failed: %v", err)
+		}
+		if _, err := nested.FindDescendantByClass("Edit", 1); !errors.Is(err, winput.ErrWindowNotFound) {
+			t.Errorf("FindDescendantByClass(Edit, depth 1) = %v, want ErrWindowNotFound", err)
+		}
+		b, err := nested.FindDescendantByClass("Button", 0)
+		if err != nil {
+			t.Fatalf("FindDescendantByClass(Button) failed: %v", err)
+		}
+		if text, _ := b.Text(); text != "shallow" {
+			t.Errorf("FindDescendantByClass(Button) = %q, want the shallower \"shallow\"", text)
+		}
+	})
+
+	leaf := children[0]
+	if none, err := leaf.Children(); err != nil || none == nil || len(none) != 0 {
+		t.Errorf("Children of a leaf = %v, %v; want an empty slice", none, err)
+	}
+
+	ow.Close()
+	if _, err := ow.Children(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("Children after Close = %v, want ErrWindowGone", err)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{Children: []winput.TestChild{
+		{Class: "Static", ID: 10, Children: []winput.TestChild{{Class: "Edit", ID: 11}}},
+	}})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	attempts := 0
+	root := winput.ResolveFunc("flaky", func(*winput.Window) (*winput.Window, error) {
+		if attempts++; attempts < 3 {
+			return nil, winput.ErrWindowNotFound
+		}
+		return ow.Window, nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	edit, err := winput.Resolve(ctx, root, winput.ChildByID(10), winput.ChildByClass("Edit"))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if class, _ := edit.ClassName(); class != "Edit" || attempts != 3 {
+		t.Errorf("Resolve = %s after %d attempts, want Edit after 3", class, attempts)
+	}
+
+	want := edit.HWND
+	edit.HWND = 0
+	if err := edit.Refind(ctx); err != nil || edit.HWND != want {
+		t.Errorf("Refind = %v, HWND %#x; want nil, %#x", err, edit.HWND, want)
+	}
+	if err := ow.Refind(ctx); !errors.Is(err, winput.ErrNotResolved) {
+		t.Errorf("Refind of a window not from Resolve = %v, want ErrNotResolved", err)
+	}
+
+	short, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	_, err = winput.Resolve(short, winput.ResolveFunc("root", func(*winput.Window) (*winput.Window, error) {
+		return ow.Window, nil
+	}), winput.ChildByClass("Button"))
+	var re *winput.ResolveError
+	if !errors.As(err, &re) || re.Step != 1 || re.Attempts == 0 {
+		t.Fatalf("Resolve of a missing child = %v, want a *ResolveError at step 1", err)
+	}
+	if !errors.Is(err, winput.ErrTimeout) || !errors.Is(err, winput.ErrWindowNotFound) {
+		t.Errorf("ResolveError %v should match ErrTimeout and ErrWindowNotFound", err)
+	}
+
+	if _, err := winput.Resolve(ctx, winput.ChildByClass("Edit")); err == nil || errors.As(err, &re) {
+		t.Errorf("Resolve starting with a chained step = %v, want a usage error", err)
+	}
+}
+
+func TestWaitForWindow(t *testing.T) {
+	title := fmt.Sprintf("winput wait %d", time.Now().UnixNano())
+	created := make(chan *winput.OwnedWindow, 1)
+	go func() {
+		time.Sleep(300 * time.Millisecond)
+		ow, err := winput.NewTestWindow(winput.TestWindowOptions{Title: title})
+		if err != nil {
+			t.Errorf("NewTestWindow failed: %v", err)
+		}
+		created <- ow
+	}()
+
+	w, err := winput.WaitForWindowByTitle(title, 5*time.Second)
+	ow := <-created
+	if ow == nil {
+		t.FailNow()
+	}
+	defer ow.Close()
+	if err != nil {
+		t.Fatalf("WaitForWindowByTitle failed: %v", err)
+	}
+	if w.HWND != ow.HWND {
+		t.Errorf("WaitForWindowByTitle = %#x, want %#x", w.HWND, ow.HWND)
+	}
+
+	start := time.Now()
+	_, err = winput.WaitForWindowByClass("winput_no_such_class", 200*time.Millisecond)
+	if !errors.Is(err, winput.ErrWindowNotFound) || time.Since(start) > 2*time.Second {
+		t.Errorf("WaitForWindowByClass of a missing class = %v after %v, want ErrWindowNotFound after ~200ms", err, time.Since(start))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = winput.WaitForWindowByProcessNameContext(ctx, "winput_no_such_process.exe")
+	if !errors.Is(err, winput.ErrWindowNotFound) || !errors.Is(err, context.Canceled) {
+		t.Errorf("WaitForWindowByProcessNameContext with a canceled context = %v, want ErrWindowNotFound and context.Canceled", err)
+	}
+
+	t.Run("Notepad", func(t *testing.T) {
+		cmd := exec.Command("notepad.exe")
+		if err := cmd.Start(); err != nil {
+			t.Skipf("Failed to start notepad: %v", err)
+		}
+		defer cleanupTestApp(cmd.Process)
+		w, err := winput.WaitForWindowByClass("Notepad", 5*time.Second)
+		if err != nil {
+			t.Fatalf("WaitForWindowByClass(Notepad) failed: %v", err)
+		}
+		if !w.IsVisible() {
+			t.Error("WaitForWindowByClass returned an invisible window")
+		}
+		if err := w.WaitIdle(5 * time.Second); err != nil {
+			t.Errorf("WaitIdle failed: %v", err)
+		}
+	})
+
+	t.Run("StartAndFind", func(t *testing.T) {
+		w, proc, err := winput.StartAndFind("notepad.exe", nil, 10*time.Second)
+		if err != nil {
+			t.Fatalf("StartAndFind failed: %v", err)
+		}
+		defer cleanupTestApp(proc)
+		if pid, _ := w.ProcessID(); pid != uint32(proc.Pid) {
+			t.Errorf("window belongs to PID %d, returned process is %d", pid, proc.Pid)
+		}
+
+		// A console program never shows a window of its own; this one exits by itself.
+		start := time.Now()
+		_, _, err = winput.StartAndFind("cmd.exe", []string{"/c", "exit"}, 500*time.Millisecond)
+		if !errors.Is(err, winput.ErrWindowNotFound) || time.Since(start) > 5*time.Second {
+			t.Errorf("StartAndFind of a console program = %v after %v, want ErrWindowNotFound", err, time.Since(start))
+		}
+		if _, _, err := winput.StartAndFind(`C:\winput\no_such_program.exe`, nil, time.Second); err == nil {
+			t.Error("StartAndFind of a missing executable succeeded")
+		}
+	})
+}
+
+func TestSimulateFocus(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	// expect reads the focus messages that follow, ignoring input messages.
+	expect := func(want ...[2]uintptr) {
+		t.Helper()
+		for _, w := range want {
+			for {
+				m, err := ow.NextMessage(2 * time.Second)
+				if err != nil {
+					t.Fatalf("message 0x%X not received", w[0])
+				}
+				if m.Msg != window.WM_ACTIVATE && m.Msg != window.WM_ACTIVATEAPP &&
+					m.Msg != window.WM_KILLFOCUS && m.Msg != window.WM_SETFOCUS {
+					continue
+				}
+				if uintptr(m.Msg) != w[0] || m.WParam&0xFFFF != w[1] {
+					t.Fatalf("got message 0x%X wParam %d, want 0x%X wParam %d", m.Msg, m.WParam, w[0], w[1])
+				}
+				break
+			}
+		}
+	}
+
+	if err := ow.SimulateFocusLoss(); err != nil {
+		t.Fatalf("SimulateFocusLoss failed: %v", err)
+	}
+	expect(
+		[2]uintptr{window.WM_ACTIVATE, window.WA_INACTIVE},
+		[2]uintptr{window.WM_ACTIVATEAPP, 0},
+		[2]uintptr{window.WM_KILLFOCUS, 0},
+	)
+
+	if err := ow.SimulateFocusGain(); err != nil {
+		t.Fatalf("SimulateFocusGain failed: %v", err)
+	}
+	expect(
+		[2]uintptr{window.WM_ACTIVATEAPP, 1},
+		[2]uintptr{window.WM_ACTIVATE, window.WA_ACTIVE},
+		[2]uintptr{window.WM_SETFOCUS, 0},
+	)
+
+	t.Run("StealFocus", func(t *testing.T) {
+		vw, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 100, Y: 100})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer vw.Close()
+		if !window.SetForegroundWindow(vw.HWND) {
+			t.Skip("cannot bring the test window to the foreground")
+		}
+		time.Sleep(100 * time.Millisecond)
+
+		restore, err := vw.StealFocus()
+		if errors.Is(err, winput.ErrActivateFailed) {
+			t.Skip("foreground change refused")
+		}
+		if err != nil {
+			t.Fatalf("StealFocus failed: %v", err)
+		}
+		if window.GetForegroundWindow() == vw.HWND {
+			t.Error("window still in the foreground after StealFocus")
+		}
+		if err := restore(); err != nil {
+			t.Fatalf("restore failed: %v", err)
+		}
+		if window.GetForegroundWindow() != vw.HWND {
+			t.Error("window not in the foreground after restore")
+		}
+	})
+}
+
+func TestActivate(t *testing.T) {
+	first, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 100, Y: 100})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer first.Close()
+	second, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 150, Y: 150})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer second.Close()
+
+	for _, ow := range []*winput.OwnedWindow{first, second, first} {
+		err := ow.Activate()
+		if errors.Is(err, winput.ErrActivateFailed) {
+			t.Skip("foreground change refused")
+		}
+		if err != nil {
+			t.Fatalf("Activate failed: %v", err)
+		}
+		if fg, err := winput.ForegroundWindow(); err != nil || fg.HWND != ow.HWND {
+			t.Fatalf("ForegroundWindow after Activate = %v, %v; want %#x", fg, err, ow.HWND)
+		}
+	}
+	if !first.IsForeground() || second.IsForeground() {
+		t.Errorf("IsForeground = %v, %v after activating the first window; want true, false", first.IsForeground(), second.IsForeground())
+	}
+
+	if err := first.Flash(0); err != nil {
+		t.Errorf("Flash of the foreground window = %v, want nil", err)
+	}
+	if err := second.Flash(2); err != nil {
+		t.Errorf("Flash of a background window = %v, want nil", err)
+	}
+
+	second.Minimize()
+	if err := second.Activate(); err != nil {
+		t.Fatalf("Activate of a minimized window failed: %v", err)
+	}
+	if window.IsIconic(second.HWND) || window.GetForegroundWindow() != second.HWND {
+		t.Error("minimized window was not restored to the foreground")
+	}
+}
+
+func TestFocus(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{
+		Children: []winput.TestChild{{Class: "Edit", ID: 1}, {Class: "Edit", ID: 2}},
+	})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+	tid, _ := window.GetThreadProcessID(ow.HWND)
+
+	for _, id := range []int{2, 1} {
+		hwnd, err := window.FindChildByID(ow.HWND, id)
+		if err != nil {
+			t.Fatalf("FindChildByID(%d) failed: %v", id, err)
+		}
+		edit := &winput.Window{HWND: hwnd}
+		if err := edit.Focus(); err != nil {
+			t.Fatalf("Focus of edit %d failed: %v", id, err)
+		}
+		if got := window.ThreadFocus(tid); got != edit.HWND {
+			t.Errorf("focus is on %#x after Focus, want edit %d (%#x)", got, id, edit.HWND)
+		}
+		if fc, err := ow.FocusedChild(); err != nil || fc.HWND != edit.HWND {
+			t.Errorf("FocusedChild = %v, %v; want edit %d (%#x)", fc, err, id, edit.HWND)
+		}
+	}
+
+	t.Run("CaretPos", func(t *testing.T) {
+		// Edit 1, at the top of the client area, has focus and so owns the caret.
+		x0, y0, err := ow.CaretPos()
+		if err != nil || y0 < 0 || y0 >= 24 {
+			t.Fatalf("CaretPos = (%d,%d), %v; want a point inside the first edit", x0, y0, err)
+		}
+		hwnd, _ := window.FindChildByID(ow.HWND, 1)
+		if err := (&winput.Window{HWND: hwnd}).Type("abc"); err != nil {
+			t.Fatalf("Type failed: %v", err)
+		}
+		deadline := time.Now().Add(time.Second)
+		for {
+			x, y, err := ow.CaretPos()
+			if err == nil && x > x0 && y == y0 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("CaretPos after typing = (%d,%d), %v; want it right of (%d,%d)", x, y, err, x0, y0)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+
+	ow.Close()
+	if err := ow.Focus(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("Focus after Close = %v, want ErrWindowGone", err)
+	}
+	if _, err := ow.FocusedChild(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("FocusedChild after Close = %v, want ErrWindowGone", err)
+	}
+}
+
+func TestWindowAt(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 200, Y: 200, Children: []winput.TestChild{{Class: "Edit"}}})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+	if err := ow.SetTopMost(true); err != nil {
+		t.Fatalf("SetTopMost failed: %v", err)
+	}
+	edit, err := ow.FindChildByClass("Edit")
+	if err != nil {
+		t.Fatalf("FindChildByClass failed: %v", err)
+	}
+
+	// The Edit covers client (0..120, 0..24); below it is the window's own client area.
+	for _, tc := range []struct {
+		cx, cy int32
+		want   uintptr
+	}{{10, 10, edit.HWND}, {10, 100, ow.HWND}} {
+		x, y, err := ow.ClientToScreen(tc.cx, tc.cy)
+		if err != nil {
+			t.Fatalf("ClientToScreen failed: %v", err)
+		}
+		w, err := winput.WindowAt(x, y)
+		if err != nil || w.HWND != tc.want {
+			t.Errorf("WindowAt(client %d,%d) = %v, %v; want %#x", tc.cx, tc.cy, w, err, tc.want)
+		}
+		top, err := winput.TopLevelWindowAt(x, y)
+		if err != nil || top.HWND != ow.HWND {
+			t.Errorf("TopLevelWindowAt(client %d,%d) = %v, %v; want %#x", tc.cx, tc.cy, top, err, ow.HWND)
+		}
+	}
+
+	if _, err := winput.WindowAt(-100000, -100000); !errors.Is(err, winput.ErrWindowNotFound) {
+		t.Errorf("WindowAt outside every monitor = %v, want ErrWindowNotFound", err)
+	}
+}
+
+func TestShowState(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 100, Y: 100})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	if err := ow.Minimize(); err != nil {
+		t.Fatalf("Minimize failed: %v", err)
+	}
+	if !ow.IsMinimized() {
+		t.Error("IsMinimized = false after Minimize")
+	}
+	if err := ow.Click(10, 10); !errors.Is(err, winput.ErrWindowMinimized) || !errors.Is(err, winput.ErrWindowNotVisible) {
+		t.Errorf("Click on a minimized window = %v, want ErrWindowMinimized wrapping ErrWindowNotVisible", err)
+	}
+	if err := ow.Restore(); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if !ow.IsVisible() {
+		t.Error("window not visible after Restore")
+	}
+	if err := ow.Restore(); err != nil {
+		t.Errorf("Restore of a restored window = %v, want nil", err)
+	}
+
+	if err := ow.Maximize(); err != nil || !ow.IsMaximized() {
+		t.Errorf("Maximize = %v, IsMaximized %v; want nil, true", err, ow.IsMaximized())
+	}
+	if err := ow.Restore(); err != nil || ow.IsMaximized() || ow.IsMinimized() {
+		t.Errorf("Restore from maximized = %v, IsMaximized %v; want nil, false", err, ow.IsMaximized())
+	}
+
+	if err := ow.Hide(); err != nil || window.IsVisible(ow.HWND) {
+		t.Errorf("Hide = %v, visible %v; want nil, false", err, window.IsVisible(ow.HWND))
+	}
+	if err := ow.Click(10, 10); !errors.Is(err, winput.ErrWindowHidden) || !errors.Is(err, winput.ErrWindowNotVisible) {
+		t.Errorf("Click on a hidden window = %v, want ErrWindowHidden wrapping ErrWindowNotVisible", err)
+	}
+	if err := ow.Show(); err != nil || !ow.IsVisible() {
+		t.Errorf("Show = %v, visible %v; want nil, true", err, ow.IsVisible())
+	}
+
+	ow.Close()
+	if err := ow.Restore(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("Restore after Close = %v, want ErrWindowGone", err)
+	}
+}
+
+func TestWindowBounds(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 100, Y: 100})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	rect := func() winput.Rect {
+		r, err := window.GetWindowRect(ow.HWND)
+		if err != nil {
+			t.Fatalf("GetWindowRect failed: %v", err)
+		}
+		return r
+	}
+
+	if err := ow.SetPosition(120, 130); err != nil {
+		t.Fatalf("SetPosition failed: %v", err)
+	}
+	if r := rect(); r.Left != 120 || r.Top != 130 || r.Right-r.Left != 400 || r.Bottom-r.Top != 300 {
+		t.Errorf("after SetPosition(120, 130): %+v, want origin (120,130) and size 400x300", r)
+	}
+	if err := ow.Resize(500, 350); err != nil {
+		t.Fatalf("Resize failed: %v", err)
+	}
+	if r := rect(); r.Left != 120 || r.Right-r.Left != 500 || r.Bottom-r.Top != 350 {
+		t.Errorf("after Resize(500, 350): %+v", r)
+	}
+	want := winput.Rect{Left: 150, Top: 160, Right: 450, Bottom: 400}
+	if err := ow.SetBounds(want); err != nil {
+		t.Fatalf("SetBounds failed: %v", err)
+	}
+	if r := rect(); r != want {
+		t.Errorf("after SetBounds: %+v, want %+v", r, want)
+	}
+
+	fg := window.GetForegroundWindow()
+	for _, topmost := range []bool{true, false} {
+		if err := ow.SetTopMost(topmost); err != nil || ow.IsTopMost() != topmost {
+			t.Errorf("SetTopMost(%v) = %v, IsTopMost %v", topmost, err, ow.IsTopMost())
+		}
+	}
+	if window.GetForegroundWindow() != fg {
+		t.Error("SetTopMost changed the foreground window")
+	}
+
+	if err := ow.ResizeClient(320, 200); err != nil {
+		t.Fatalf("ResizeClient failed: %v", err)
+	}
+	if w, h, _ := ow.ClientRect(); w != 320 || h != 200 {
+		t.Errorf("client area after ResizeClient(320, 200) = %dx%d", w, h)
+	}
+	if r := rect(); r.Left != 150 || r.Top != 160 {
+		t.Errorf("ResizeClient moved the window: %+v", r)
+	}
+}
+
+func TestWindowStyle(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{}) // tool window, WS_EX_NOACTIVATE
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	style, err := ow.Style()
+	if err != nil || style&0x00C00000 != 0x00C00000 { // WS_CAPTION
+		t.Errorf("Style = %#x, %v; want WS_CAPTION set", style, err)
+	}
+	ex, err := ow.ExStyle()
+	if err != nil || ex&window.WS_EX_NOACTIVATE == 0 || ex&window.WS_EX_TOOLWINDOW == 0 {
+		t.Errorf("ExStyle = %#x, %v; want WS_EX_NOACTIVATE|WS_EX_TOOLWINDOW set", ex, err)
+	}
+	if ow.IsDisabled() || ow.IsLayered() {
+		t.Errorf("IsDisabled = %v, IsLayered = %v; want false, false", ow.IsDisabled(), ow.IsLayered())
+	}
+
+	if err := ow.SetOpacity(128); err != nil || !ow.IsLayered() {
+		t.Errorf("SetOpacity(128) = %v, IsLayered %v; want nil, true", err, ow.IsLayered())
+	}
+	if err := ow.SetOpacity(255); err != nil || ow.IsLayered() {
+		t.Errorf("SetOpacity(255) = %v, IsLayered %v; want nil, false", err, ow.IsLayered())
+	}
+
+	ow.Close()
+	if _, err := ow.Style(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("Style after Close = %v, want ErrWindowGone", err)
+	}
+	if err := ow.SetOpacity(128); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("SetOpacity after Close = %v, want ErrWindowGone", err)
+	}
+}
+
+func TestWindowClose(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	if err := ow.Window.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for ow.IsValid() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := ow.Window.Close(); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("Close of a closed window = %v, want ErrWindowGone", err)
+	}
+	if err := ow.Press(winput.KeyA); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("Press after Close = %v, want ErrWindowGone", err)
+	}
+	if err := ow.ForceClose(time.Second); !errors.Is(err, winput.ErrWindowGone) {
+		t.Errorf("ForceClose of a closed window = %v, want ErrWindowGone", err)
+	}
+
+	t.Run("ForceClose", func(t *testing.T) {
+		w, cmd := setupTestApp(t)
+		defer cleanupTestApp(cmd)
+		// Unsaved text may raise a "save changes?" prompt, which ForceClose must get past.
+		if edit, err := findNotepadTextControl(w); err == nil {
+			edit.Type("unsaved")
+		}
+		if err := w.ForceClose(2 * time.Second); err != nil {
+			t.Fatalf("ForceClose failed: %v", err)
+		}
+		if w.IsValid() {
+			t.Error("window still exists after ForceClose")
+		}
+	})
+}
+
+func TestWatch(t *testing.T) {
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	events, stop := ow.Watch(20 * time.Millisecond)
+	defer stop()
+	next := func(want winput.WindowEventKind) winput.WindowEvent {
+		t.Helper()
+		select {
+		case ev, ok := <-events:
+			if !ok {
+				t.Fatalf("channel closed, want %v", want)
+			}
+			if ev.Kind != want {
+				t.Fatalf("got %v event, want %v", ev.Kind, want)
+			}
+			return ev
+		case <-time.After(2 * time.Second):
+			t.Fatalf("no %v event", want)
+		}
+		return winput.WindowEvent{}
+	}
+
+	if err := window.SetText(ow.HWND, "renamed", 1000); err != nil {
+		t.Fatalf("SetText failed: %v", err)
+	}
+	if ev := next(winput.WindowTitleChanged); ev.Title != "renamed" {
+		t.Errorf("title = %q, want %q", ev.Title, "renamed")
+	}
+	if err := ow.Minimize(); err != nil {
+		t.Fatalf("Minimize failed: %v", err)
+	}
+	next(winput.WindowMinimized)
+	if err := ow.Restore(); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	next(winput.WindowRestored)
+
+	t.Run("Stop", func(t *testing.T) {
+		events, stop := ow.Watch(time.Hour)
+		stop()
+		stop()
+		select {
+		case _, ok := <-events:
+			if ok {
+				t.Error("got an event after stop")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("channel not closed after stop")
+		}
+	})
+
+	closed := make(chan struct{})
+	defer ow.OnClosed(func() { close(closed) })()
+	ow.Close()
+	next(winput.WindowClosed)
+	if _, ok := <-events; ok {
+		t.Error("channel still open after WindowClosed")
+	}
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Error("OnClosed callback not called")
+	}
+}
+
+func TestSelfTarget(t *testing.T) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	hwnd, err := window.CreateMessageWindow() // owned by this thread
+	if err != nil {
+		t.Fatalf("CreateMessageWindow failed: %v", err)
+	}
+	defer window.DestroyWindow(hwnd)
+	w := &winput.Window{HWND: hwnd}
+
+	if !w.IsOwnProcess() {
+		t.Error("IsOwnProcess() = false for a window of this process")
+	}
+	if err := w.ReplaceText("x"); !errors.Is(err, winput.ErrSelfTarget) {
+		t.Errorf("ReplaceText on own thread = %v, want ErrSelfTarget", err)
+	}
+	if _, err := w.Text(); !errors.Is(err, winput.ErrSelfTarget) {
+		t.Errorf("Text on own thread = %v, want ErrSelfTarget", err)
+	}
+
+	winput.SetAllowSelfTarget(true)
+	defer winput.SetAllowSelfTarget(false)
+	if err := w.ReplaceText("x"); err != nil {
+		t.Errorf("ReplaceText with SetAllowSelfTarget = %v", err)
+	}
+	if text, err := w.Text(); err != nil || text != "x" {
+		t.Errorf("Text with SetAllowSelfTarget = %q, %v; want \"x\"", text, err)
+	}
+}
+
+func TestOpenFileDialog(t *testing.T) {
+	w, cmd := setupTestApp(t)
+	defer cleanupTestApp(cmd)
+
+	name := fmt.Sprintf("winput_open_%d.txt", time.Now().UnixNano())
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte("opened by winput"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := winput.OpenFileDialog(w, path, 5*time.Second)
+	var fde *winput.FileDialogError
+	if errors.As(err, &fde) && fde.Stage == winput.FileDialogWait {
+		t.Skipf("Open dialog did not appear (Ctrl+O not delivered?): %v", err)
+	}
+	if err != nil {
+		t.Fatalf("OpenFileDialog failed: %v", err)
+	}
+
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		title, _ := w.Title()
+		if strings.Contains(title, strings.TrimSuffix(name, ".txt")) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Notepad title %q does not name the opened file %q", title, name)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+func TestListWindows(t *testing.T) {
+	visible, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, Title: "winput List Test", X: 120, Y: 80})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer visible.Close()
+	tool, err := winput.NewTestWindow(winput.TestWindowOptions{Title: "winput List Test (tool)"})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer tool.Close()
+
+	find := func(infos []winput.WindowInfo, hwnd uintptr) *winput.WindowInfo {
+		for i := range infos {
+			if infos[i].HWND == hwnd {
+				return &infos[i]
+			}
+		}
+		return nil
+	}
+
+	infos, err := winput.ListWindows()
+	if err != nil {
+		t.Fatalf("ListWindows failed: %v", err)
+	}
+	info := find(infos, visible.HWND)
+	if info == nil {
+		t.Fatal("visible test window not listed")
+	}
+	if info.Title != "winput List Test" || info.ClassName != "winput_test_window" || !info.Visible {
+		t.Errorf("unexpected info: %+v", *info)
+	}
+	if info.PID != uint32(os.Getpid()) || !strings.HasSuffix(strings.ToLower(info.ProcessName), ".exe") {
+		t.Errorf("PID/ProcessName = %d/%q, want %d/*.exe", info.PID, info.ProcessName, os.Getpid())
+	}
+	if info.Rect.Left != 120 || info.Rect.Top != 80 {
+		t.Errorf("Rect = %+v, want origin (120,80)", info.Rect)
+	}
+	if find(infos, tool.HWND) != nil {
+		t.Error("tool window listed without IncludeHidden")
+	}
+
+	all, err := winput.ListWindows(winput.IncludeHidden())
+	if err != nil {
+		t.Fatalf("ListWindows(IncludeHidden) failed: %v", err)
+	}
+	if find(all, tool.HWND) == nil {
+		t.Error("tool window not listed with IncludeHidden")
+	}
+}
+
+func TestWorkAreaGuard(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+	monitors, err := screen.Monitors()
+	if err != nil {
+		t.Fatalf("Monitors failed: %v", err)
+	}
+	var m screen.Monitor
+	for _, m = range monitors {
+		if m.Primary {
+			break
+		}
+	}
+	if m.WorkArea == m.Bounds {
+		t.Skip("primary monitor has no taskbar area")
+	}
+
+	// A point in the bounds but outside the work area lies on the taskbar.
+	p := m.WorkCenter()
+	switch {
+	case m.WorkArea.Bottom < m.Bounds.Bottom:
+		p.Y = m.Bounds.Bottom - 1
+	case m.WorkArea.Top > m.Bounds.Top:
+		p.Y = m.Bounds.Top
+	case m.WorkArea.Left > m.Bounds.Left:
+		p.X = m.Bounds.Left
+	default:
+		p.X = m.Bounds.Right - 1
+	}
+
+	winput.SetWorkAreaGuard(true)
+	defer winput.SetWorkAreaGuard(false)
+
+	err = winput.MoveMouseTo(p.X, p.Y)
+	var oe *winput.OutsideWorkAreaError
+	if !errors.As(err, &oe) || !errors.Is(err, winput.ErrOutsideWorkArea) {
+		t.Fatalf("MoveMouseTo(taskbar) = %v, want *OutsideWorkAreaError", err)
+	}
+	if !m.IsInWorkArea(screen.Point{X: oe.ActualX, Y: oe.ActualY}) {
+		t.Errorf("clamped target (%d,%d) is outside the work area %+v", oe.ActualX, oe.ActualY, m.WorkArea)
+	}
+	if x, y, _ := winput.GetCursorPos(); x != oe.ActualX || y != oe.ActualY {
+		t.Errorf("cursor at (%d,%d), want (%d,%d)", x, y, oe.ActualX, oe.ActualY)
+	}
+
+	c := m.WorkCenter()
+	if err := winput.MoveMouseTo(c.X, c.Y); err != nil {
+		t.Errorf("MoveMouseTo(work center) = %v", err)
+	}
+}
+
 func TestMouseInput(t *testing.T) {
 	// Test Message Backend (Default)
 	winput.SetBackend(winput.BackendMessage)
@@ -181,6 +1225,42 @@ func TestMouseInput(t *testing.T) {
 		}
 		t.Log("Global double click executed")
 	})
+
+	t.Run("GlobalMouseDownUp", func(t *testing.T) {
+		if err := winput.MouseDownAt(240, 240, winput.MouseRight); err != nil {
+			t.Fatalf("MouseDownAt failed: %v", err)
+		}
+		if err := winput.MouseUpAt(240, 240, winput.MouseRight); err != nil {
+			t.Fatalf("MouseUpAt failed: %v", err)
+		}
+		if x, y, _ := winput.GetCursorPos(); x != 240 || y != 240 {
+			t.Errorf("cursor after MouseUpAt = (%d, %d), want (240, 240)", x, y)
+		}
+	})
+
+	t.Run("GlobalScroll", func(t *testing.T) {
+		if err := winput.ScrollAt(250, 250, -120); err != nil {
+			t.Fatalf("ScrollAt failed: %v", err)
+		}
+		if x, y, _ := winput.GetCursorPos(); x != 250 || y != 250 {
+			t.Errorf("cursor after ScrollAt = (%d, %d), want (250, 250)", x, y)
+		}
+		if err := winput.ScrollAt(250, 250, 50); err == nil {
+			t.Error("ScrollAt with a delta that is not a multiple of 120 succeeded")
+		}
+		if err := winput.ScrollHAt(250, 250, 120); err != nil {
+			t.Errorf("ScrollHAt failed: %v", err)
+		}
+	})
+
+	t.Run("GlobalDrag", func(t *testing.T) {
+		if err := winput.DragMouse(230, 230, 260, 240); err != nil {
+			t.Fatalf("DragMouse failed: %v", err)
+		}
+		if x, y, _ := winput.GetCursorPos(); x != 260 || y != 240 {
+			t.Errorf("cursor after DragMouse = (%d, %d), want (260, 240)", x, y)
+		}
+	})
 }
 
 // -----------------------------------------------------------------------------
@@ -202,7 +1282,7 @@ func TestKeyboardInput(t *testing.T) {
 		if err := winput.Type(text); err != nil {
 			// In CI/Headless environments, SendInput (Global Type) often fails.
 			// This is not a library bug but an environment limitation.
-			if err.Error() == "SendInput self-test failed; unsupported in this context" {
+			if errors.Is(err, winput.ErrNoInteractiveSession) {
 				t.Skipf("Skipping Global Type test: %v", err)
 			}
 			t.Errorf("Type failed: %v", err)
@@ -277,6 +1357,123 @@ func TestWindowTextRead(t *testing.T) {
 	})
 }
 
+func TestRichEditFastPath(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+
+	w, cmd := setupTestApp(t)
+	defer cleanupTestApp(cmd)
+
+	textControl, err := findNotepadTextControl(w)
+	if err != nil {
+		t.Skipf("Skipping RichEdit test: %v", err)
+	}
+	if !window.IsRichEdit(textControl.HWND) {
+		t.Skipf("Skipping RichEdit test: text control is %q", window.GetClassName(textControl.HWND))
+	}
+
+	if err := textControl.ReplaceText(""); err != nil {
+		t.Fatalf("ReplaceText failed: %v", err)
+	}
+
+	// ~10KB; WM_CHAR typing would take minutes.
+	chunk := strings.Repeat("0123456789", 1000)
+	start := time.Now()
+	if err := textControl.Type(chunk); err != nil {
+		t.Fatalf("Type failed: %v", err)
+	}
+	elapsed := time.Since(start)
+	t.Logf("Typed %d chars in %v", len(chunk), elapsed)
+	if elapsed > 2*time.Second {
+		t.Errorf("fast path not taken: typing took %v", elapsed)
+	}
+
+	got, err := textControl.Text()
+	if err != nil {
+		t.Fatalf("Text failed: %v", err)
+	}
+	if got != chunk {
+		t.Errorf("unexpected text: got %d chars, want %d", len(got), len(chunk))
+	}
+
+	selStart, selEnd, err := textControl.Selection()
+	if err != nil {
+		t.Fatalf("Selection failed: %v", err)
+	}
+	if selStart != len(chunk) || selEnd != len(chunk) {
+		t.Errorf("caret at %d-%d, want %d", selStart, selEnd, len(chunk))
+	}
+}
+
+func TestTypeCaretPlacement(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+
+	w, cmd := setupTestApp(t)
+	defer cleanupTestApp(cmd)
+
+	textControl, err := findNotepadTextControl(w)
+	if err != nil {
+		t.Skipf("Skipping caret test: %v", err)
+	}
+	if err := textControl.ReplaceText("middle"); err != nil {
+		t.Fatalf("ReplaceText failed: %v", err)
+	}
+	if err := textControl.TypeWithOptions("[", winput.TypeOptions{Caret: winput.CaretStart}); err != nil {
+		t.Fatalf("Type at start failed: %v", err)
+	}
+	if err := textControl.TypeWithOptions("]", winput.TypeOptions{Caret: winput.CaretEnd}); err != nil {
+		t.Fatalf("Type at end failed: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got, err := textControl.Text(); err != nil || got != "[middle]" {
+		t.Errorf("Text = %q, %v; want %q", got, err, "[middle]")
+	}
+}
+
+func TestTypeBidi(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+
+	w, cmd := setupTestApp(t)
+	defer cleanupTestApp(cmd)
+
+	textControl, err := findNotepadTextControl(w)
+	if err != nil {
+		t.Skipf("Skipping bidi test: %v", err)
+	}
+
+	fixtures := []struct {
+		name, input, want string
+	}{
+		{"Hebrew", "שלום עולם", "שלום עולם"},
+		{"ArabicDiacritics", "مَرْحَبًا", "مَرْحَبًا"},
+		{"MixedDirection", "abc שלום 123 مرحبا xyz", "abc שלום 123 مرحبا xyz"},
+		{"DirectionalMarks", "\u200fשלום\u200e abc", "\u200fשלום\u200e abc"},
+		{"DecomposedLatin", "Cafe\u0301 nai\u0308ve", "Café naïve"},
+	}
+
+	for _, clipboard := range []bool{false, true} {
+		textControl.SetBidiClipboard(clipboard)
+		for _, f := range fixtures {
+			t.Run(fmt.Sprintf("%s/clipboard=%v", f.name, clipboard), func(t *testing.T) {
+				if err := textControl.ReplaceText(""); err != nil {
+					t.Fatalf("ReplaceText failed: %v", err)
+				}
+				if err := textControl.TypeBidi(f.input); err != nil {
+					t.Fatalf("TypeBidi failed: %v", err)
+				}
+				time.Sleep(300 * time.Millisecond)
+				got, err := textControl.Text()
+				if err != nil {
+					t.Fatalf("Text failed: %v", err)
+				}
+				if got != f.want {
+					t.Errorf("round-trip mismatch: got %+q, want %+q", got, f.want)
+				}
+			})
+		}
+	}
+	textControl.SetBidiClipboard(false)
+}
+
 // -----------------------------------------------------------------------------
 // 4. HID Backend Tests (Requires Driver)
 // -----------------------------------------------------------------------------
@@ -313,6 +1510,20 @@ func TestBackendHID(t *testing.T) {
 		}
 	})
 
+	t.Run("HID_MoveExact", func(t *testing.T) {
+		// The correction phase must land on target, whatever the trajectory drift; a 1px
+		// residue is accepted without an error.
+		targets := [][2]int32{{300, 300}, {310, 295}, {800, 600}, {120, 700}}
+		for _, p := range targets {
+			if err := winput.MoveMouseTo(p[0], p[1]); err != nil {
+				t.Fatalf("MoveMouseTo(%d,%d) failed: %v", p[0], p[1], err)
+			}
+			if x, y, _ := winput.GetCursorPos(); x-p[0] > 1 || p[0]-x > 1 || y-p[1] > 1 || p[1]-y > 1 {
+				t.Errorf("MoveMouseTo(%d,%d) ended at %d,%d", p[0], p[1], x, y)
+			}
+		}
+	})
+
 	t.Run("HID_Type", func(t *testing.T) {
 		winput.ClickMouseAt(500, 500)
 		if err := winput.Type("hid test"); err != nil {
@@ -320,12 +1531,93 @@ func TestBackendHID(t *testing.T) {
 		}
 	})
 
-	t.Run("HID_DBL_CLICK", func(t *testing.T) {
-		time.Sleep(time.Second)
-		e := winput.DoubleClickMouseAt(40, 40)
-		if e != nil {
-			t.Error("HID double click error")
+	t.Run("HID_DBL_CLICK", func(t *testing.T) {
+		time.Sleep(time.Second)
+		e := winput.DoubleClickMouseAt(40, 40)
+		if e != nil {
+			t.Error("HID double click error")
+		}
+	})
+
+	t.Run("HID_DoubleClickRecognized", func(t *testing.T) {
+		ow, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 100, Y: 100})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer ow.Close()
+
+		// A slow requested gap must still be clamped into the system double-click time.
+		ow.SetTiming(winput.Timing{DoubleClickGap: 2 * time.Second})
+		defer ow.ClearTiming()
+
+		const rounds = 3
+		for i := 0; i < rounds; i++ {
+			if err := ow.DoubleClick(50, 50); err != nil {
+				t.Fatalf("DoubleClick failed: %v", err)
+			}
+			time.Sleep(time.Second) // let the next pair start a fresh double click
+		}
+
+		dbl := 0
+		for {
+			m, err := ow.NextMessage(200 * time.Millisecond)
+			if err != nil {
+				break
+			}
+			if m.Msg == 0x0203 { // WM_LBUTTONDBLCLK
+				dbl++
+			}
+		}
+		if dbl != rounds {
+			t.Errorf("received %d WM_LBUTTONDBLCLK, want %d", dbl, rounds)
+		}
+	})
+}
+
+func BenchmarkHIDMove(b *testing.B) {
+	if !*useHID {
+		b.Skip("Skipping HID benchmark. Use -hid flag to enable (requires admin & driver).")
+	}
+	winput.SetBackend(winput.BackendHID)
+	defer winput.SetBackend(winput.BackendMessage)
+
+	for i := 0; i < b.N; i++ {
+		x := int32(200 + (i%2)*600)
+		if err := winput.MoveMouseTo(x, 400); err != nil {
+			b.Fatalf("MoveMouseTo failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkHIDClickBurst compares window clicks with per-call coordinate conversion
+// against a burst snapshot. Run with: go test -run x -bench HIDClick -hid
+func BenchmarkHIDClickBurst(b *testing.B) {
+	if !*useHID {
+		b.Skip("Skipping HID benchmark. Use -hid flag to enable (requires admin & driver).")
+	}
+	winput.SetBackend(winput.BackendHID)
+	defer winput.SetBackend(winput.BackendMessage)
+
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 100, Y: 100})
+	if err != nil {
+		b.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	run := func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := ow.Click(int32(20+i%2*100), 50); err != nil {
+				b.Fatalf("Click failed: %v", err)
+			}
+		}
+	}
+	b.Run("PerCall", run)
+	b.Run("Burst", func(b *testing.B) {
+		if err := ow.BeginBurst(); err != nil {
+			b.Fatalf("BeginBurst failed: %v", err)
 		}
+		defer ow.EndBurst()
+		run(b)
 	})
 }
 
@@ -343,6 +1635,53 @@ func TestMultiMonitorSupport(t *testing.T) {
 		t.Fatalf("Failed to enumerate monitors: %v", err)
 	}
 
+	// EnablePerMonitorDPI already ran in TestMain; repeating it must be harmless.
+	t.Run("DPIIdempotent", func(t *testing.T) {
+		level, err := winput.DPIAwareness()
+		if err != nil {
+			t.Fatalf("DPIAwareness: %v", err)
+		}
+		err = winput.EnablePerMonitorDPI()
+		var already *winput.DPIAlreadySetError
+		switch {
+		case err == nil:
+		case errors.As(err, &already):
+			if already.Level != level || !errors.Is(err, winput.ErrDPIAlreadySet) {
+				t.Errorf("DPIAlreadySetError = %v, DPIAwareness = %s", err, level)
+			}
+		default:
+			t.Errorf("second EnablePerMonitorDPI: %v", err)
+		}
+	})
+
+	t.Run("WindowMonitor", func(t *testing.T) {
+		for i, m := range monitors {
+			c := m.WorkCenter()
+			ow, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: c.X - 100, Y: c.Y - 100, Width: 200, Height: 200})
+			if err != nil {
+				t.Fatalf("NewTestWindow failed: %v", err)
+			}
+			got, err := ow.Monitor()
+			if i == 0 {
+				// Polling must not exhaust syscall callback slots (about 2000).
+				for j := 0; j < 2500 && err == nil; j++ {
+					_, err = ow.Monitor()
+				}
+			}
+			ow.Close()
+			if err != nil {
+				t.Fatalf("Monitor failed: %v", err)
+			}
+			if got.Handle != m.Handle || got.Bounds != m.Bounds {
+				t.Errorf("Monitor of a window centered on monitor %d = %+v, want %+v", i, got, m)
+			}
+		}
+		gone := &winput.Window{HWND: 0}
+		if _, err := gone.Monitor(); !errors.Is(err, winput.ErrWindowGone) {
+			t.Errorf("Monitor of an invalid window = %v, want ErrWindowGone", err)
+		}
+	})
+
 	t.Logf("Detected %d monitor(s)", len(monitors))
 	for i, m := range monitors {
 		t.Logf("Monitor %d: Primary=%v, Bounds=%+v", i, m.Primary, m.Bounds)
@@ -461,6 +1800,55 @@ func TestScreenCapture(t *testing.T) {
 		}
 	})
 
+	t.Run("Window", func(t *testing.T) {
+		ow, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 100, Y: 100, Width: 300, Height: 200})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer ow.Close()
+		// Created later, so it covers ow; Capture must not be affected.
+		cover, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 120, Y: 120})
+		if err != nil {
+			t.Fatalf("NewTestWindow failed: %v", err)
+		}
+		defer cover.Close()
+
+		img, err := ow.Capture()
+		if err != nil {
+			t.Fatalf("Capture failed: %v", err)
+		}
+		b := img.Bounds()
+		if b.Dx() != 300 || b.Dy() != 200 {
+			t.Errorf("image is %dx%d, want the 300x200 window rect", b.Dx(), b.Dy())
+		}
+		cw, ch, _ := ow.ClientRect()
+		if b.Min.X > 0 || b.Min.Y >= 0 || !image.Pt(int(cw)-1, int(ch)-1).In(b) {
+			t.Errorf("bounds %v do not place the %dx%d client area at (0,0)", b, cw, ch)
+		}
+
+		part, err := ow.CaptureClient(10, 20, 50, 40)
+		if err != nil || part.Bounds() != image.Rect(10, 20, 60, 60) {
+			t.Errorf("CaptureClient = %v, %v; want bounds (10,20)-(60,60)", part.Bounds(), err)
+		} else if part.At(30, 30) != img.At(30, 30) {
+			t.Errorf("CaptureClient pixel %v differs from Capture pixel %v", part.At(30, 30), img.At(30, 30))
+		}
+		part, err = ow.CaptureClient(cw-10, -5, 100, 15)
+		if err != nil || part.Bounds() != image.Rect(int(cw)-10, 0, int(cw), 10) {
+			t.Errorf("CaptureClient past the edge = %v, %v; want it clipped to the client area", part.Bounds(), err)
+		}
+		if _, err := ow.CaptureClient(0, 0, 0, 10); err == nil {
+			t.Error("CaptureClient of an empty region succeeded")
+		}
+		if _, err := ow.CaptureClient(cw, 0, 10, 10); err == nil {
+			t.Error("CaptureClient outside the client area succeeded")
+		}
+
+		ow.Minimize()
+		if _, err := ow.Capture(); !errors.Is(err, winput.ErrWindowMinimized) {
+			t.Errorf("Capture of a minimized window = %v, want ErrWindowMinimized", err)
+		}
+	})
+
 	t.Run("CaptureWithOptions", func(t *testing.T) {
 		opts := screen.CaptureOptions{
 			PreserveAlpha: true,
@@ -485,3 +1873,574 @@ func TestScreenCapture(t *testing.T) {
 		}
 	})
 }
+
+// TestOwnedWindow verifies the exact messages the Message backend posts, using a window
+// owned by the test itself (no Notepad required).
+func TestOwnedWindow(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	// waitFor skips unrelated messages (e.g. WM_MOUSEMOVE from the real cursor).
+	waitFor := func(msg uint32) winput.Message {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			m, err := ow.NextMessage(time.Until(deadline))
+			if err != nil {
+				break
+			}
+			if m.Msg == msg {
+				return m
+			}
+		}
+		t.Fatalf("message 0x%X not received", msg)
+		return winput.Message{}
+	}
+	// waitFor2 is waitFor accepting either of two messages.
+	waitFor2 := func(a, b uint32) winput.Message {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			m, err := ow.NextMessage(time.Until(deadline))
+			if err != nil {
+				break
+			}
+			if m.Msg == a || m.Msg == b {
+				return m
+			}
+		}
+		t.Fatalf("neither message 0x%X nor 0x%X received", a, b)
+		return winput.Message{}
+	}
+
+	t.Run("Click", func(t *testing.T) {
+		if err := ow.Click(12, 34); err != nil {
+			t.Fatalf("Click failed: %v", err)
+		}
+		down := waitFor(0x0201) // WM_LBUTTONDOWN
+		if x, y := int16(down.LParam), int16(down.LParam>>16); x != 12 || y != 34 {
+			t.Errorf("WM_LBUTTONDOWN at (%d,%d), want (12,34)", x, y)
+		}
+		waitFor(0x0202) // WM_LBUTTONUP
+	})
+
+	t.Run("Type", func(t *testing.T) {
+		if err := ow.Type("a"); err != nil {
+			t.Fatalf("Type failed: %v", err)
+		}
+		if m := waitFor(0x0102); m.WParam != 'a' { // WM_CHAR
+			t.Errorf("WM_CHAR %q, want 'a'", rune(m.WParam))
+		}
+	})
+
+	t.Run("TypeRegisteredRune", func(t *testing.T) {
+		ow.RegisterRune('\n', winput.KeyDef{Code: winput.KeyEnter})
+		defer ow.ClearRunes()
+		if err := ow.Type("\n"); err != nil {
+			t.Fatalf("Type failed: %v", err)
+		}
+		if m := waitFor(0x0100); winput.Key(m.LParam>>16&0xFF) != winput.KeyEnter { // WM_KEYDOWN
+			t.Errorf("WM_KEYDOWN scan code 0x%X, want KeyEnter", m.LParam>>16&0xFF)
+		}
+	})
+
+	t.Run("TypeClickFirst", func(t *testing.T) {
+		opts := winput.TypeOptions{ClickFirst: &winput.Point{X: 5, Y: 6}, Caret: winput.CaretEnd}
+		if err := ow.TypeWithOptions("b", opts); err != nil {
+			t.Fatalf("TypeWithOptions failed: %v", err)
+		}
+		if down := waitFor(0x0201); int16(down.LParam) != 5 || int16(down.LParam>>16) != 6 { // WM_LBUTTONDOWN
+			t.Errorf("WM_LBUTTONDOWN LPARAM 0x%X, want (5,6)", down.LParam)
+		}
+		// Not an edit control: the caret is moved with Ctrl+End.
+		if m := waitFor(0x0100); m.WParam != 0x11 { // WM_KEYDOWN VK_CONTROL
+			t.Errorf("first WM_KEYDOWN VK 0x%X, want VK_CONTROL", m.WParam)
+		}
+		if m := waitFor(0x0100); m.WParam != 0x23 { // VK_END
+			t.Errorf("second WM_KEYDOWN VK 0x%X, want VK_END", m.WParam)
+		}
+		if m := waitFor(0x0102); m.WParam != 'b' { // WM_CHAR
+			t.Errorf("WM_CHAR %q, want 'b'", rune(m.WParam))
+		}
+	})
+
+	t.Run("StrictMode", func(t *testing.T) {
+		winput.SetStrictMode(true)
+		defer winput.SetStrictMode(false)
+
+		if pumping, err := ow.IsPumping(); err != nil || !pumping {
+			t.Fatalf("IsPumping = %v, %v; want true", pumping, err)
+		}
+		if err := ow.Click(1, 1); err != nil {
+			t.Errorf("Click in strict mode failed: %v", err)
+		}
+	})
+
+	t.Run("StrictReadyCheck", func(t *testing.T) {
+		winput.SetStrictReadyCheck(true)
+		defer winput.SetStrictReadyCheck(false)
+
+		if !ow.IsResponding() {
+			t.Fatal("IsResponding = false for a pumping window")
+		}
+		if err := ow.Click(1, 1); err != nil {
+			t.Errorf("Click with the strict ready check failed: %v", err)
+		}
+		if testing.Short() {
+			t.Skip("a window takes 5s to count as hung")
+		}
+
+		// A visible top-level window whose thread never retrieves messages.
+		created := make(chan uintptr)
+		release := make(chan struct{})
+		go func() {
+			runtime.LockOSThread()
+			defer runtime.UnlockOSThread()
+			class, _ := syscall.UTF16PtrFromString("Static")
+			hwnd, _, _ := window.ProcCreateWindowExW.Call(0, uintptr(unsafe.Pointer(class)), 0,
+				0x10CF0000, 0, 0, 200, 100, 0, 0, 0, 0) // WS_OVERLAPPEDWINDOW | WS_VISIBLE
+			created <- hwnd
+			<-release
+			window.DestroyWindow(hwnd)
+		}()
+		hung := &winput.Window{HWND: <-created}
+		defer close(release)
+		if hung.HWND == 0 {
+			t.Fatal("CreateWindowExW failed")
+		}
+		window.PostMessage(hung.HWND, 0x0400, 0, 0) // WM_USER: pending input nobody reads
+
+		deadline := time.Now().Add(8 * time.Second)
+		for hung.IsResponding() && time.Now().Before(deadline) {
+			time.Sleep(250 * time.Millisecond)
+		}
+		if hung.IsResponding() {
+			t.Skip("the system did not report the window as hung")
+		}
+		if err := hung.Click(1, 1); !errors.Is(err, winput.ErrWindowHung) {
+			t.Errorf("Click on a hung window = %v, want ErrWindowHung", err)
+		}
+	})
+
+	t.Run("ScrollH", func(t *testing.T) {
+		// Positive scrolls right, negative left.
+		for _, want := range []int16{240, -120} {
+			if err := ow.ScrollH(10, 10, int32(want)); err != nil {
+				t.Fatalf("ScrollH(%d) failed: %v", want, err)
+			}
+			m := waitFor(0x020E) // WM_MOUSEHWHEEL
+			if delta := int16(m.WParam >> 16); delta != want {
+				t.Errorf("WM_MOUSEHWHEEL delta=%d, want %d", delta, want)
+			}
+		}
+		if err := ow.ScrollH(10, 10, 60); err == nil {
+			t.Error("ScrollH with a delta that is not a multiple of 120 succeeded")
+		}
+	})
+
+	t.Run("Zoom", func(t *testing.T) {
+		if err := ow.Zoom(1); err != nil {
+			t.Fatalf("Zoom failed: %v", err)
+		}
+		m := waitFor(0x020A) // WM_MOUSEWHEEL
+		if keys, delta := uint16(m.WParam), int16(m.WParam>>16); keys != 0x0008 || delta != 120 {
+			t.Errorf("WM_MOUSEWHEEL keys=0x%X delta=%d, want MK_CONTROL, 120", keys, delta)
+		}
+	})
+
+	t.Run("Command", func(t *testing.T) {
+		if err := ow.Command(0x1234); err != nil {
+			t.Fatalf("Command failed: %v", err)
+		}
+		if m := waitFor(0x0111); m.WParam != 0x1234 || m.LParam != 0 { // WM_COMMAND
+			t.Errorf("WM_COMMAND wParam=0x%X lParam=0x%X, want 0x1234, 0", m.WParam, m.LParam)
+		}
+	})
+
+	t.Run("Drag", func(t *testing.T) {
+		if err := ow.DragWithOptions(10, 20, 110, 70, winput.DragOptions{Steps: 5, Button: winput.MouseRight}); err != nil {
+			t.Fatalf("Drag failed: %v", err)
+		}
+		down := waitFor(0x0204) // WM_RBUTTONDOWN
+		if x, y := int16(down.LParam), int16(down.LParam>>16); x != 10 || y != 20 {
+			t.Errorf("WM_RBUTTONDOWN at (%d,%d), want (10,20)", x, y)
+		}
+		var moves []winput.Message
+		for {
+			m := waitFor2(0x0200, 0x0205) // WM_MOUSEMOVE, WM_RBUTTONUP
+			if m.Msg == 0x0205 {
+				if x, y := int16(m.LParam), int16(m.LParam>>16); x != 110 || y != 70 {
+					t.Errorf("WM_RBUTTONUP at (%d,%d), want (110,70)", x, y)
+				}
+				break
+			}
+			if m.WParam&0x0002 != 0 { // MK_RBUTTON: ours, not the real cursor's
+				moves = append(moves, m)
+			}
+		}
+		if len(moves) != 5 {
+			t.Fatalf("got %d WM_MOUSEMOVE with MK_RBUTTON, want 5", len(moves))
+		}
+		if last := moves[4]; int16(last.LParam) != 110 || int16(last.LParam>>16) != 70 {
+			t.Errorf("last move at (%d,%d), want (110,70)", int16(last.LParam), int16(last.LParam>>16))
+		}
+	})
+
+	t.Run("Hover", func(t *testing.T) {
+		start := time.Now()
+		if err := ow.Hover(15, 25, 350*time.Millisecond); err != nil {
+			t.Fatalf("Hover failed: %v", err)
+		}
+		if elapsed := time.Since(start); elapsed < 350*time.Millisecond {
+			t.Errorf("Hover returned after %v, want at least 350ms", elapsed)
+		}
+		moves := 0
+		for {
+			m, err := ow.NextMessage(100 * time.Millisecond)
+			if err != nil {
+				break
+			}
+			if m.Msg == 0x0200 && int16(m.LParam) == 15 && int16(m.LParam>>16) == 25 {
+				moves++
+			}
+		}
+		if moves < 3 {
+			t.Errorf("got %d WM_MOUSEMOVE at (15,25) during the dwell, want at least 3", moves)
+		}
+	})
+
+	t.Run("ClickWithModifiers", func(t *testing.T) {
+		if err := ow.ClickWithModifiers(20, 30, winput.KeyCtrl, winput.KeyShift); err != nil {
+			t.Fatalf("ClickWithModifiers failed: %v", err)
+		}
+		waitFor(0x0100) // WM_KEYDOWN
+		down := waitFor(0x0201)
+		if keys := down.WParam & 0xFFFF; keys != 0x0001|0x0004|0x0008 {
+			t.Errorf("WM_LBUTTONDOWN WPARAM = 0x%X, want MK_LBUTTON|MK_SHIFT|MK_CONTROL", keys)
+		}
+		up := waitFor(0x0202)
+		if keys := up.WParam & 0xFFFF; keys != 0x0004|0x0008 {
+			t.Errorf("WM_LBUTTONUP WPARAM = 0x%X, want MK_SHIFT|MK_CONTROL", keys)
+		}
+		waitFor(0x0101) // WM_KEYUP
+		if err := ow.ClickWithModifiers(20, 30, winput.KeyA); !errors.Is(err, winput.ErrUnsupportedKey) {
+			t.Errorf("ClickWithModifiers(KeyA) = %v, want ErrUnsupportedKey", err)
+		}
+	})
+
+	t.Run("MultiClick", func(t *testing.T) {
+		if err := ow.DoubleClickRight(5, 6); err != nil {
+			t.Fatalf("DoubleClickRight failed: %v", err)
+		}
+		waitFor(0x0204) // WM_RBUTTONDOWN
+		waitFor(0x0206) // WM_RBUTTONDBLCLK
+		waitFor(0x0205) // WM_RBUTTONUP
+
+		if err := ow.MultiClick(winput.MouseLeft, 7, 8, 3); err != nil {
+			t.Fatalf("MultiClick failed: %v", err)
+		}
+		// Presses alternate between button down and double-click.
+		var presses []uint32
+		for len(presses) < 3 {
+			m := waitFor2(0x0201, 0x0203) // WM_LBUTTONDOWN, WM_LBUTTONDBLCLK
+			presses = append(presses, m.Msg)
+		}
+		if presses[0] != 0x0201 || presses[1] != 0x0203 || presses[2] != 0x0201 {
+			t.Errorf("triple click presses = %#x, want down, dblclk, down", presses)
+		}
+		if err := ow.MultiClick(winput.MouseLeft, 7, 8, 0); err == nil {
+			t.Error("MultiClick with count 0 succeeded")
+		}
+	})
+
+	t.Run("MouseDownUp", func(t *testing.T) {
+		if err := ow.MouseDown(winput.MouseX2, 30, 40); err != nil {
+			t.Fatalf("MouseDown failed: %v", err)
+		}
+		down := waitFor(0x020B) // WM_XBUTTONDOWN
+		if hi := down.WParam >> 16; hi != 2 {
+			t.Errorf("WM_XBUTTONDOWN XBUTTON = %d, want 2 (XBUTTON2)", hi)
+		}
+		if x, y := int16(down.LParam), int16(down.LParam>>16); x != 30 || y != 40 {
+			t.Errorf("WM_XBUTTONDOWN at (%d,%d), want (30,40)", x, y)
+		}
+		if err := ow.MouseUp(winput.MouseX2, 35, 45); err != nil {
+			t.Fatalf("MouseUp failed: %v", err)
+		}
+		up := waitFor(0x020C) // WM_XBUTTONUP
+		if x, y := int16(up.LParam), int16(up.LParam>>16); x != 35 || y != 45 {
+			t.Errorf("WM_XBUTTONUP at (%d,%d), want (35,45)", x, y)
+		}
+		if err := ow.ClickX(3, 4, 1); err != nil {
+			t.Fatalf("ClickX failed: %v", err)
+		}
+		if m := waitFor(0x020B); m.WParam>>16 != 1 { // XBUTTON1
+			t.Errorf("ClickX WM_XBUTTONDOWN XBUTTON = %d, want 1", m.WParam>>16)
+		}
+		waitFor(0x020C)
+		if err := ow.ClickX(3, 4, 3); err == nil {
+			t.Error("ClickX(3) succeeded")
+		}
+		if err := ow.MouseDown(winput.MouseButton(9), 0, 0); err == nil {
+			t.Error("MouseDown with an invalid button succeeded")
+		}
+	})
+
+	t.Run("WatchTitle", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		watch, err := ow.WatchTitle(ctx, 20*time.Millisecond)
+		if err != nil {
+			t.Fatalf("WatchTitle failed: %v", err)
+		}
+		if err := window.SetText(ow.HWND, "progress 43%", 1000); err != nil {
+			t.Fatalf("SetText failed: %v", err)
+		}
+		select {
+		case title := <-watch.C:
+			if title != "progress 43%" {
+				t.Errorf("got title %q", title)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("title change not reported")
+		}
+		if err := ow.WaitTitle("43%", time.Second); err != nil {
+			t.Errorf("WaitTitle failed: %v", err)
+		}
+		if err := ow.WaitTitle("100%", 100*time.Millisecond); !errors.Is(err, winput.ErrTimeout) {
+			t.Errorf("WaitTitle = %v, want ErrTimeout", err)
+		}
+		cancel()
+		for range watch.C {
+		}
+		if !errors.Is(watch.Err(), context.Canceled) {
+			t.Errorf("watch.Err() = %v, want context.Canceled", watch.Err())
+		}
+	})
+
+	t.Run("CoordinateRecorder", func(t *testing.T) {
+		var recs []winput.CoordinateRecord
+		winput.SetCoordinateRecorder(func(r winput.CoordinateRecord) { recs = append(recs, r) })
+		defer winput.SetCoordinateRecorder(nil)
+
+		if err := ow.Click(7, 8); err != nil {
+			t.Fatalf("Click failed: %v", err)
+		}
+		waitFor(0x0202) // WM_LBUTTONUP
+		if len(recs) != 1 || recs[0].Action != "Click" || recs[0].X != 7 || recs[0].Y != 8 {
+			t.Fatalf("unexpected records: %+v", recs)
+		}
+		if _, _, why := winput.ReplayCoordinates(recs[0]); !strings.HasPrefix(why, "unchanged") {
+			t.Errorf("expected an unchanged replay, got %q", why)
+		}
+
+		r := recs[0].WindowRect
+		if err := ow.SetPosImmediate(r.Left+50, r.Top, r.Right-r.Left, r.Bottom-r.Top, time.Second); err != nil {
+			t.Fatalf("SetPosImmediate failed: %v", err)
+		}
+		x, _, why := winput.ReplayCoordinates(recs[0])
+		if x != recs[0].ScreenX+50 || !strings.Contains(why, "+50") {
+			t.Errorf("replay after move: x=%d (recorded %d), %q", x, recs[0].ScreenX, why)
+		}
+
+		recs = nil
+		if err := ow.Scroll(3, 4, 120); err != nil {
+			t.Fatalf("Scroll failed: %v", err)
+		}
+		if len(recs) != 1 || recs[0].Action != "Scroll" || recs[0].X != 3 || recs[0].Y != 4 {
+			t.Errorf("unexpected records for Scroll: %+v", recs)
+		}
+	})
+
+	t.Run("ReadyWait", func(t *testing.T) {
+		const swHide, swShowNoActivate = 0, 4
+		window.ProcShowWindow.Call(ow.HWND, swHide)
+		defer window.ProcShowWindow.Call(ow.HWND, swShowNoActivate)
+
+		if err := ow.Click(1, 1); !errors.Is(err, winput.ErrWindowNotVisible) {
+			t.Fatalf("Click on hidden window = %v, want ErrWindowNotVisible", err)
+		}
+
+		var retries atomic.Int32
+		winput.SetReadyRetryHook(func(winput.ReadyRetry) { retries.Add(1) })
+		defer winput.SetReadyRetryHook(nil)
+		ow.SetReadyWait(2*time.Second, 20*time.Millisecond)
+		defer ow.SetReadyWait(0, 0)
+
+		time.AfterFunc(150*time.Millisecond, func() { window.ProcShowWindow.Call(ow.HWND, swShowNoActivate) })
+		if err := ow.Click(3, 4); err != nil {
+			t.Fatalf("Click with ReadyWait failed: %v", err)
+		}
+		waitFor(0x0202) // WM_LBUTTONUP
+		if retries.Load() == 0 {
+			t.Error("no ReadyRetry events reported")
+		}
+	})
+
+	t.Run("BurstInvalidation", func(t *testing.T) {
+		// A long TTL so that the TTL cannot be what refreshes the snapshot.
+		if err := ow.BeginBurstWithOptions(winput.BurstOptions{TTL: time.Minute}); err != nil {
+			t.Fatalf("BeginBurst failed: %v", err)
+		}
+		defer ow.EndBurst()
+
+		for _, pos := range [][2]int32{{-9000, -9000}, {-8000, -8500}} {
+			if err := ow.SetPosImmediate(pos[0], pos[1], 400, 300, time.Second); err != nil {
+				t.Fatalf("SetPosImmediate failed: %v", err)
+			}
+			// The very first conversion after the move must be right: the location-change
+			// hook is asynchronous and may not have run yet.
+			wantX, wantY, _ := window.ClientToScreen(ow.HWND, 5, 5)
+			x, y, err := ow.ClientToScreen(5, 5)
+			if err != nil {
+				t.Fatalf("ClientToScreen failed: %v", err)
+			}
+			if x != wantX || y != wantY {
+				t.Errorf("stale burst snapshot after move to %v: got (%d,%d), want (%d,%d)", pos, x, y, wantX, wantY)
+			}
+		}
+	})
+
+	if err := ow.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if ow.IsValid() {
+		t.Error("window still valid after Close")
+	}
+}
+
+func TestKeyboardLayouts(t *testing.T) {
+	layouts, err := winput.KeyboardLayouts()
+	if err != nil {
+		t.Fatalf("KeyboardLayouts failed: %v", err)
+	}
+	if len(layouts) == 0 {
+		t.Fatal("no keyboard layouts installed")
+	}
+	for _, l := range layouts {
+		t.Logf("HKL=%#x KLID=%s Locale=%s Name=%q", l.HKL, l.KLID, l.Locale, l.Name)
+		if len(l.KLID) != 8 {
+			t.Errorf("unexpected KLID %q for HKL %#x", l.KLID, l.HKL)
+		}
+	}
+
+	ran := false
+	if err := winput.WithLayout(layouts[0].KLID, func() error { ran = true; return nil }); err != nil || !ran {
+		t.Errorf("WithLayout(%s) = %v, ran=%v", layouts[0].KLID, err, ran)
+	}
+
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+
+	err = ow.ActivateLayout("DEADBEEF")
+	var notInstalled *winput.LayoutNotInstalledError
+	if !errors.Is(err, winput.ErrLayoutNotInstalled) || !errors.As(err, &notInstalled) || len(notInstalled.Installed) != len(layouts) {
+		t.Errorf("expected LayoutNotInstalledError listing %d layouts, got %v", len(layouts), err)
+	}
+	if err := ow.ActivateLayout(layouts[0].KLID); err != nil {
+		t.Errorf("ActivateLayout(%s) failed: %v", layouts[0].KLID, err)
+	}
+}
+
+func TestVirtualKeys(t *testing.T) {
+	keys := []winput.Key{
+		winput.KeyEsc, winput.Key1, winput.Key2, winput.Key3, winput.Key4, winput.Key5, winput.Key6, winput.Key7,
+		winput.Key8, winput.Key9, winput.Key0, winput.KeyMinus, winput.KeyEqual, winput.KeyBkSp, winput.KeyTab, winput.KeyQ,
+		winput.KeyW, winput.KeyE, winput.KeyR, winput.KeyT, winput.KeyY, winput.KeyU, winput.KeyI, winput.KeyO,
+		winput.KeyP, winput.KeyLBr, winput.KeyRBr, winput.KeyEnter, winput.KeyCtrl, winput.KeyA, winput.KeyS, winput.KeyD,
+		winput.KeyF, winput.KeyG, winput.KeyH, winput.KeyJ, winput.KeyK, winput.KeyL, winput.KeySemi, winput.KeyQuot,
+		winput.KeyTick, winput.KeyShift, winput.KeyBackslash, winput.KeyZ, winput.KeyX, winput.KeyC, winput.KeyV, winput.KeyB,
+		winput.KeyN, winput.KeyM, winput.KeyComma, winput.KeyDot, winput.KeySlash, winput.KeyAlt, winput.KeySpace, winput.KeyCaps,
+		winput.KeyF1, winput.KeyF2, winput.KeyF3, winput.KeyF4, winput.KeyF5, winput.KeyF6, winput.KeyF7, winput.KeyF8,
+		winput.KeyF9, winput.KeyF10, winput.KeyF11, winput.KeyF12, winput.KeyNumLock, winput.KeyScroll, winput.KeyHome, winput.KeyArrowUp,
+		winput.KeyPageUp, winput.KeyLeft, winput.KeyRight, winput.KeyEnd, winput.KeyArrowDown, winput.KeyPageDown, winput.KeyInsert, winput.KeyDelete,
+	}
+	for _, k := range keys {
+		vk := winput.VKFromKey(k)
+		if vk == 0 {
+			t.Errorf("VKFromKey(0x%02X) = 0", k)
+			continue
+		}
+		if got, ok := winput.KeyFromVK(vk); !ok || got != k {
+			t.Errorf("KeyFromVK(VKFromKey(0x%02X) = 0x%02X) = 0x%02X, %v", k, vk, got, ok)
+		}
+	}
+
+	const vkDelete, vkLShift, vkNumpad7, vkLWin = 0x2E, 0xA0, 0x67, 0x5B
+	if k, ok := winput.KeyFromVK(vkDelete); !ok || k != winput.KeyDelete {
+		t.Errorf("KeyFromVK(VK_DELETE) = 0x%02X, %v", k, ok)
+	}
+	if k, ok := winput.KeyFromVK(vkLShift); !ok || k != winput.KeyShift {
+		t.Errorf("KeyFromVK(VK_LSHIFT) = 0x%02X, %v", k, ok)
+	}
+	for _, vk := range []uint16{vkNumpad7, vkLWin} {
+		if k, ok := winput.KeyFromVK(vk); ok {
+			t.Errorf("KeyFromVK(0x%02X) = 0x%02X, want unrepresentable", vk, k)
+		}
+	}
+}
+
+func TestGlobalExtendedKeys(t *testing.T) {
+	winput.SetBackend(winput.BackendMessage)
+
+	ow, err := winput.NewTestWindow(winput.TestWindowOptions{Visible: true, X: 100, Y: 100})
+	if err != nil {
+		t.Fatalf("NewTestWindow failed: %v", err)
+	}
+	defer ow.Close()
+	// Global input goes to the foreground window.
+	window.SetForegroundWindow(ow.HWND)
+	time.Sleep(100 * time.Millisecond)
+	if window.GetForegroundWindow() != ow.HWND {
+		t.Skip("cannot bring the test window to the foreground")
+	}
+
+	keyDown := func(k winput.Key) uintptr {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			m, err := ow.NextMessage(time.Until(deadline))
+			if err != nil {
+				break
+			}
+			if m.Msg == 0x0100 && winput.Key(m.LParam>>16&0xFF) == k { // WM_KEYDOWN
+				return m.LParam
+			}
+		}
+		t.Fatalf("WM_KEYDOWN for 0x%02X not received", k)
+		return 0
+	}
+
+	// KeyNumLock is extended too, but pressing it would toggle the machine's NumLock state.
+	extended := []winput.Key{
+		winput.KeyInsert, winput.KeyDelete, winput.KeyHome, winput.KeyEnd,
+		winput.KeyPageUp, winput.KeyPageDown, winput.KeyArrowUp, winput.KeyArrowDown,
+		winput.KeyLeft, winput.KeyRight,
+	}
+	for _, k := range extended {
+		if err := winput.Press(k); err != nil {
+			t.Fatalf("Press(0x%02X) failed: %v", k, err)
+		}
+		if lp := keyDown(k); lp&(1<<24) == 0 {
+			t.Errorf("WM_KEYDOWN for 0x%02X lacks the extended bit (LPARAM 0x%08X)", k, lp)
+		}
+	}
+
+	// The left modifiers share codes with the right ones but must not arrive as them.
+	for _, k := range []winput.Key{winput.KeyA, winput.KeyCtrl} {
+		if err := winput.Press(k); err != nil {
+			t.Fatalf("Press(0x%02X) failed: %v", k, err)
+		}
+		if lp := keyDown(k); lp&(1<<24) != 0 {
+			t.Errorf("WM_KEYDOWN for 0x%02X has the extended bit (LPARAM 0x%08X)", k, lp)
+		}
+	}
+}
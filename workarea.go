@@ -0,0 +1,63 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/rpdg/winput/screen"
+	"github.com/rpdg/winput/window"
+)
+
+var workAreaGuard atomic.Bool
+
+// SetWorkAreaGuard enables or disables the work-area guard for the global mouse functions
+// (MoveMouseTo, ClickMouseAt, DoubleClickMouseAt, ClickRightMouseAt, ClickMiddleMouseAt).
+// While enabled, a target on the taskbar or another docked app bar of its monitor is clamped
+// into that monitor's work area before the action, and the call returns an
+// *OutsideWorkAreaError after performing it, so callers learn the point was not where they
+// thought. Auto-hide taskbars are guarded too, although they do not reserve a work area.
+// Targets outside every monitor are left alone.
+func SetWorkAreaGuard(enabled bool) {
+	workAreaGuard.Store(enabled)
+}
+
+// OutsideWorkAreaError reports a target that the work-area guard moved.
+type OutsideWorkAreaError struct {
+	X, Y             int32 // requested target
+	ActualX, ActualY int32 // clamped target the action was performed at
+	Monitor          screen.Monitor
+}
+
+func (e *OutsideWorkAreaError) Error() string {
+	return fmt.Sprintf("%v: (%d,%d) is on the taskbar or an app bar, used (%d,%d)",
+		ErrOutsideWorkArea, e.X, e.Y, e.ActualX, e.ActualY)
+}
+
+func (e *OutsideWorkAreaError) Unwrap() error {
+	return ErrOutsideWorkArea
+}
+
+// guardWorkArea applies the work-area guard to a global target. It returns the point to use
+// and, if the point was moved, the error to report once the action has been performed.
+func guardWorkArea(x, y int32) (int32, int32, error) {
+	if !workAreaGuard.Load() {
+		return x, y, nil
+	}
+	// Look up only the monitor under the point; this runs on every global mouse call.
+	h := window.MonitorFromPoint(x, y)
+	if h == 0 {
+		return x, y, nil
+	}
+	m, err := screen.MonitorInfo(h)
+	if err != nil {
+		return x, y, nil
+	}
+	p := screen.Point{X: x, Y: y}
+	if m.IsInWorkArea(p) {
+		return x, y, nil
+	}
+	c := m.ClampToWorkArea(p)
+	return c.X, c.Y, &OutsideWorkAreaError{X: x, Y: y, ActualX: c.X, ActualY: c.Y, Monitor: m}
+}
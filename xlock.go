@@ -0,0 +1,157 @@
+//go:build windows
+
+package winput
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/rpdg/winput/window"
+)
+
+const (
+	waitObject0   = 0x00000000
+	waitAbandoned = 0x00000080
+	waitTimeout   = 0x00000102
+)
+
+// crossLock is a named Win32 mutex shared by cooperating processes. A mutex is owned by
+// the thread that acquired it, so all waits and releases run on one locked OS thread.
+type crossLock struct {
+	reqs chan xlockReq
+}
+
+type xlockReq struct {
+	op      int // 0 acquire, 1 release, 2 close
+	timeout time.Duration
+	done    chan error
+}
+
+var (
+	xlockMu      sync.Mutex
+	xlock        *crossLock
+	xlockTimeout = 5 * time.Second
+)
+
+// SetCrossProcessLock makes HID input (and sessions acquired while BackendHID is selected)
+// also hold a named system mutex, so several winput-based programs using the HID device
+// take turns instead of interleaving strokes. All cooperating programs must use the same name;
+// prefix it with `Global\` to coordinate across logon sessions. An empty name disables the lock.
+//
+// Message-backend operations do not take it, since they target distinct HWNDs.
+// A mutex abandoned by a crashed process is recovered by the next waiter.
+func SetCrossProcessLock(name string) error {
+	var l *crossLock
+	if name != "" {
+		var err error
+		if l, err = newCrossLock(name); err != nil {
+			return err
+		}
+	}
+
+	// Swap only while no input operation is in flight.
+	inputSem <- struct{}{}
+	defer func() { <-inputSem }()
+
+	xlockMu.Lock()
+	old := xlock
+	xlock = l
+	xlockMu.Unlock()
+	if old != nil {
+		old.call(xlockReq{op: 2})
+	}
+	return nil
+}
+
+// SetCrossProcessLockTimeout sets how long to wait for another process to release the
+// cross-process lock before failing with ErrInputBusy (default 5s).
+func SetCrossProcessLockTimeout(timeout time.Duration) {
+	xlockMu.Lock()
+	defer xlockMu.Unlock()
+	xlockTimeout = timeout
+}
+
+// lockCrossProcess acquires the cross-process lock if one is configured and the HID backend
+// is selected. The returned release func is never nil.
+func lockCrossProcess() (func(), error) {
+	xlockMu.Lock()
+	l, timeout := xlock, xlockTimeout
+	xlockMu.Unlock()
+
+	if l == nil || getBackend() != BackendHID {
+		return func() {}, nil
+	}
+	if err := l.call(xlockReq{op: 0, timeout: timeout}); err != nil {
+		return nil, err
+	}
+	return func() { l.call(xlockReq{op: 1}) }, nil
+}
+
+func newCrossLock(name string) (*crossLock, error) {
+	p, err := syscall.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, err
+	}
+	l := &crossLock{reqs: make(chan xlockReq)}
+	ready := make(chan error, 1)
+	go l.run(p, ready)
+	if err := <-ready; err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *crossLock) call(req xlockReq) error {
+	req.done = make(chan error, 1)
+	l.reqs <- req
+	return <-req.done
+}
+
+func (l *crossLock) run(name *uint16, ready chan<- error) {
+	// The thread stays locked for the goroutine's lifetime and exits with it.
+	runtime.LockOSThread()
+
+	h, _, e := window.ProcCreateMutexW.Call(0, 0, uintptr(unsafe.Pointer(name)))
+	if h == 0 {
+		ready <- fmt.Errorf("CreateMutexW failed: %v", e)
+		return
+	}
+	ready <- nil
+
+	held := false
+	for req := range l.reqs {
+		switch req.op {
+		case 0:
+			ev, err := syscall.WaitForSingleObject(syscall.Handle(h), uint32(req.timeout/time.Millisecond))
+			switch {
+			case err != nil:
+				req.done <- fmt.Errorf("WaitForSingleObject failed: %v", err)
+			case ev == waitObject0, ev == waitAbandoned:
+				// WAIT_ABANDONED: the previous owner died holding it; ownership passes to us.
+				held = true
+				req.done <- nil
+			case ev == waitTimeout:
+				req.done <- ErrInputBusy
+			default:
+				req.done <- fmt.Errorf("WaitForSingleObject returned 0x%X", ev)
+			}
+		case 1:
+			if held {
+				window.ProcReleaseMutex.Call(h)
+				held = false
+			}
+			req.done <- nil
+		case 2:
+			if held {
+				window.ProcReleaseMutex.Call(h)
+			}
+			window.ProcCloseHandle.Call(h)
+			req.done <- nil
+			return
+		}
+	}
+}